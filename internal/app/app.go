@@ -29,6 +29,8 @@ type App struct {
 }
 
 func (a *App) Start(ctx *context.Context, checkErr func(err error)) {
+	checkErr(repository.RegisterAuditCallbacks(a.StitchDB))
+
 	go func(ctx *context.Context) {
 
 		//App startup essentials
@@ -67,7 +69,7 @@ func (a *App) Migrate(ctx *context.Context, checkErr func(err error)) {
 		// &entities.EmailNotification{},
 		// &entities.EnquiryHistory{},
 		// &entities.Enquiry{},
-		//&entities.Expense{},
+		// &entities.Expense{},
 		// &entities.MasterConfig{},
 		// &entities.Measurement{},
 		// &entities.MeasurementHistory{},
@@ -76,12 +78,27 @@ func (a *App) Migrate(ctx *context.Context, checkErr func(err error)) {
 		// &entities.Order{},
 		// &entities.OrderItem{},
 		// &entities.Person{},
-		&entities.Task{},
+		// &entities.Task{},
 		// &entities.UserChannelDetail{},
 		// &entities.UserConfig{},
 		// &entities.User{},
 		// &entities.WhatsappNotification{},
-		&entities.Task{},
+		// &entities.Payment{},
+		// &entities.Invoice{},
+		// &entities.InvoiceSequence{},
+		// &entities.Attachment{},
+		// &entities.AuditLog{},
+		// &entities.OrderItemAssignment{},
+		// &entities.PayoutEntry{},
+		// &entities.PayoutSettlement{},
+		// &entities.Material{},
+		// &entities.StockMovement{},
+		// &entities.Vendor{},
+		// &entities.ExpenseCategory{},
+		// &entities.RecurringExpense{},
+		// &entities.ExpenseBudget{},
+		// &entities.Appointment{},
+		&entities.ApiKey{},
 	}
 
 	//************************//
@@ -97,5 +114,5 @@ func (a *App) Migrate(ctx *context.Context, checkErr func(err error)) {
 
 	//migrator.Migrate(entityList, checkErr)
 
-	migrator.GenerateAlterMigration(entityList, "004_add_task_entity")
+	migrator.GenerateAlterMigration(entityList, "020_add_api_key_entity")
 }
@@ -2,8 +2,10 @@ package app
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/imkarthi24/sf-backend/internal/config"
+	"github.com/imkarthi24/sf-backend/internal/repository"
 	"github.com/imkarthi24/sf-backend/internal/service/base"
 	tsk "github.com/imkarthi24/sf-backend/internal/task"
 	_log "github.com/loop-kar/pixie/log"
@@ -26,14 +28,47 @@ type Task struct {
 // Sample task runner
 func (a *Task) Start(ctx *context.Context, checkErr func(err error)) {
 	_log.InitLogger(a.NewRelic)
+	checkErr(repository.RegisterAuditCallbacks(a.ChitDb))
 
-	// Run notification task at 9AM IST
-	// _, err := a.Cron.AddFunc("0 9 * * * *", func() {
-	// 	// a.NotificationRunnerTask(ctx)
-	// 	a.FeeCallBackReminderTask(ctx)
-	// })
+	// Deliver pending notifications, skipping a run while the previous one is still in progress
+	notificationJob := cron.NewChain(cron.SkipIfStillRunning(cron.DefaultLogger)).Then(cron.FuncJob(func() {
+		a.NotificationRunnerTask(ctx)
+	}))
+	_, err := a.Cron.AddJob(a.Config.Job.NotificationCron, notificationJob)
 
-	// checkErr(err)
+	checkErr(err)
+
+	// Remind the channel owners of the materials running low on stock
+	lowStockJob := cron.NewChain(cron.SkipIfStillRunning(cron.DefaultLogger)).Then(cron.FuncJob(func() {
+		a.LowStockCheckTask(ctx)
+	}))
+	_, err = a.Cron.AddJob(a.Config.Job.LowStockCron, lowStockJob)
+
+	checkErr(err)
+
+	// Record the rent, salaries etc due today from the recurring expenses
+	recurringExpenseJob := cron.NewChain(cron.SkipIfStillRunning(cron.DefaultLogger)).Then(cron.FuncJob(func() {
+		a.RecurringExpenseTask(ctx)
+	}))
+	_, err = a.Cron.AddJob(a.Config.Job.RecurringExpenseCron, recurringExpenseJob)
+
+	checkErr(err)
+
+	// Queue the reminders of the upcoming appointments
+	appointmentReminderJob := cron.NewChain(cron.SkipIfStillRunning(cron.DefaultLogger)).Then(cron.FuncJob(func() {
+		a.AppointmentReminderTask(ctx)
+	}))
+	_, err = a.Cron.AddJob(a.Config.Job.AppointmentReminderCron, appointmentReminderJob)
+
+	checkErr(err)
+
+	// Remind the staff of the enquiries to call back today
+	enquiryCallbackJob := cron.NewChain(cron.SkipIfStillRunning(cron.DefaultLogger)).Then(cron.FuncJob(func() {
+		a.EnquiryCallbackTask(ctx)
+	}))
+	_, err = a.Cron.AddJob(a.Config.Job.EnquiryCallbackCron, enquiryCallbackJob)
+
+	checkErr(err)
 
 	// Run student reminder task at 10AM IST
 	// _, err = a.Cron.AddFunc("0 10 * * * *", func() {
@@ -61,6 +96,39 @@ func (a *Task) NotificationRunnerTask(ctx *context.Context) {
 
 }
 
+func (a *Task) LowStockCheckTask(ctx *context.Context) {
+	// every run uses its own context, the transaction stored in the context is not shared with the other runs and jobs
+	jobCtx := context.Background()
+	err := a.BaseService.InventoryService.CheckLowStock(&jobCtx)
+	if err != nil {
+		_log.FromCtx(&jobCtx).Error(fmt.Sprintf("Low stock check failed: %v", err))
+	}
+}
+
+func (a *Task) RecurringExpenseTask(ctx *context.Context) {
+	jobCtx := context.Background()
+	err := a.BaseService.BudgetService.RecordRecurringExpenses(&jobCtx)
+	if err != nil {
+		_log.FromCtx(&jobCtx).Error(fmt.Sprintf("Recording recurring expenses failed: %v", err))
+	}
+}
+
+func (a *Task) AppointmentReminderTask(ctx *context.Context) {
+	jobCtx := context.Background()
+	err := a.BaseService.AppointmentService.QueueReminders(&jobCtx)
+	if err != nil {
+		_log.FromCtx(&jobCtx).Error(fmt.Sprintf("Queueing appointment reminders failed: %v", err))
+	}
+}
+
+func (a *Task) EnquiryCallbackTask(ctx *context.Context) {
+	jobCtx := context.Background()
+	err := a.BaseService.EnquiryService.RemindCallbacks(&jobCtx)
+	if err != nil {
+		_log.FromCtx(&jobCtx).Error(fmt.Sprintf("Enquiry callback reminders failed: %v", err))
+	}
+}
+
 func (a *Task) Shutdown(ctx *context.Context, checkErr func(err error)) {
 	// Stop the cron scheduler
 	if a.Cron != nil {
@@ -4,6 +4,7 @@ import (
 	"flag"
 	"io"
 	"os"
+	"strings"
 
 	"github.com/loop-kar/pixie/config"
 )
@@ -20,8 +21,11 @@ type AppConfig struct {
 	SMTP     SMTPConfig     `mapstructure:"smtp"`
 	Site     SiteConfig     `mapstructure:"site"`
 	Config   Config         `mapstructure:"config"`
+	Job      JobConfig      `mapstructure:"job"`
+	Whatsapp WhatsappConfig `mapstructure:"whatsapp"`
 	NewRelic NewRelicConfig `mapstructure:"log"`
 	S3Config S3Config       `mapstructure:"s3Config"`
+	Storage  StorageConfig  `mapstructure:"storage"`
 }
 
 type ServerConfig struct {
@@ -32,6 +36,13 @@ type ServerConfig struct {
 	JwtSecretKey     string `mapstructure:"jwtSecretKey"`
 	JwtExpiryMinutes int64  `mapstructure:"jwtExpiryMinutes"`
 	SecretKey        string `mapstructure:"secretKey"`
+
+	// Requests allowed per minute from a client of an api key on the external endpoints
+	ExternalRateLimit int `mapstructure:"externalRateLimit"`
+
+	// Comma separated ips or cidrs of the proxies in front of the server,
+	// the forwarded headers are read for the client ip only on the requests coming through them
+	TrustedProxies string `mapstructure:"trustedProxies"`
 }
 
 type SMTPConfig struct {
@@ -52,6 +63,28 @@ type Config struct {
 	UseJobService bool `mapstructure:"useJobService"`
 }
 
+// JobConfig holds the schedule and retry policy of the background jobs.
+// Cron expressions include the seconds field.
+type JobConfig struct {
+	NotificationCron           string `mapstructure:"notificationCron"`
+	NotificationMaxRetries     int    `mapstructure:"notificationMaxRetries"`
+	NotificationBackoffSeconds int    `mapstructure:"notificationBackoffSeconds"`
+	LowStockCron               string `mapstructure:"lowStockCron"`
+	RecurringExpenseCron       string `mapstructure:"recurringExpenseCron"`
+	AppointmentReminderCron    string `mapstructure:"appointmentReminderCron"`
+	EnquiryCallbackCron        string `mapstructure:"enquiryCallbackCron"`
+}
+
+// WhatsappConfig holds the WhatsApp Business-API credentials.
+// Provider is either "http" (default) or "fake" which only records the messages.
+type WhatsappConfig struct {
+	Provider           string `mapstructure:"provider"`
+	BaseURL            string `mapstructure:"baseUrl"`
+	PhoneNumberId      string `mapstructure:"phoneNumberId"`
+	AccessToken        string `mapstructure:"accessToken"`
+	DefaultCountryCode string `mapstructure:"defaultCountryCode"`
+}
+
 type DatabaseConfig struct {
 	Host     string `mapstructure:"host"`
 	DBName   string `mapstructure:"name"`
@@ -76,6 +109,16 @@ type S3Config struct {
 	ForceHTTPS      bool   `mapstructure:"forceHTTPS"`
 }
 
+// StorageConfig selects where the attachments are stored.
+// Provider is "s3" (uses S3Config) or "local" (uses LocalPath).
+// PublicBaseURL is the scheme and host the local downloads are served on, eg: https://api.example.com
+type StorageConfig struct {
+	Provider         string `mapstructure:"provider"`
+	LocalPath        string `mapstructure:"localPath"`
+	URLExpiryMinutes int    `mapstructure:"urlExpiryMinutes"`
+	PublicBaseURL    string `mapstructure:"publicBaseUrl"`
+}
+
 var configFile string
 
 func init() {
@@ -110,6 +153,9 @@ func LoadConfig(configReader io.ReadCloser) (AppConfig, error) {
 		"server.secretKey":        "SECRET_KEY",
 		"server.AppName":          "APP_NAME",
 
+		"server.externalRateLimit": "EXTERNAL_RATE_LIMIT",
+		"server.trustedProxies":    "TRUSTED_PROXIES",
+
 		"database.host":     "DB_HOST",
 		"database.name":     "DB_NAME",
 		"database.port":     "DB_PORT",
@@ -130,6 +176,20 @@ func LoadConfig(configReader io.ReadCloser) (AppConfig, error) {
 
 		"config.useJobService": "USE_JOB_SERVICE",
 
+		"job.notificationCron":           "JOB_NOTIFICATION_CRON",
+		"job.notificationMaxRetries":     "JOB_NOTIFICATION_MAX_RETRIES",
+		"job.notificationBackoffSeconds": "JOB_NOTIFICATION_BACKOFF_SECONDS",
+		"job.lowStockCron":               "JOB_LOW_STOCK_CRON",
+		"job.recurringExpenseCron":       "JOB_RECURRING_EXPENSE_CRON",
+		"job.appointmentReminderCron":    "JOB_APPOINTMENT_REMINDER_CRON",
+		"job.enquiryCallbackCron":        "JOB_ENQUIRY_CALLBACK_CRON",
+
+		"whatsapp.provider":           "WHATSAPP_PROVIDER",
+		"whatsapp.baseUrl":            "WHATSAPP_BASE_URL",
+		"whatsapp.phoneNumberId":      "WHATSAPP_PHONE_NUMBER_ID",
+		"whatsapp.accessToken":        "WHATSAPP_ACCESS_TOKEN",
+		"whatsapp.defaultCountryCode": "WHATSAPP_DEFAULT_COUNTRY_CODE",
+
 		"log.license": "NEW_RELIC_LICENSE_KEY",
 
 		"s3Config.region":          "S3_REGION",
@@ -139,6 +199,11 @@ func LoadConfig(configReader io.ReadCloser) (AppConfig, error) {
 		"s3Config.endpoint":        "S3_ENDPOINT",
 		"s3Config.usePathStyle":    "S3_USE_PATH_STYLE", // true
 		"s3Config.forceHTTPS":      "S3_FORCE_HTTPS",    // true
+
+		"storage.provider":         "STORAGE_PROVIDER",
+		"storage.localPath":        "STORAGE_LOCAL_PATH",
+		"storage.urlExpiryMinutes": "STORAGE_URL_EXPIRY_MINUTES",
+		"storage.publicBaseUrl":    "STORAGE_PUBLIC_BASE_URL",
 	}
 
 	err := config.LoadConfig(configReader, keysToEnvVars, &cfg)
@@ -147,5 +212,77 @@ func LoadConfig(configReader io.ReadCloser) (AppConfig, error) {
 		return cfg, err
 	}
 
+	setServerDefaults(&cfg.Server)
+	setJobDefaults(&cfg.Job)
+	setWhatsappDefaults(&cfg.Whatsapp)
+	setStorageDefaults(&cfg.Storage, cfg.S3Config, cfg.Server)
+
 	return cfg, nil
 }
+
+func setServerDefaults(server *ServerConfig) {
+	if server.ExternalRateLimit <= 0 {
+		server.ExternalRateLimit = 10
+	}
+}
+
+func setJobDefaults(job *JobConfig) {
+	// every 5 minutes
+	if job.NotificationCron == "" {
+		job.NotificationCron = "0 */5 * * * *"
+	}
+	if job.NotificationMaxRetries <= 0 {
+		job.NotificationMaxRetries = 5
+	}
+	if job.NotificationBackoffSeconds <= 0 {
+		job.NotificationBackoffSeconds = 60
+	}
+	// every day at 9 AM
+	if job.LowStockCron == "" {
+		job.LowStockCron = "0 0 9 * * *"
+	}
+	// every day at 12:30 AM
+	if job.RecurringExpenseCron == "" {
+		job.RecurringExpenseCron = "0 30 0 * * *"
+	}
+	// every 15 minutes
+	if job.AppointmentReminderCron == "" {
+		job.AppointmentReminderCron = "0 */15 * * * *"
+	}
+	// every day at 8 AM
+	if job.EnquiryCallbackCron == "" {
+		job.EnquiryCallbackCron = "0 0 8 * * *"
+	}
+}
+
+func setWhatsappDefaults(whatsapp *WhatsappConfig) {
+	if whatsapp.Provider == "" {
+		whatsapp.Provider = "http"
+	}
+	if whatsapp.BaseURL == "" {
+		whatsapp.BaseURL = "https://graph.facebook.com/v20.0"
+	}
+	if whatsapp.DefaultCountryCode == "" {
+		whatsapp.DefaultCountryCode = "91"
+	}
+}
+
+func setStorageDefaults(storage *StorageConfig, s3Config S3Config, server ServerConfig) {
+	if storage.Provider == "" {
+		storage.Provider = "local"
+		if s3Config.Bucket != "" {
+			storage.Provider = "s3"
+		}
+	}
+	if storage.LocalPath == "" {
+		storage.LocalPath = "uploads"
+	}
+	if storage.URLExpiryMinutes <= 0 {
+		storage.URLExpiryMinutes = 15
+	}
+	// the server host is host:port without a scheme
+	if storage.PublicBaseURL == "" {
+		storage.PublicBaseURL = "http://" + server.Host
+	}
+	storage.PublicBaseURL = strings.TrimSuffix(storage.PublicBaseURL, "/")
+}
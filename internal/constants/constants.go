@@ -5,7 +5,56 @@ const (
 	PASSWORD_RESET_HTML_TEMPLATE         = "passwordReset.htm"
 	PASSWORD_RESET_SUCCESS_HTML_TEMPLATE = "passwordResetSuccess.htm"
 	USER_CREATED_HTML_TEMPLATE           = "userCreated.htm"
+	CALLBACK_DIGEST_HTML_TEMPLATE        = "enquiryCallbackDigest.htm"
 )
 
+// Invoice Template
+const (
+	INVOICE_TEMPLATE_DIR     = "templates/invoice_templates"
+	DEFAULT_INVOICE_TEMPLATE = "invoice.json"
+	DEFAULT_INVOICE_PREFIX   = "INV"
+)
+
+// Notification Template
+const (
+	NOTIFICATION_TEMPLATE_DIR   = "templates/notification_templates"
+	ORDER_NOTIFICATION_TEMPLATE = "order_events.json"
+
+	APPOINTMENT_NOTIFICATION_TEMPLATE = "appointment_reminders.json"
+)
+
+// Attachments
+const ATTACHMENT_MAX_SIZE = 10 << 20 // 10 MB
+
+// Master Config keys (Type.Name)
+const (
+	CONFIG_CHANNEL_NAME     = "Channel.Name"
+	CONFIG_CHANNEL_ADDRESS  = "Channel.Address"
+	CONFIG_CHANNEL_PHONE    = "Channel.PhoneNumber"
+	CONFIG_CHANNEL_EMAIL    = "Channel.Email"
+	CONFIG_CHANNEL_GSTIN    = "Channel.GSTIN"
+	CONFIG_INVOICE_PREFIX   = "Invoice.Prefix"
+	CONFIG_INVOICE_TEMPLATE = "Invoice.Template"
+
+	CONFIG_ORDER_STATUS_TRANSITIONS = "Order.StatusTransitions"
+
+	CONFIG_ACCESS_PERMISSIONS = "Access.Permissions"
+
+	CONFIG_NOTIFICATION_ORDER_EVENTS    = "Notification.OrderEvents"
+	CONFIG_NOTIFICATION_ORDER_TEMPLATES = "Notification.OrderTemplates"
+
+	CONFIG_NOTIFICATION_APPOINTMENT_REMINDERS = "Notification.AppointmentReminders"
+	CONFIG_NOTIFICATION_APPOINTMENT_TEMPLATES = "Notification.AppointmentTemplates"
+
+	CONFIG_APPOINTMENT_REMINDER_HOURS = "Appointment.ReminderHours"
+
+	CONFIG_MEASUREMENT_DEFAULT_UNIT = "Measurement.DefaultUnit"
+
+	CONFIG_INVENTORY_LOW_STOCK_THRESHOLDS = "Inventory.LowStockThresholds"
+)
+
+// Prefix of the public identifier of the api keys
+const API_KEY_ID_PREFIX = "sfk_"
+
 const PASSWORD_RESET_UI_PATH = "reset-password"
 const FORGOT_PASSWORD_UI_PATH = "forgot-password"
@@ -5,3 +5,16 @@ const API_PREFIX_V1 = "api/sf/v1"
 const (
 	HEALTH = "/health"
 )
+
+// Serves the signed downloads of the local attachment storage
+const ATTACHMENT_FILE = "/attachment/file"
+
+// Headers of the requests signed with an api key
+const (
+	API_KEY_ID_HEADER        = "X-Api-Key-Id"
+	API_KEY_TIMESTAMP_HEADER = "X-Timestamp"
+	API_KEY_SIGNATURE_HEADER = "X-Signature"
+
+	// ip of the visitor, forwarded by the backend which signs the request on behalf of a form
+	API_KEY_CLIENT_IP_HEADER = "X-Client-Ip"
+)
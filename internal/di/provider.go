@@ -1,7 +1,12 @@
 package di
 
 import (
+	"fmt"
+
 	"github.com/imkarthi24/sf-backend/internal/config"
+	"github.com/imkarthi24/sf-backend/internal/constants"
+	"github.com/imkarthi24/sf-backend/internal/utils/storage"
+	"github.com/imkarthi24/sf-backend/internal/utils/whatsapp"
 	"github.com/loop-kar/pixie/db"
 	pkgservice "github.com/loop-kar/pixie/service"
 	pkgemail "github.com/loop-kar/pixie/service/email"
@@ -38,3 +43,33 @@ func ProvideDatabaseConnectionParams(dbConfig config.DatabaseConfig) db.Database
 		Schema:   dbConfig.Schema,
 	}
 }
+
+// ProvideWhatsappSender maps the internal config to the WhatsApp sender
+func ProvideWhatsappSender(whatsappConfig config.WhatsappConfig) whatsapp.Sender {
+	return whatsapp.NewSender(whatsappConfig.Provider, whatsapp.Config{
+		BaseURL:       whatsappConfig.BaseURL,
+		PhoneNumberId: whatsappConfig.PhoneNumberId,
+		AccessToken:   whatsappConfig.AccessToken,
+	})
+}
+
+// ProvideStorage returns the attachment storage of the configured provider
+func ProvideStorage(appConfig config.AppConfig) storage.Storage {
+	if appConfig.Storage.Provider == storage.ProviderS3 {
+		return storage.NewS3Storage(storage.S3Config{
+			Region:          appConfig.S3Config.Region,
+			Bucket:          appConfig.S3Config.Bucket,
+			AccessKeyID:     appConfig.S3Config.AccessKeyID,
+			SecretAccessKey: appConfig.S3Config.SecretAccessKey,
+			Endpoint:        appConfig.S3Config.Endpoint,
+			UsePathStyle:    appConfig.S3Config.UsePathStyle,
+			ForceHTTPS:      appConfig.S3Config.ForceHTTPS,
+		})
+	}
+
+	return storage.NewLocalStorage(storage.LocalConfig{
+		BasePath:    appConfig.Storage.LocalPath,
+		DownloadURL: fmt.Sprintf("%s/%s%s", appConfig.Storage.PublicBaseURL, constants.API_PREFIX_V1, constants.ATTACHMENT_FILE),
+		SecretKey:   appConfig.Server.SecretKey,
+	})
+}
@@ -26,7 +26,7 @@ import (
 
 var appConfigSet = wire.NewSet(
 	config.ProvideAppConfig,
-	wire.FieldsOf(new(config.AppConfig), "SMTP", "Server", "Database"),
+	wire.FieldsOf(new(config.AppConfig), "SMTP", "Server", "Database", "Job", "Whatsapp", "Storage"),
 	// wire.FieldsOf(new(config.AppConfig), "Server"),
 	// wire.FieldsOf(new(config.AppConfig), "Database"),
 )
@@ -34,6 +34,8 @@ var appConfigSet = wire.NewSet(
 var pkgServiceSet = wire.NewSet(
 	ProvideServiceContainer,
 	wire.FieldsOf(new(*pkgservice.Service), "EmailService"),
+	ProvideWhatsappSender,
+	ProvideStorage,
 )
 
 var handlerSet = wire.NewSet(
@@ -55,6 +57,17 @@ var handlerSet = wire.NewSet(
 	handler.ProvideEnquiryHistoryHandler,
 	handler.ProvideExpenseTrackerHandler,
 	handler.ProvideTaskHandler,
+	handler.ProvidePaymentHandler,
+	handler.ProvideAttachmentHandler,
+	handler.ProvideDashboardHandler,
+	handler.ProvideAuditLogHandler,
+	handler.ProvideOrderItemAssignmentHandler,
+	handler.ProvidePayoutHandler,
+	handler.ProvideInventoryHandler,
+	handler.ProvideVendorHandler,
+	handler.ProvideBudgetHandler,
+	handler.ProvideAppointmentHandler,
+	handler.ProvideApiKeyHandler,
 )
 var logSet = wire.NewSet(
 	newreliclog.ProvideNewRelic,
@@ -93,6 +106,18 @@ var svcSet = wire.NewSet(
 	service.ProvideEnquiryHistoryService,
 	service.ProvideExpenseTrackerService,
 	service.ProvideTaskService,
+	service.ProvidePaymentService,
+	service.ProvideInvoiceService,
+	service.ProvideAttachmentService,
+	service.ProvideDashboardService,
+	service.ProvideAuditLogService,
+	service.ProvideOrderItemAssignmentService,
+	service.ProvidePayoutService,
+	service.ProvideInventoryService,
+	service.ProvideVendorService,
+	service.ProvideBudgetService,
+	service.ProvideAppointmentService,
+	service.ProvideApiKeyService,
 )
 
 var baseSvc = wire.NewSet(
@@ -118,6 +143,18 @@ var repoSet = wire.NewSet(
 	repository.ProvideEnquiryHistoryRepository,
 	repository.ProvideExpenseTrackerRepository,
 	repository.ProvideTaskRepository,
+	repository.ProvidePaymentRepository,
+	repository.ProvideInvoiceRepository,
+	repository.ProvideAttachmentRepository,
+	repository.ProvideDashboardRepository,
+	repository.ProvideAuditLogRepository,
+	repository.ProvideOrderItemAssignmentRepository,
+	repository.ProvidePayoutRepository,
+	repository.ProvideInventoryRepository,
+	repository.ProvideVendorRepository,
+	repository.ProvideBudgetRepository,
+	repository.ProvideAppointmentRepository,
+	repository.ProvideApiKeyRepository,
 )
 
 var cronSet = wire.NewSet(
@@ -62,40 +62,83 @@ func InitApp(ctx *context.Context) (*app.App, error) {
 	customerService := service.ProvideCustomerService(customerRepository, personRepository, mapperMapper, responseMapper)
 	customerHandler := handler.ProvideCustomerHandler(customerService)
 	enquiryRepository := repository.ProvideEnquiryRepository(gormDAL)
-	enquiryService := service.ProvideEnquiryService(enquiryRepository, customerRepository, mapperMapper, responseMapper)
+	enquiryHistoryRepository := repository.ProvideEnquiryHistoryRepository(gormDAL)
+	taskRepository := repository.ProvideTaskRepository(gormDAL)
+	notificationRepository := repository.ProvideNotificationRepository(gormDAL)
+	smtpConfig := appConfig.SMTP
+	jobConfig := appConfig.Job
+	whatsappConfig := appConfig.Whatsapp
+	sender := ProvideWhatsappSender(whatsappConfig)
+	notificationService := service.ProvideNotificationService(notificationRepository, masterConfigService, mapperMapper, smtpConfig, jobConfig, whatsappConfig, emailService, sender)
+	enquiryService := service.ProvideEnquiryService(enquiryRepository, customerRepository, enquiryHistoryRepository, taskRepository, channelRepository, notificationService, mapperMapper, responseMapper)
 	enquiryHandler := handler.ProvideEnquiryHandler(enquiryService)
 	orderRepository := repository.ProvideOrderRepository(gormDAL)
 	orderHistoryRepository := repository.ProvideOrderHistoryRepository(gormDAL)
-	orderService := service.ProvideOrderService(orderRepository, orderHistoryRepository, mapperMapper, responseMapper)
-	orderHandler := handler.ProvideOrderHandler(orderService)
+	orderItemAssignmentRepository := repository.ProvideOrderItemAssignmentRepository(gormDAL)
+	orderService := service.ProvideOrderService(orderRepository, orderHistoryRepository, orderItemAssignmentRepository, masterConfigService, notificationService, mapperMapper, responseMapper)
+	invoiceRepository := repository.ProvideInvoiceRepository(gormDAL)
+	invoiceService := service.ProvideInvoiceService(invoiceRepository, orderRepository, masterConfigService)
+	orderHandler := handler.ProvideOrderHandler(orderService, invoiceService)
 	orderItemRepository := repository.ProvideOrderItemRepository(gormDAL)
-	orderItemService := service.ProvideOrderItemService(orderItemRepository, mapperMapper, responseMapper)
+	orderItemService := service.ProvideOrderItemService(orderItemRepository, orderHistoryRepository, mapperMapper, responseMapper)
 	orderItemHandler := handler.ProvideOrderItemHandler(orderItemService)
 	measurementRepository := repository.ProvideMeasurementRepository(gormDAL)
 	measurementHistoryRepository := repository.ProvideMeasurementHistoryRepository(gormDAL)
-	measurementService := service.ProvideMeasurementService(measurementRepository, measurementHistoryRepository, mapperMapper, responseMapper)
+	dressTypeRepository := repository.ProvideDressTypeRepository(gormDAL)
+	measurementService := service.ProvideMeasurementService(measurementRepository, measurementHistoryRepository, dressTypeRepository, masterConfigService, mapperMapper, responseMapper)
 	measurementHandler := handler.ProvideMeasurementHandler(measurementService)
-	personService := service.ProvidePersonService(personRepository, mapperMapper, responseMapper)
+	personService := service.ProvidePersonService(personRepository, masterConfigService, mapperMapper, responseMapper)
 	personHandler := handler.ProvidePersonHandler(personService)
-	dressTypeRepository := repository.ProvideDressTypeRepository(gormDAL)
 	dressTypeService := service.ProvideDressTypeService(dressTypeRepository, mapperMapper, responseMapper)
 	dressTypeHandler := handler.ProvideDressTypeHandler(dressTypeService)
 	orderHistoryService := service.ProvideOrderHistoryService(orderHistoryRepository, mapperMapper, responseMapper)
 	orderHistoryHandler := handler.ProvideOrderHistoryHandler(orderHistoryService)
-	measurementHistoryService := service.ProvideMeasurementHistoryService(measurementHistoryRepository, mapperMapper, responseMapper)
+	measurementHistoryService := service.ProvideMeasurementHistoryService(measurementHistoryRepository, masterConfigService, mapperMapper, responseMapper)
 	measurementHistoryHandler := handler.ProvideMeasurementHistoryHandler(measurementHistoryService)
-	enquiryHistoryRepository := repository.ProvideEnquiryHistoryRepository(gormDAL)
 	enquiryHistoryService := service.ProvideEnquiryHistoryService(enquiryHistoryRepository, mapperMapper, responseMapper)
 	enquiryHistoryHandler := handler.ProvideEnquiryHistoryHandler(enquiryHistoryService)
 	expenseTrackerRepository := repository.ProvideExpenseTrackerRepository(gormDAL)
-	expenseTrackerService := service.ProvideExpenseTrackerService(expenseTrackerRepository, mapperMapper, responseMapper)
+	inventoryRepository := repository.ProvideInventoryRepository(gormDAL)
+	vendorRepository := repository.ProvideVendorRepository(gormDAL)
+	budgetRepository := repository.ProvideBudgetRepository(gormDAL)
+	expenseTrackerService := service.ProvideExpenseTrackerService(expenseTrackerRepository, inventoryRepository, vendorRepository, budgetRepository, mapperMapper, responseMapper)
 	expenseTrackerHandler := handler.ProvideExpenseTrackerHandler(expenseTrackerService)
-	taskRepository := repository.ProvideTaskRepository(gormDAL)
 	taskService := service.ProvideTaskService(taskRepository, mapperMapper, responseMapper)
 	taskHandler := handler.ProvideTaskHandler(taskService)
-	baseHandler := base.ProvideBaseHandler(health, userHandler, channelHandler, masterConfigHandler, adminHandler, customerHandler, enquiryHandler, orderHandler, orderItemHandler, measurementHandler, personHandler, dressTypeHandler, orderHistoryHandler, measurementHistoryHandler, enquiryHistoryHandler, expenseTrackerHandler, taskHandler)
+	paymentRepository := repository.ProvidePaymentRepository(gormDAL)
+	paymentService := service.ProvidePaymentService(paymentRepository, orderRepository, mapperMapper, responseMapper)
+	paymentHandler := handler.ProvidePaymentHandler(paymentService)
+	attachmentRepository := repository.ProvideAttachmentRepository(gormDAL)
+	storage := ProvideStorage(appConfig)
+	storageConfig := appConfig.Storage
+	attachmentService := service.ProvideAttachmentService(attachmentRepository, storage, storageConfig, responseMapper)
+	attachmentHandler := handler.ProvideAttachmentHandler(attachmentService)
+	dashboardRepository := repository.ProvideDashboardRepository(gormDAL)
+	dashboardService := service.ProvideDashboardService(dashboardRepository)
+	dashboardHandler := handler.ProvideDashboardHandler(dashboardService)
+	auditLogRepository := repository.ProvideAuditLogRepository(gormDAL)
+	auditLogService := service.ProvideAuditLogService(auditLogRepository, responseMapper)
+	auditLogHandler := handler.ProvideAuditLogHandler(auditLogService)
+	payoutRepository := repository.ProvidePayoutRepository(gormDAL)
+	payoutService := service.ProvidePayoutService(payoutRepository, userRepository, responseMapper)
+	orderItemAssignmentService := service.ProvideOrderItemAssignmentService(orderItemAssignmentRepository, orderItemRepository, userRepository, orderService, payoutService, mapperMapper, responseMapper)
+	orderItemAssignmentHandler := handler.ProvideOrderItemAssignmentHandler(orderItemAssignmentService)
+	payoutHandler := handler.ProvidePayoutHandler(payoutService)
+	inventoryService := service.ProvideInventoryService(inventoryRepository, expenseTrackerRepository, orderItemRepository, taskRepository, channelRepository, masterConfigService, mapperMapper, responseMapper)
+	inventoryHandler := handler.ProvideInventoryHandler(inventoryService)
+	vendorService := service.ProvideVendorService(vendorRepository, mapperMapper, responseMapper)
+	vendorHandler := handler.ProvideVendorHandler(vendorService)
+	budgetService := service.ProvideBudgetService(budgetRepository, expenseTrackerRepository, vendorRepository, channelRepository, mapperMapper, responseMapper)
+	budgetHandler := handler.ProvideBudgetHandler(budgetService)
+	appointmentRepository := repository.ProvideAppointmentRepository(gormDAL)
+	appointmentService := service.ProvideAppointmentService(appointmentRepository, customerRepository, orderRepository, userRepository, channelRepository, masterConfigService, notificationService, mapperMapper, responseMapper)
+	appointmentHandler := handler.ProvideAppointmentHandler(appointmentService)
+	apiKeyRepository := repository.ProvideApiKeyRepository(gormDAL)
+	apiKeyService := service.ProvideApiKeyService(apiKeyRepository, appConfig, mapperMapper, responseMapper)
+	apiKeyHandler := handler.ProvideApiKeyHandler(apiKeyService, masterConfigService)
+	baseHandler := base.ProvideBaseHandler(health, userHandler, channelHandler, masterConfigHandler, adminHandler, customerHandler, enquiryHandler, orderHandler, orderItemHandler, measurementHandler, personHandler, dressTypeHandler, orderHistoryHandler, measurementHistoryHandler, enquiryHistoryHandler, expenseTrackerHandler, taskHandler, paymentHandler, attachmentHandler, dashboardHandler, auditLogHandler, orderItemAssignmentHandler, payoutHandler, inventoryHandler, vendorHandler, budgetHandler, appointmentHandler, apiKeyHandler)
 	serverConfig := appConfig.Server
-	engine := router.InitRouter(baseHandler, serverConfig)
+	engine := router.InitRouter(baseHandler, serverConfig, masterConfigService, apiKeyService)
 	application := newreliclog.ProvideNewRelic(appConfig)
 	appApp := &app.App{
 		Server:                 engine,
@@ -128,34 +171,48 @@ func InitJobService(ctx *context.Context) (*app.Task, error) {
 	emailService := serviceService.EmailService
 	userService := service.ProvideUserService(userRepository, channelRepository, mapperMapper, appConfig, responseMapper, emailService)
 	notificationRepository := repository.ProvideNotificationRepository(gormDAL)
-	smtpConfig := appConfig.SMTP
-	notificationService := service.ProvideNotificationService(notificationRepository, mapperMapper, smtpConfig, emailService)
-	channelService := service.ProvideChannelService(channelRepository, userRepository, mapperMapper, responseMapper)
 	masterConfigRepository := repository.ProvideMasterConfigRepository(gormDAL)
 	masterConfigService := service.ProvideMasterConfigService(masterConfigRepository, mapperMapper, appConfig, responseMapper)
+	smtpConfig := appConfig.SMTP
+	jobConfig := appConfig.Job
+	whatsappConfig := appConfig.Whatsapp
+	sender := ProvideWhatsappSender(whatsappConfig)
+	notificationService := service.ProvideNotificationService(notificationRepository, masterConfigService, mapperMapper, smtpConfig, jobConfig, whatsappConfig, emailService, sender)
+	channelService := service.ProvideChannelService(channelRepository, userRepository, mapperMapper, responseMapper)
 	customerRepository := repository.ProvideCustomerRepository(gormDAL)
 	personRepository := repository.ProvidePersonRepository(gormDAL)
 	customerService := service.ProvideCustomerService(customerRepository, personRepository, mapperMapper, responseMapper)
 	enquiryRepository := repository.ProvideEnquiryRepository(gormDAL)
-	enquiryService := service.ProvideEnquiryService(enquiryRepository, customerRepository, mapperMapper, responseMapper)
+	enquiryHistoryRepository := repository.ProvideEnquiryHistoryRepository(gormDAL)
+	taskRepository := repository.ProvideTaskRepository(gormDAL)
+	enquiryService := service.ProvideEnquiryService(enquiryRepository, customerRepository, enquiryHistoryRepository, taskRepository, channelRepository, notificationService, mapperMapper, responseMapper)
 	orderRepository := repository.ProvideOrderRepository(gormDAL)
 	orderHistoryRepository := repository.ProvideOrderHistoryRepository(gormDAL)
-	orderService := service.ProvideOrderService(orderRepository, orderHistoryRepository, mapperMapper, responseMapper)
+	orderItemAssignmentRepository := repository.ProvideOrderItemAssignmentRepository(gormDAL)
+	orderService := service.ProvideOrderService(orderRepository, orderHistoryRepository, orderItemAssignmentRepository, masterConfigService, notificationService, mapperMapper, responseMapper)
 	orderItemRepository := repository.ProvideOrderItemRepository(gormDAL)
-	orderItemService := service.ProvideOrderItemService(orderItemRepository, mapperMapper, responseMapper)
+	orderItemService := service.ProvideOrderItemService(orderItemRepository, orderHistoryRepository, mapperMapper, responseMapper)
 	measurementRepository := repository.ProvideMeasurementRepository(gormDAL)
 	measurementHistoryRepository := repository.ProvideMeasurementHistoryRepository(gormDAL)
-	measurementService := service.ProvideMeasurementService(measurementRepository, measurementHistoryRepository, mapperMapper, responseMapper)
-	personService := service.ProvidePersonService(personRepository, mapperMapper, responseMapper)
 	dressTypeRepository := repository.ProvideDressTypeRepository(gormDAL)
+	measurementService := service.ProvideMeasurementService(measurementRepository, measurementHistoryRepository, dressTypeRepository, masterConfigService, mapperMapper, responseMapper)
+	personService := service.ProvidePersonService(personRepository, masterConfigService, mapperMapper, responseMapper)
 	dressTypeService := service.ProvideDressTypeService(dressTypeRepository, mapperMapper, responseMapper)
 	orderHistoryService := service.ProvideOrderHistoryService(orderHistoryRepository, mapperMapper, responseMapper)
-	measurementHistoryService := service.ProvideMeasurementHistoryService(measurementHistoryRepository, mapperMapper, responseMapper)
+	measurementHistoryService := service.ProvideMeasurementHistoryService(measurementHistoryRepository, masterConfigService, mapperMapper, responseMapper)
 	expenseTrackerRepository := repository.ProvideExpenseTrackerRepository(gormDAL)
-	expenseTrackerService := service.ProvideExpenseTrackerService(expenseTrackerRepository, mapperMapper, responseMapper)
-	taskRepository := repository.ProvideTaskRepository(gormDAL)
+	inventoryRepository := repository.ProvideInventoryRepository(gormDAL)
+	vendorRepository := repository.ProvideVendorRepository(gormDAL)
+	budgetRepository := repository.ProvideBudgetRepository(gormDAL)
+	expenseTrackerService := service.ProvideExpenseTrackerService(expenseTrackerRepository, inventoryRepository, vendorRepository, budgetRepository, mapperMapper, responseMapper)
 	taskService := service.ProvideTaskService(taskRepository, mapperMapper, responseMapper)
-	baseService := base2.ProvideBaseService(userService, notificationService, channelService, masterConfigService, customerService, enquiryService, orderService, orderItemService, measurementService, personService, dressTypeService, orderHistoryService, measurementHistoryService, expenseTrackerService, taskService)
+	paymentRepository := repository.ProvidePaymentRepository(gormDAL)
+	paymentService := service.ProvidePaymentService(paymentRepository, orderRepository, mapperMapper, responseMapper)
+	inventoryService := service.ProvideInventoryService(inventoryRepository, expenseTrackerRepository, orderItemRepository, taskRepository, channelRepository, masterConfigService, mapperMapper, responseMapper)
+	budgetService := service.ProvideBudgetService(budgetRepository, expenseTrackerRepository, vendorRepository, channelRepository, mapperMapper, responseMapper)
+	appointmentRepository := repository.ProvideAppointmentRepository(gormDAL)
+	appointmentService := service.ProvideAppointmentService(appointmentRepository, customerRepository, orderRepository, userRepository, channelRepository, masterConfigService, notificationService, mapperMapper, responseMapper)
+	baseService := base2.ProvideBaseService(userService, notificationService, channelService, masterConfigService, customerService, enquiryService, orderService, orderItemService, measurementService, personService, dressTypeService, orderHistoryService, measurementHistoryService, expenseTrackerService, taskService, paymentService, inventoryService, budgetService, appointmentService)
 	application := newreliclog.ProvideNewRelic(appConfig)
 	cronCron := cron.ProvideCron()
 	task := &app.Task{
@@ -170,13 +227,13 @@ func InitJobService(ctx *context.Context) (*app.Task, error) {
 
 // wire.go:
 
-var appConfigSet = wire.NewSet(config.ProvideAppConfig, wire.FieldsOf(new(config.AppConfig), "SMTP", "Server", "Database"))
+var appConfigSet = wire.NewSet(config.ProvideAppConfig, wire.FieldsOf(new(config.AppConfig), "SMTP", "Server", "Database", "Job", "Whatsapp", "Storage"))
 
 var pkgServiceSet = wire.NewSet(
-	ProvideServiceContainer, wire.FieldsOf(new(*service2.Service), "EmailService"),
+	ProvideServiceContainer, wire.FieldsOf(new(*service2.Service), "EmailService"), ProvideWhatsappSender, ProvideStorage,
 )
 
-var handlerSet = wire.NewSet(base.ProvideHealthHandler, base.ProvideBaseHandler, handler.ProvideUserHandler, handler.ProvideChannelHandler, handler.ProvideMasterConfigHandler, handler.ProvideAdminHandler, handler.ProvideCustomerHandler, handler.ProvideEnquiryHandler, handler.ProvideOrderHandler, handler.ProvideOrderItemHandler, handler.ProvideMeasurementHandler, handler.ProvidePersonHandler, handler.ProvideDressTypeHandler, handler.ProvideOrderHistoryHandler, handler.ProvideMeasurementHistoryHandler, handler.ProvideEnquiryHistoryHandler, handler.ProvideExpenseTrackerHandler, handler.ProvideTaskHandler)
+var handlerSet = wire.NewSet(base.ProvideHealthHandler, base.ProvideBaseHandler, handler.ProvideUserHandler, handler.ProvideChannelHandler, handler.ProvideMasterConfigHandler, handler.ProvideAdminHandler, handler.ProvideCustomerHandler, handler.ProvideEnquiryHandler, handler.ProvideOrderHandler, handler.ProvideOrderItemHandler, handler.ProvideMeasurementHandler, handler.ProvidePersonHandler, handler.ProvideDressTypeHandler, handler.ProvideOrderHistoryHandler, handler.ProvideMeasurementHistoryHandler, handler.ProvideEnquiryHistoryHandler, handler.ProvideExpenseTrackerHandler, handler.ProvideTaskHandler, handler.ProvidePaymentHandler, handler.ProvideAttachmentHandler, handler.ProvideDashboardHandler, handler.ProvideAuditLogHandler, handler.ProvideOrderItemAssignmentHandler, handler.ProvidePayoutHandler, handler.ProvideInventoryHandler, handler.ProvideVendorHandler, handler.ProvideBudgetHandler, handler.ProvideAppointmentHandler, handler.ProvideApiKeyHandler)
 
 var logSet = wire.NewSet(newreliclog.ProvideNewRelic)
 
@@ -188,10 +245,10 @@ var dbSet = wire.NewSet(
 
 var mapperSet = wire.NewSet(mapper.ProvideMapper, mapper.ProvideResponseMapper)
 
-var svcSet = wire.NewSet(service.ProvideUserService, service.ProvideNotificationService, service.ProvideChannelService, service.ProvideMasterConfigService, service.ProvideAdminService, service.ProvideCustomerService, service.ProvideEnquiryService, service.ProvideOrderService, service.ProvideOrderItemService, service.ProvideMeasurementService, service.ProvidePersonService, service.ProvideDressTypeService, service.ProvideOrderHistoryService, service.ProvideMeasurementHistoryService, service.ProvideEnquiryHistoryService, service.ProvideExpenseTrackerService, service.ProvideTaskService)
+var svcSet = wire.NewSet(service.ProvideUserService, service.ProvideNotificationService, service.ProvideChannelService, service.ProvideMasterConfigService, service.ProvideAdminService, service.ProvideCustomerService, service.ProvideEnquiryService, service.ProvideOrderService, service.ProvideOrderItemService, service.ProvideMeasurementService, service.ProvidePersonService, service.ProvideDressTypeService, service.ProvideOrderHistoryService, service.ProvideMeasurementHistoryService, service.ProvideEnquiryHistoryService, service.ProvideExpenseTrackerService, service.ProvideTaskService, service.ProvidePaymentService, service.ProvideInvoiceService, service.ProvideAttachmentService, service.ProvideDashboardService, service.ProvideAuditLogService, service.ProvideOrderItemAssignmentService, service.ProvidePayoutService, service.ProvideInventoryService, service.ProvideVendorService, service.ProvideBudgetService, service.ProvideAppointmentService, service.ProvideApiKeyService)
 
 var baseSvc = wire.NewSet(base2.ProvideBaseService)
 
-var repoSet = wire.NewSet(repository.ProvideGormDAL, repository.ProvideUserRepository, repository.ProvideNotificationRepository, repository.ProvideChannelRepository, repository.ProvideMasterConfigRepository, repository.ProvideAdminRepository, repository.ProvideCustomerRepository, repository.ProvideEnquiryRepository, repository.ProvideOrderRepository, repository.ProvideOrderItemRepository, repository.ProvideMeasurementRepository, repository.ProvidePersonRepository, repository.ProvideDressTypeRepository, repository.ProvideOrderHistoryRepository, repository.ProvideMeasurementHistoryRepository, repository.ProvideEnquiryHistoryRepository, repository.ProvideExpenseTrackerRepository, repository.ProvideTaskRepository)
+var repoSet = wire.NewSet(repository.ProvideGormDAL, repository.ProvideUserRepository, repository.ProvideNotificationRepository, repository.ProvideChannelRepository, repository.ProvideMasterConfigRepository, repository.ProvideAdminRepository, repository.ProvideCustomerRepository, repository.ProvideEnquiryRepository, repository.ProvideOrderRepository, repository.ProvideOrderItemRepository, repository.ProvideMeasurementRepository, repository.ProvidePersonRepository, repository.ProvideDressTypeRepository, repository.ProvideOrderHistoryRepository, repository.ProvideMeasurementHistoryRepository, repository.ProvideEnquiryHistoryRepository, repository.ProvideExpenseTrackerRepository, repository.ProvideTaskRepository, repository.ProvidePaymentRepository, repository.ProvideInvoiceRepository, repository.ProvideAttachmentRepository, repository.ProvideDashboardRepository, repository.ProvideAuditLogRepository, repository.ProvideOrderItemAssignmentRepository, repository.ProvidePayoutRepository, repository.ProvideInventoryRepository, repository.ProvideVendorRepository, repository.ProvideBudgetRepository, repository.ProvideAppointmentRepository, repository.ProvideApiKeyRepository)
 
 var cronSet = wire.NewSet(cron.ProvideCron)
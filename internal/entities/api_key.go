@@ -0,0 +1,48 @@
+package entities
+
+import "time"
+
+type ApiKeyStatus string
+
+const (
+	ApiKeyStatusActive  ApiKeyStatus = "ACTIVE"
+	ApiKeyStatusExpired ApiKeyStatus = "EXPIRED"
+	ApiKeyStatusRevoked ApiKeyStatus = "REVOKED"
+)
+
+// ApiKey authorizes the signed requests of an external integration on the data of a channel.
+// The secret is never stored, it is derived from the server secret key and the salt of the key
+// and only its hash is kept to detect a change of the server secret key.
+type ApiKey struct {
+	*Model `mapstructure:",squash"`
+
+	Name string `gorm:"not null" json:"name"`
+
+	// KeyId is the public identifier of the key, sent in the X-Api-Key-Id header
+	KeyId      string `gorm:"uniqueIndex;not null" json:"keyId"`
+	Salt       string `gorm:"not null" json:"-"`
+	SecretHash string `gorm:"not null" json:"-"`
+
+	// Comma-separated permissions of the key in the "resource:action" form (e.g., "master-config:read,enquiry:create")
+	Scopes string `gorm:"type:text;not null" json:"scopes"`
+
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+
+	Channel *Channel `gorm:"foreignKey:ChannelId" json:"-"`
+}
+
+func (ApiKey) TableNameForQuery() string {
+	return "\"stich\".\"ApiKeys\" E"
+}
+
+func (k ApiKey) Status(now time.Time) ApiKeyStatus {
+	if k.RevokedAt != nil || (k.Model != nil && !k.IsActive) {
+		return ApiKeyStatusRevoked
+	}
+	if k.ExpiresAt != nil && !now.Before(*k.ExpiresAt) {
+		return ApiKeyStatusExpired
+	}
+	return ApiKeyStatusActive
+}
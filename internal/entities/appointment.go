@@ -0,0 +1,69 @@
+package entities
+
+import "time"
+
+type AppointmentType string
+
+const (
+	AppointmentTypeMeasurement AppointmentType = "MEASUREMENT"
+	AppointmentTypeFitting     AppointmentType = "FITTING"
+	AppointmentTypePickup      AppointmentType = "PICKUP"
+)
+
+var AppointmentTypes = map[AppointmentType]bool{
+	AppointmentTypeMeasurement: true,
+	AppointmentTypeFitting:     true,
+	AppointmentTypePickup:      true,
+}
+
+type AppointmentStatus string
+
+const (
+	AppointmentStatusScheduled AppointmentStatus = "SCHEDULED"
+	AppointmentStatusCompleted AppointmentStatus = "COMPLETED"
+	AppointmentStatusCancelled AppointmentStatus = "CANCELLED"
+	AppointmentStatusNoShow    AppointmentStatus = "NO_SHOW"
+)
+
+// AppointmentStatusTransitions is the allowed status flow of an appointment.
+// A cancelled or missed appointment is booked again as a new appointment.
+var AppointmentStatusTransitions = map[AppointmentStatus][]AppointmentStatus{
+	AppointmentStatusScheduled: {AppointmentStatusCompleted, AppointmentStatusCancelled, AppointmentStatusNoShow},
+	AppointmentStatusCompleted: {AppointmentStatusScheduled},
+	AppointmentStatusCancelled: {},
+	AppointmentStatusNoShow:    {},
+}
+
+// Appointment is a slot booked with a staff for the measurement, fitting or pickup of a customer
+type Appointment struct {
+	*Model `mapstructure:",squash"`
+
+	Type   AppointmentType   `gorm:"type:text;not null" json:"type"`
+	Status AppointmentStatus `gorm:"type:text;not null" json:"status"`
+
+	StartTime time.Time `gorm:"not null" json:"startTime"`
+	EndTime   time.Time `gorm:"not null" json:"endTime"`
+
+	Notes string `gorm:"type:text" json:"notes"`
+
+	// ReminderQueuedAt is set once the reminder of the slot is queued, a rescheduled appointment is reminded again
+	ReminderQueuedAt *time.Time `json:"reminderQueuedAt,omitempty"`
+
+	CustomerId uint      `gorm:"not null" json:"customerId"`
+	Customer   *Customer `gorm:"foreignKey:CustomerId" json:"customer,omitempty"`
+
+	OrderId *uint  `json:"orderId,omitempty"`
+	Order   *Order `gorm:"foreignKey:OrderId" json:"order,omitempty"`
+
+	StaffId uint  `gorm:"not null" json:"staffId"`
+	Staff   *User `gorm:"foreignKey:StaffId" json:"staff,omitempty"`
+}
+
+func (Appointment) TableNameForQuery() string {
+	return "\"stich\".\"Appointments\" E"
+}
+
+// BlocksSlot is true when the staff is booked for the slot of the appointment
+func (a Appointment) BlocksSlot() bool {
+	return a.Status == AppointmentStatusScheduled || a.Status == AppointmentStatusCompleted
+}
@@ -0,0 +1,30 @@
+package entities
+
+// Entities that files can be attached to
+var AttachableEntities = map[EntityName]bool{
+	Entity_Customer:    true,
+	Entity_Order:       true,
+	Entity_OrderItem:   true,
+	Entity_Measurement: true,
+}
+
+// Attachment is a file (fabric photo, design sketch, reference image etc.) attached to an entity.
+// The file itself lives in the storage under StorageKey.
+type Attachment struct {
+	*Model `mapstructure:",squash"`
+
+	EntityType  EntityName `gorm:"type:text;not null" json:"entityType"`
+	EntityId    uint       `gorm:"not null" json:"entityId"`
+	Kind        string     `json:"kind"`
+	FileName    string     `gorm:"not null" json:"fileName"`
+	ContentType string     `json:"contentType"`
+	Size        int64      `json:"size"`
+	StorageKey  string     `gorm:"not null" json:"-"`
+
+	UploadedById *uint `json:"uploadedById"`
+	UploadedBy   *User `gorm:"foreignKey:UploadedById" json:"uploadedBy"`
+}
+
+func (Attachment) TableNameForQuery() string {
+	return "\"stich\".\"Attachments\" E"
+}
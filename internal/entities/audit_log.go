@@ -0,0 +1,36 @@
+package entities
+
+import (
+	"time"
+
+	entitiy_types "github.com/imkarthi24/sf-backend/internal/entities/types"
+)
+
+type AuditAction string
+
+const (
+	AuditActionCreate AuditAction = "CREATE"
+	AuditActionUpdate AuditAction = "UPDATE"
+	AuditActionDelete AuditAction = "DELETE"
+)
+
+// AuditLog is recorded by the gorm audit callbacks for every create, update and delete of an entity
+type AuditLog struct {
+	*Model `mapstructure:",squash"`
+
+	EntityName EntityName  `gorm:"type:text;not null" json:"entityName"`
+	EntityId   uint        `json:"entityId"`
+	Action     AuditAction `gorm:"type:text;not null" json:"action"`
+
+	// Changed columns as {"column": {"old": .., "new": ..}}
+	Changes entitiy_types.JSON `gorm:"type:jsonb" json:"changes,omitempty"`
+
+	ActorId *uint `json:"actorId,omitempty"`
+	Actor   *User `gorm:"foreignKey:ActorId" json:"-"`
+
+	PerformedAt time.Time `gorm:"not null" json:"performedAt"`
+}
+
+func (AuditLog) TableNameForQuery() string {
+	return "\"stich\".\"AuditLogs\" E"
+}
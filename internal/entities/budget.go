@@ -0,0 +1,70 @@
+package entities
+
+import "time"
+
+// BudgetMonthLayout is the format of the budget months in the requests and responses
+const BudgetMonthLayout = "2006-01"
+
+type RecurringFrequency string
+
+const (
+	RecurringFrequencyMonthly   RecurringFrequency = "MONTHLY"
+	RecurringFrequencyQuarterly RecurringFrequency = "QUARTERLY"
+	RecurringFrequencyYearly    RecurringFrequency = "YEARLY"
+)
+
+// ExpenseCategory groups the expenses for budgeting, eg: Rent, Salaries, Utilities, Fabric
+type ExpenseCategory struct {
+	*Model `mapstructure:",squash"`
+
+	Name        string  `gorm:"not null" json:"name"`
+	Description *string `json:"description,omitempty"`
+}
+
+func (ExpenseCategory) TableNameForQuery() string {
+	return "\"stich\".\"ExpenseCategories\" E"
+}
+
+// RecurringExpense is a template of a bill paid every period, eg: rent, salaries.
+// The cron scheduler records an Expense from it on every due date till the end date.
+type RecurringExpense struct {
+	*Model `mapstructure:",squash"`
+
+	Name        string             `gorm:"not null" json:"name"`
+	Frequency   RecurringFrequency `gorm:"type:text;not null" json:"frequency"`
+	StartDate   time.Time          `gorm:"not null" json:"startDate"` // the day of the month of the due dates
+	EndDate     *time.Time         `json:"endDate,omitempty"`
+	NextDueDate time.Time          `gorm:"not null" json:"nextDueDate"`
+
+	CompanyName string  `json:"companyName"`
+	Material    string  `json:"material"`
+	Price       float64 `json:"price"`
+	Location    *string `json:"location,omitempty"`
+	Notes       *string `json:"notes,omitempty"`
+
+	CategoryId *uint            `json:"categoryId,omitempty"`
+	Category   *ExpenseCategory `gorm:"foreignKey:CategoryId" json:"category,omitempty"`
+
+	VendorId *uint   `json:"vendorId,omitempty"`
+	Vendor   *Vendor `gorm:"foreignKey:VendorId" json:"vendor,omitempty"`
+}
+
+func (RecurringExpense) TableNameForQuery() string {
+	return "\"stich\".\"RecurringExpenses\" E"
+}
+
+// ExpenseBudget is the amount planned to be spent on a category in a month
+type ExpenseBudget struct {
+	*Model `mapstructure:",squash"`
+
+	Month  time.Time `gorm:"not null" json:"month"` // the first day of the month
+	Amount float64   `gorm:"not null" json:"amount"`
+	Notes  *string   `json:"notes,omitempty"`
+
+	CategoryId uint             `gorm:"not null" json:"categoryId"`
+	Category   *ExpenseCategory `gorm:"foreignKey:CategoryId" json:"category,omitempty"`
+}
+
+func (ExpenseBudget) TableNameForQuery() string {
+	return "\"stich\".\"ExpenseBudgets\" E"
+}
@@ -16,6 +16,20 @@ const (
 	Entity_Measurement          EntityName = "Measurement"
 	Entity_Order                EntityName = "Order"
 	Entity_OrderItem            EntityName = "OrderItem"
+	Entity_Payment              EntityName = "Payment"
+	Entity_Invoice              EntityName = "Invoice"
+	Entity_Attachment           EntityName = "Attachment"
+	Entity_AuditLog             EntityName = "AuditLog"
+	Entity_OrderItemAssignment  EntityName = "OrderItemAssignment"
+	Entity_PayoutEntry          EntityName = "PayoutEntry"
+	Entity_PayoutSettlement     EntityName = "PayoutSettlement"
+	Entity_Material             EntityName = "Material"
+	Entity_StockMovement        EntityName = "StockMovement"
+	Entity_Vendor               EntityName = "Vendor"
+	Entity_ExpenseCategory      EntityName = "ExpenseCategory"
+	Entity_RecurringExpense     EntityName = "RecurringExpense"
+	Entity_ExpenseBudget        EntityName = "ExpenseBudget"
+	Entity_Appointment          EntityName = "Appointment"
 )
 
 // string to entity name
@@ -1,13 +1,67 @@
 package entities
 
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+)
+
+type MeasurementUnit string
+
+const (
+	MeasurementUnitCm   MeasurementUnit = "cm"
+	MeasurementUnitInch MeasurementUnit = "inch"
+)
+
+// MeasurementField is the definition of a single measurement taken for a dress type, eg: Waist in cm between 20 and 60
+type MeasurementField struct {
+	Name     string          `json:"name"`
+	Unit     MeasurementUnit `json:"unit"`
+	Required bool            `json:"required"`
+	Min      *float64        `json:"min,omitempty"`
+	Max      *float64        `json:"max,omitempty"`
+}
+
+// MeasurementFields is stored as jsonb
+type MeasurementFields []MeasurementField
+
 type DressType struct {
 	*Model `mapstructure:",squash"`
 
 	Name         string `json:"name"`
 	Description  string `json:"description"`
 	Measurements string `json:"measurements"` //CSV of mesurement types Hip, Waist, Chest
+
+	// Structured definition of the measurements, Measurements is kept in sync with the names
+	MeasurementFields MeasurementFields `gorm:"type:jsonb" json:"measurementFields,omitempty"`
 }
 
 func (DressType) TableNameForQuery() string {
 	return "\"stich\".\"DressTypes\" E"
 }
+
+func (f MeasurementFields) Value() (driver.Value, error) {
+	if len(f) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(f)
+}
+
+func (f *MeasurementFields) Scan(value interface{}) error {
+	if value == nil {
+		*f = nil
+		return nil
+	}
+
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return errors.New("failed to unmarshal measurement fields")
+	}
+
+	return json.Unmarshal(bytes, f)
+}
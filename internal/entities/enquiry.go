@@ -9,6 +9,40 @@ const (
 	EnquiryStatusBacklog  EnquiryStatus = "closed"
 )
 
+// Sources of the enquiries captured by the lead forms
+const (
+	EnquirySourceWebsite   string = "website"
+	EnquirySourceInstagram string = "instagram"
+	EnquirySourceFacebook  string = "facebook"
+)
+
+var EnquiryLeadSources = map[string]bool{
+	EnquirySourceWebsite:   true,
+	EnquirySourceInstagram: true,
+	EnquirySourceFacebook:  true,
+}
+
+// EnquiryStatusTransitions is the allowed status flow of an enquiry.
+// A closed enquiry can be reopened, an accepted enquiry can only be closed.
+var EnquiryStatusTransitions = map[EnquiryStatus][]EnquiryStatus{
+	EnquiryStatusNew:      {EnquiryStatusCallback, EnquiryStatusAccepted, EnquiryStatusBacklog},
+	EnquiryStatusCallback: {EnquiryStatusAccepted, EnquiryStatusBacklog},
+	EnquiryStatusAccepted: {EnquiryStatusBacklog},
+	EnquiryStatusBacklog:  {EnquiryStatusNew, EnquiryStatusCallback},
+}
+
+// Enquiry change field constants, recorded as the changed fields of the enquiry history
+const (
+	EnquiryChangeFieldStatus              string = "status"
+	EnquiryChangeFieldSubject             string = "subject"
+	EnquiryChangeFieldNotes               string = "notes"
+	EnquiryChangeFieldSource              string = "source"
+	EnquiryChangeFieldReferredBy          string = "referredBy"
+	EnquiryChangeFieldReferrerPhoneNumber string = "referrerPhoneNumber"
+	EnquiryChangeFieldCustomerId          string = "customerId"
+	EnquiryChangeFieldCustomer            string = "customer" // name or contact details of the customer
+)
+
 type Enquiry struct {
 	*Model `mapstructure:",squash"`
 
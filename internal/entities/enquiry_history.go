@@ -27,6 +27,11 @@ type EnquiryHistory struct {
 	EmployeeId uint  `json:"employeeId,omitempty"`
 	Employee   *User `json:"employee,omitempty"`
 
+	// Set for the histories recorded while updating the enquiry.
+	// Comma-separated list of changed fields (e.g., "status,notes")
+	OldStatus     *EnquiryStatus `gorm:"type:text" json:"oldStatus,omitempty"`
+	ChangedFields string         `json:"changedFields,omitempty"`
+
 	// History tracking fields
 	PerformedAt   time.Time `gorm:"not null" json:"performedAt"`
 	PerformedById uint      `json:"performedById"`
@@ -12,6 +12,16 @@ type Expense struct {
 	Price        float64    `json:"price,omitempty"`
 	Location     *string    `json:"location,omitempty"`
 	Notes        *string    `json:"notes,omitempty"`
+
+	// CompanyName is kept in sync with the name of the vendor
+	VendorId *uint   `json:"vendorId,omitempty"`
+	Vendor   *Vendor `gorm:"foreignKey:VendorId" json:"vendor,omitempty"`
+
+	CategoryId *uint            `json:"categoryId,omitempty"`
+	Category   *ExpenseCategory `gorm:"foreignKey:CategoryId" json:"category,omitempty"`
+
+	// Set on the expenses recorded by the cron scheduler from a recurring expense
+	RecurringExpenseId *uint `json:"recurringExpenseId,omitempty"`
 }
 
 func (Expense) TableNameForQuery() string {
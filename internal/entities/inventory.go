@@ -0,0 +1,51 @@
+package entities
+
+import "time"
+
+type StockMovementType string
+
+const (
+	StockMovementTypeIn         StockMovementType = "IN"         // purchased, usually against an expense bill
+	StockMovementTypeOut        StockMovementType = "OUT"        // consumed by an order item
+	StockMovementTypeAdjustment StockMovementType = "ADJUSTMENT" // stock count correction, wastage etc
+)
+
+// Material is an item of the stock catalogue, eg: fabric, lining, buttons
+type Material struct {
+	*Model `mapstructure:",squash"`
+
+	Name     string  `gorm:"not null" json:"name"`
+	Code     *string `json:"code,omitempty"`
+	Category *string `json:"category,omitempty"`
+	Unit     string  `gorm:"not null" json:"unit"` // eg: METER, PIECE, ROLL
+	Notes    *string `json:"notes,omitempty"`
+}
+
+func (Material) TableNameForQuery() string {
+	return "\"stich\".\"Materials\" E"
+}
+
+// StockMovement is a change in the stock of a material.
+// Quantity is signed, the on hand stock of a material is the sum of its movements.
+type StockMovement struct {
+	*Model `mapstructure:",squash"`
+
+	Type     StockMovementType `gorm:"type:text;not null" json:"type"`
+	Quantity float64           `gorm:"not null" json:"quantity"`
+	UnitCost *float64          `json:"unitCost,omitempty"`
+	MovedAt  time.Time         `gorm:"not null" json:"movedAt"`
+	Notes    *string           `json:"notes,omitempty"`
+
+	MaterialId uint      `gorm:"not null" json:"materialId"`
+	Material   *Material `gorm:"foreignKey:MaterialId" json:"material,omitempty"`
+
+	ExpenseId *uint    `json:"expenseId,omitempty"`
+	Expense   *Expense `gorm:"foreignKey:ExpenseId" json:"expense,omitempty"`
+
+	OrderItemId *uint      `json:"orderItemId,omitempty"`
+	OrderItem   *OrderItem `gorm:"foreignKey:OrderItemId" json:"orderItem,omitempty"`
+}
+
+func (StockMovement) TableNameForQuery() string {
+	return "\"stich\".\"StockMovements\" E"
+}
@@ -0,0 +1,27 @@
+package entities
+
+import "time"
+
+type Invoice struct {
+	*Model `mapstructure:",squash"`
+
+	// Sequence is the running number of the invoice within a channel
+	Sequence      int       `gorm:"not null" json:"sequence"`
+	InvoiceNumber string    `gorm:"not null" json:"invoiceNumber"`
+	InvoiceDate   time.Time `gorm:"not null" json:"invoiceDate"`
+
+	OrderId uint   `json:"orderId"`
+	Order   *Order `gorm:"foreignKey:OrderId" json:"order,omitempty"`
+}
+
+func (Invoice) TableNameForQuery() string {
+	return "\"stich\".\"Invoices\" E"
+}
+
+// InvoiceSequence holds the last invoice sequence allocated in a channel,
+// the row is locked while allocating so that concurrent invoices get distinct numbers
+type InvoiceSequence struct {
+	*Model `mapstructure:",squash"`
+
+	LastSequence int `gorm:"not null" json:"lastSequence"`
+}
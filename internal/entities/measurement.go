@@ -6,6 +6,7 @@ type Measurement struct {
 	*Model `mapstructure:",squash"`
 
 	Value entitiy_types.JSON `gorm:"type:jsonb" json:"values"`
+	Unit  MeasurementUnit    `gorm:"type:text" json:"unit"` // unit of the values, the channel default when empty
 
 	PersonId uint    `json:"personId"`
 	Person   *Person `gorm:"foreignKey:PersonId" json:"person"`
@@ -20,6 +20,7 @@ type MeasurementHistory struct {
 	Action MeasurementHistoryAction `gorm:"type:text;not null" json:"action"`
 
 	OldValues entitiy_types.JSON `gorm:"type:jsonb" json:"oldValues,omitempty"`
+	Unit      MeasurementUnit    `gorm:"type:text" json:"unit,omitempty"` // unit of the old values
 
 	MeasurementId uint         `json:"measurementId"`
 	Measurement   *Measurement `gorm:"foreignKey:MeasurementId" json:"measurement"`
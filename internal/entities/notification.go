@@ -1,5 +1,7 @@
 package entities
 
+import "time"
+
 type NotificationStatus string
 
 const (
@@ -9,12 +11,24 @@ const (
 	NOTIF_FAULTED   NotificationStatus = "FAULTED"
 )
 
+type NotificationChannel string
+
+const (
+	NOTIF_CHANNEL_EMAIL    NotificationChannel = "EMAIL"
+	NOTIF_CHANNEL_WHATSAPP NotificationChannel = "WHATSAPP"
+)
+
 type Notification struct {
 	*Model
 	Status       NotificationStatus `gorm:"default:'PENDING';type:text;not null" json:"status,omitempty"`
 	SourceEntity string             `json:"sourceEntity,omitempty"`
 	EntityId     uint               `json:"entityId,omitempty"`
 
+	// Delivery attempts made so far and when the next one is due.
+	// NextAttemptAt is nil for a notification that has not been tried yet.
+	AttemptCount  int        `gorm:"default:0;not null" json:"attemptCount,omitempty"`
+	NextAttemptAt *time.Time `json:"nextAttemptAt,omitempty"`
+
 	//Children
 	EmailNotifications    []EmailNotification    `json:"-"`
 	WhatsappNotifications []WhatsappNotification `json:"-"`
@@ -17,6 +17,31 @@ const (
 	CANCELLED           OrderStatus = "CANCELLED"
 )
 
+// DefaultOrderStatusTransitions is the allowed status flow of an order.
+// It can be overridden per channel with the Order.StatusTransitions master config
+// which takes the same shape as json, eg: {"DRAFT":["CONFIRMED","CANCELLED"]}
+var DefaultOrderStatusTransitions = map[OrderStatus][]OrderStatus{
+	DRAFT:               {CONFIRMED, CANCELLED},
+	CONFIRMED:           {DESIGN_CONFIRMED, RAW_MATERIAL_SOURCE, CUTTING, CANCELLED},
+	DESIGN_CONFIRMED:    {RAW_MATERIAL_SOURCE, CUTTING, CANCELLED},
+	RAW_MATERIAL_SOURCE: {CUTTING, CANCELLED},
+	CUTTING:             {STITCHING, CANCELLED},
+	STITCHING:           {FINISHING, READY_FOR_DELIVERY, CANCELLED},
+	FINISHING:           {STITCHING, READY_FOR_DELIVERY, CANCELLED},
+	READY_FOR_DELIVERY:  {FINISHING, DELIVERED, CANCELLED},
+	DELIVERED:           {},
+	CANCELLED:           {},
+}
+
+// OrderEvent is a change in the order the customer gets notified about
+type OrderEvent string
+
+const (
+	OrderEventReadyForDelivery    OrderEvent = "READY_FOR_DELIVERY"
+	OrderEventDelivered           OrderEvent = "DELIVERED"
+	OrderEventDeliveryDateChanged OrderEvent = "DELIVERY_DATE_CHANGED"
+)
+
 type Order struct {
 	*Model `mapstructure:",squash"`
 
@@ -36,10 +61,12 @@ type Order struct {
 	OrderTakenBy   *User `gorm:"foreignKey:OrderTakenById" json:"orderTakenBy"`
 
 	OrderItems []OrderItem `gorm:"foreignKey:OrderId" json:"orderItems"`
+	Payments   []Payment   `gorm:"foreignKey:OrderId" json:"payments,omitempty"`
 
 	// Calculated fields (populated via SQL subqueries, not stored in DB)
 	OrderQuantity int     `gorm:"->" json:"-"`
 	OrderValue    float64 `gorm:"->" json:"-"`
+	PaidAmount    float64 `gorm:"->" json:"-"`
 }
 
 func (Order) TableNameForQuery() string {
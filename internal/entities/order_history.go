@@ -12,6 +12,11 @@ const (
 	OrderHistoryActionCreated OrderHistoryAction = "CREATED"
 	OrderHistoryActionUpdated OrderHistoryAction = "UPDATED"
 	OrderHistoryActionDeleted OrderHistoryAction = "DELETED"
+
+	// Item level actions, OrderItemId and OrderItemData are set for these
+	OrderHistoryActionItemAdded    OrderHistoryAction = "ITEM_ADDED"
+	OrderHistoryActionItemModified OrderHistoryAction = "ITEM_MODIFIED"
+	OrderHistoryActionItemRemoved  OrderHistoryAction = "ITEM_REMOVED"
 )
 
 // Order change field constants
@@ -21,6 +26,32 @@ const (
 	OrderChangeFieldDeliveredDate        string = "deliveredDate"
 )
 
+// Order item change field constants
+const (
+	OrderItemChangeFieldDescription          string = "description"
+	OrderItemChangeFieldQuantity             string = "quantity"
+	OrderItemChangeFieldPrice                string = "price"
+	OrderItemChangeFieldAdditionalCharges    string = "additionalCharges"
+	OrderItemChangeFieldTotal                string = "total"
+	OrderItemChangeFieldExpectedDeliveryDate string = "expectedDeliveryDate"
+	OrderItemChangeFieldDeliveredDate        string = "deliveredDate"
+	OrderItemChangeFieldPersonId             string = "personId"
+	OrderItemChangeFieldMeasurementId        string = "measurementId"
+)
+
+// OrderItemHistoryData is stored as the OrderItemData of an item level history.
+// Added items have only the new values, removed items only the old values.
+type OrderItemHistoryData struct {
+	Description string                 `json:"description,omitempty"`
+	Changes     []OrderItemFieldChange `json:"changes"`
+}
+
+type OrderItemFieldChange struct {
+	Field    string      `json:"field"`
+	OldValue interface{} `json:"oldValue,omitempty"`
+	NewValue interface{} `json:"newValue,omitempty"`
+}
+
 type OrderHistory struct {
 	*Model `mapstructure:",squash"`
 
@@ -33,6 +64,9 @@ type OrderHistory struct {
 	ExpectedDeliveryDate *time.Time   `json:"expectedDeliveryDate,omitempty"`
 	DeliveredDate        *time.Time   `json:"deliveredDate,omitempty"`
 
+	// Reason provided while changing the status of the order
+	Reason *string `gorm:"type:text" json:"reason,omitempty"`
+
 	OrderItemId   *uint               `json:"orderItemId,omitempty"`
 	OrderItemData *entitiy_types.JSON `gorm:"type:jsonb" json:"orderItemData,omitempty"`
 
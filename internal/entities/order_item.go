@@ -22,6 +22,8 @@ type OrderItem struct {
 
 	OrderId uint   `json:"orderId"`
 	Order   *Order `gorm:"foreignKey:OrderId" json:"order"`
+
+	Assignments []OrderItemAssignment `gorm:"foreignKey:OrderItemId" json:"assignments,omitempty"`
 }
 
 func (OrderItem) TableNameForQuery() string {
@@ -0,0 +1,69 @@
+package entities
+
+import "time"
+
+type ProductionStage string
+
+const (
+	ProductionStageCutting   ProductionStage = "CUTTING"
+	ProductionStageStitching ProductionStage = "STITCHING"
+	ProductionStageFinishing ProductionStage = "FINISHING"
+)
+
+// ProductionStages is the order in which an item moves through production
+var ProductionStages = []ProductionStage{ProductionStageCutting, ProductionStageStitching, ProductionStageFinishing}
+
+// OrderStatus is the order status of the stage, the stages share their names with the order statuses
+func (s ProductionStage) OrderStatus() OrderStatus {
+	return OrderStatus(s)
+}
+
+type AssignmentStatus string
+
+const (
+	AssignmentStatusPending    AssignmentStatus = "PENDING"
+	AssignmentStatusInProgress AssignmentStatus = "IN_PROGRESS"
+	AssignmentStatusDone       AssignmentStatus = "DONE"
+	AssignmentStatusCancelled  AssignmentStatus = "CANCELLED"
+)
+
+// AssignmentStatusTransitions is the allowed status flow of an assignment.
+// A done assignment can be reopened when the work has to be redone.
+var AssignmentStatusTransitions = map[AssignmentStatus][]AssignmentStatus{
+	AssignmentStatusPending:    {AssignmentStatusInProgress, AssignmentStatusDone, AssignmentStatusCancelled},
+	AssignmentStatusInProgress: {AssignmentStatusPending, AssignmentStatusDone, AssignmentStatusCancelled},
+	AssignmentStatusDone:       {AssignmentStatusInProgress},
+	AssignmentStatusCancelled:  {},
+}
+
+// OrderItemAssignment is the work of a production stage of an order item assigned to a staff or an outsourced tailor
+type OrderItemAssignment struct {
+	*Model `mapstructure:",squash"`
+
+	Stage  ProductionStage  `gorm:"type:text;not null" json:"stage"`
+	Status AssignmentStatus `gorm:"type:text;not null" json:"status"`
+
+	Quantity  int     `json:"quantity"`
+	PieceRate float64 `json:"pieceRate"`
+
+	DueDate     *time.Time `json:"dueDate,omitempty"`
+	StartedAt   *time.Time `json:"startedAt,omitempty"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+
+	Notes string `gorm:"type:text" json:"notes"`
+
+	OrderItemId uint       `gorm:"not null" json:"orderItemId"`
+	OrderItem   *OrderItem `gorm:"foreignKey:OrderItemId" json:"orderItem,omitempty"`
+
+	AssignedToId uint  `gorm:"not null" json:"assignedToId"`
+	AssignedTo   *User `gorm:"foreignKey:AssignedToId" json:"assignedTo,omitempty"`
+}
+
+func (OrderItemAssignment) TableNameForQuery() string {
+	return "\"stich\".\"OrderItemAssignments\" E"
+}
+
+// IsOpen is true while the work of the assignment is yet to be completed
+func (a OrderItemAssignment) IsOpen() bool {
+	return a.Status == AssignmentStatusPending || a.Status == AssignmentStatusInProgress
+}
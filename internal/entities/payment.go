@@ -0,0 +1,52 @@
+package entities
+
+import "time"
+
+type PaymentType string
+
+const (
+	PaymentTypeAdvance PaymentType = "ADVANCE"
+	PaymentTypePart    PaymentType = "PART"
+	PaymentTypeFinal   PaymentType = "FINAL"
+)
+
+type PaymentMethod string
+
+const (
+	PaymentMethodCash         PaymentMethod = "CASH"
+	PaymentMethodUPI          PaymentMethod = "UPI"
+	PaymentMethodCard         PaymentMethod = "CARD"
+	PaymentMethodBankTransfer PaymentMethod = "BANK_TRANSFER"
+	PaymentMethodCheque       PaymentMethod = "CHEQUE"
+)
+
+// PaymentStatus is derived from the order value and the payments received,
+// it is not stored in DB
+type PaymentStatus string
+
+const (
+	PaymentStatusUnpaid        PaymentStatus = "UNPAID"
+	PaymentStatusPartiallyPaid PaymentStatus = "PARTIALLY_PAID"
+	PaymentStatusPaid          PaymentStatus = "PAID"
+)
+
+type Payment struct {
+	*Model `mapstructure:",squash"`
+
+	Amount          float64       `gorm:"not null" json:"amount"`
+	PaymentType     PaymentType   `gorm:"type:text;not null" json:"paymentType"`
+	PaymentMethod   PaymentMethod `gorm:"type:text;not null" json:"paymentMethod"`
+	PaymentDate     *time.Time    `json:"paymentDate,omitempty"`
+	ReferenceNumber *string       `json:"referenceNumber,omitempty"`
+	Notes           *string       `gorm:"type:text" json:"notes,omitempty"`
+
+	OrderId uint   `json:"orderId"`
+	Order   *Order `gorm:"foreignKey:OrderId" json:"order,omitempty"`
+
+	ReceivedById *uint `json:"receivedById,omitempty"`
+	ReceivedBy   *User `gorm:"foreignKey:ReceivedById" json:"receivedBy,omitempty"`
+}
+
+func (Payment) TableNameForQuery() string {
+	return "\"stich\".\"Payments\" E"
+}
@@ -0,0 +1,63 @@
+package entities
+
+import "time"
+
+type PayoutEntryType string
+
+const (
+	PayoutEntryTypeAccrual  PayoutEntryType = "ACCRUAL"  // work of an assignment marked done
+	PayoutEntryTypeReversal PayoutEntryType = "REVERSAL" // settled work which was reopened or repriced, the amount is negative
+)
+
+// PayoutEntry is an amount owed to an outsourced tailor for the work done on an order item.
+// Entries are settled in batches, an entry without a settlement is outstanding.
+type PayoutEntry struct {
+	*Model `mapstructure:",squash"`
+
+	Type        PayoutEntryType `gorm:"type:text;not null" json:"type"`
+	Amount      float64         `gorm:"not null" json:"amount"`
+	Quantity    int             `json:"quantity"`
+	PieceRate   float64         `json:"pieceRate"`
+	Description string          `json:"description"`
+	AccruedAt   time.Time       `gorm:"not null" json:"accruedAt"`
+
+	TailorId uint  `gorm:"not null" json:"tailorId"`
+	Tailor   *User `gorm:"foreignKey:TailorId" json:"tailor,omitempty"`
+
+	AssignmentId uint                 `gorm:"not null" json:"assignmentId"`
+	Assignment   *OrderItemAssignment `gorm:"foreignKey:AssignmentId" json:"assignment,omitempty"`
+
+	SettlementId *uint             `json:"settlementId,omitempty"`
+	Settlement   *PayoutSettlement `gorm:"foreignKey:SettlementId" json:"settlement,omitempty"`
+}
+
+func (PayoutEntry) TableNameForQuery() string {
+	return "\"stich\".\"PayoutEntries\" E"
+}
+
+// PayoutSettlement is a payment made to a tailor settling their outstanding entries
+type PayoutSettlement struct {
+	*Model `mapstructure:",squash"`
+
+	Amount     float64    `gorm:"not null" json:"amount"`
+	EntryCount int        `json:"entryCount"`
+	PeriodFrom *time.Time `json:"periodFrom,omitempty"` // accrual date of the earliest entry settled
+	PeriodTo   time.Time  `gorm:"not null" json:"periodTo"`
+	SettledAt  time.Time  `gorm:"not null" json:"settledAt"`
+
+	PaymentMethod   PaymentMethod `gorm:"type:text;not null" json:"paymentMethod"`
+	ReferenceNumber *string       `json:"referenceNumber,omitempty"`
+	Notes           *string       `json:"notes,omitempty"`
+
+	TailorId uint  `gorm:"not null" json:"tailorId"`
+	Tailor   *User `gorm:"foreignKey:TailorId" json:"tailor,omitempty"`
+
+	SettledById *uint `json:"settledById,omitempty"`
+	SettledBy   *User `gorm:"foreignKey:SettledById" json:"settledBy,omitempty"`
+
+	Entries []PayoutEntry `gorm:"foreignKey:SettlementId" json:"entries,omitempty"`
+}
+
+func (PayoutSettlement) TableNameForQuery() string {
+	return "\"stich\".\"PayoutSettlements\" E"
+}
@@ -0,0 +1,18 @@
+package entities
+
+// Vendor is a supplier the expenses are billed by
+type Vendor struct {
+	*Model `mapstructure:",squash"`
+
+	Name          string  `gorm:"not null" json:"name"`
+	ContactPerson *string `json:"contactPerson,omitempty"`
+	PhoneNumber   *string `json:"phoneNumber,omitempty"`
+	Email         *string `json:"email,omitempty"`
+	GSTIN         *string `gorm:"column:gstin" json:"gstin,omitempty"`
+	Address       *string `json:"address,omitempty"`
+	Notes         *string `json:"notes,omitempty"`
+}
+
+func (Vendor) TableNameForQuery() string {
+	return "\"stich\".\"Vendors\" E"
+}
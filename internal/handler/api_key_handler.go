@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	router "github.com/imkarthi24/sf-backend/internal/router/middleware"
+	"github.com/imkarthi24/sf-backend/internal/service"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/loop-kar/pixie/response"
+	"github.com/loop-kar/pixie/util"
+)
+
+type ApiKeyHandler struct {
+	apiKeySvc       service.ApiKeyService
+	masterConfigSvc service.MasterConfigService
+	resp            response.Response
+	dataResp        response.DataResponse
+}
+
+func ProvideApiKeyHandler(svc service.ApiKeyService, masterConfigSvc service.MasterConfigService) *ApiKeyHandler {
+	return &ApiKeyHandler{apiKeySvc: svc, masterConfigSvc: masterConfigSvc}
+}
+
+// Issue ApiKey
+//
+//	@Summary		Issue ApiKey
+//	@Description	Issues a key for an external integration of the channel. The secret signs the requests and is shown only once. The scopes are limited to the permissions of the user issuing the key
+//	@Tags			ApiKey
+//	@Accept			json
+//	@Success		201		{object}	responseModel.ApiKeySecret
+//	@Failure		400		{object}	response.Response
+//	@Failure		403		{object}	response.Response
+//	@Param			apiKey	body		requestModel.ApiKey	true	"apiKey"
+//	@Router			/api-key [post]
+func (h ApiKeyHandler) Issue(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var apiKey requestModel.ApiKey
+	err := ctx.Bind(&apiKey)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	// the signed requests run with full access, so a key cannot hold more than the user issuing it
+	for _, scope := range apiKey.Scopes {
+		if !router.CanGrant(ctx, h.masterConfigSvc, scope) {
+			x := errs.NewXError(errs.INSUFFICIENT_ACCESS, fmt.Sprintf("Scope %s exceeds the permissions of the user", scope), nil)
+			h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusForbidden)
+			return
+		}
+	}
+
+	issued, errr := h.apiKeySvc.Issue(&context, apiKey)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(issued).FormatAndSend(&context, ctx, http.StatusCreated)
+}
+
+// Rotate ApiKey
+//
+//	@Summary		Rotate ApiKey
+//	@Description	Replaces the secret of the key, the requests signed with the previous secret are rejected. The new secret is shown only once
+//	@Tags			ApiKey
+//	@Accept			json
+//	@Success		202	{object}	responseModel.ApiKeySecret
+//	@Failure		400	{object}	response.Response
+//	@Param			id	path		int	true	"ApiKey id"
+//	@Router			/api-key/{id}/rotate [post]
+func (h ApiKeyHandler) Rotate(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	rotated, errr := h.apiKeySvc.Rotate(&context, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(rotated).FormatAndSend(&context, ctx, http.StatusAccepted)
+}
+
+// Revoke ApiKey
+//
+//	@Summary		Revoke ApiKey
+//	@Description	Revokes the key, the requests signed with it are rejected
+//	@Tags			ApiKey
+//	@Accept			json
+//	@Success		202	{object}	response.Response
+//	@Failure		400	{object}	response.Response
+//	@Param			id	path		int	true	"ApiKey id"
+//	@Router			/api-key/{id}/revoke [patch]
+func (h ApiKeyHandler) Revoke(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	errr := h.apiKeySvc.Revoke(&context, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Revoke success").FormatAndSend(&context, ctx, http.StatusAccepted)
+}
+
+// Get ApiKey
+//
+//	@Summary		Get a specific ApiKey
+//	@Description	Get an instance of ApiKey, the secret is never returned
+//	@Tags			ApiKey
+//	@Accept			json
+//	@Success		200	{object}	responseModel.ApiKey
+//	@Failure		400	{object}	response.DataResponse
+//	@Param			id	path		int	true	"ApiKey id"
+//	@Router			/api-key/{id} [get]
+func (h ApiKeyHandler) Get(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	apiKey, errr := h.apiKeySvc.Get(&context, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(apiKey).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// Get All ApiKeys
+//
+//	@Summary		Get all ApiKeys
+//	@Description	Get all the keys of the channel including the revoked keys
+//	@Tags			ApiKey
+//	@Accept			json
+//	@Success		200	{object}	responseModel.ApiKey
+//	@Failure		400	{object}	response.DataResponse
+//	@Router			/api-key [get]
+func (h ApiKeyHandler) GetAll(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	apiKeys, errr := h.apiKeySvc.GetAll(&context)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(apiKeys).FormatAndSend(&context, ctx, http.StatusOK)
+}
@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	"github.com/imkarthi24/sf-backend/internal/service"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/loop-kar/pixie/response"
+	"github.com/loop-kar/pixie/util"
+)
+
+type AppointmentHandler struct {
+	appointmentSvc service.AppointmentService
+	resp           response.Response
+	dataResp       response.DataResponse
+}
+
+func ProvideAppointmentHandler(svc service.AppointmentService) *AppointmentHandler {
+	return &AppointmentHandler{appointmentSvc: svc}
+}
+
+// SaveAppointment
+//
+//	@Summary		Save Appointment
+//	@Description	Books an appointment with a staff, the slot should not overlap another appointment of the staff
+//	@Tags			Appointment
+//	@Accept			json
+//	@Success		201			{object}	response.Response
+//	@Failure		400			{object}	response.Response
+//	@Param			appointment	body		requestModel.Appointment	true	"appointment"
+//	@Router			/appointment [post]
+func (h AppointmentHandler) SaveAppointment(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var appointment requestModel.Appointment
+	err := ctx.Bind(&appointment)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	errr := h.appointmentSvc.SaveAppointment(&context, appointment)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Save success").FormatAndSend(&context, ctx, http.StatusCreated)
+}
+
+// UpdateAppointment
+//
+//	@Summary		Update Appointment
+//	@Description	Updates or reschedules an appointment, a rescheduled appointment is reminded again
+//	@Tags			Appointment
+//	@Accept			json
+//	@Success		202			{object}	response.Response
+//	@Failure		400			{object}	response.Response
+//	@Param			appointment	body		requestModel.Appointment	true	"appointment"
+//	@Param			id			path		int							true	"Appointment id"
+//	@Router			/appointment/{id} [put]
+func (h AppointmentHandler) UpdateAppointment(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var appointment requestModel.Appointment
+	err := ctx.Bind(&appointment)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	errr := h.appointmentSvc.UpdateAppointment(&context, appointment, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Update success").FormatAndSend(&context, ctx, http.StatusAccepted)
+}
+
+// Update Appointment Status
+//
+//	@Summary		Update Appointment Status
+//	@Description	Moves an appointment to COMPLETED, CANCELLED or NO_SHOW
+//	@Tags			Appointment
+//	@Accept			json
+//	@Success		202		{object}	response.Response
+//	@Failure		400		{object}	response.Response
+//	@Param			status	body		requestModel.Status	true	"status"
+//	@Param			id		path		int					true	"Appointment id"
+//	@Router			/appointment/{id}/status [patch]
+func (h AppointmentHandler) UpdateAppointmentStatus(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var status requestModel.Status
+	err := ctx.Bind(&status)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	errr := h.appointmentSvc.UpdateAppointmentStatus(&context, status, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Status update success").FormatAndSend(&context, ctx, http.StatusAccepted)
+}
+
+// Get Appointment
+//
+//	@Summary		Get a specific Appointment
+//	@Description	Get an instance of Appointment
+//	@Tags			Appointment
+//	@Accept			json
+//	@Success		200	{object}	responseModel.Appointment
+//	@Failure		400	{object}	response.DataResponse
+//	@Param			id	path		int	true	"Appointment id"
+//	@Router			/appointment/{id} [get]
+func (h AppointmentHandler) Get(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	appointment, errr := h.appointmentSvc.Get(&context, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(appointment).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// Get Appointment Calendar
+//
+//	@Summary		Get the appointment calendar
+//	@Description	Get the appointments of a day or a week (Monday to Sunday) grouped by day
+//	@Tags			Appointment
+//	@Accept			json
+//	@Success		200		{object}	responseModel.AppointmentCalendar
+//	@Failure		400		{object}	response.DataResponse
+//	@Param			date	query		string	false	"date (YYYY-MM-DD), defaults to today"
+//	@Param			view	query		string	false	"day or week, defaults to day"
+//	@Param			staffId	query		int		false	"appointments of the staff"
+//	@Router			/appointment/calendar [get]
+func (h AppointmentHandler) GetCalendar(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	date, err := parseDateQuery(ctx, "date")
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, "Invalid date, expected YYYY-MM-DD", err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	staffId, err := parseIdQuery(ctx, "staffId")
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, "Invalid staffId", err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	calendar, errr := h.appointmentSvc.GetCalendar(&context, date, ctx.Query("view"), staffId)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(calendar).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// Delete Appointment
+//
+//	@Summary		Delete Appointment
+//	@Description	Deletes an instance of Appointment
+//	@Tags			Appointment
+//	@Accept			json
+//	@Success		200	{object}	response.Response
+//	@Failure		400	{object}	response.Response
+//	@Param			id	path		int	true	"Appointment id"
+//	@Router			/appointment/{id} [delete]
+func (h AppointmentHandler) Delete(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	err := h.appointmentSvc.Delete(&context, uint(id))
+	if err != nil {
+		h.resp.DefaultFailureResponse(err).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Delete Success").FormatAndSend(&context, ctx, http.StatusOK)
+}
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imkarthi24/sf-backend/internal/service"
+	"github.com/imkarthi24/sf-backend/internal/utils"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/loop-kar/pixie/response"
+	"github.com/loop-kar/pixie/util"
+)
+
+type AttachmentHandler struct {
+	attachmentSvc service.AttachmentService
+	resp          response.Response
+	dataResp      response.DataResponse
+}
+
+func ProvideAttachmentHandler(svc service.AttachmentService) *AttachmentHandler {
+	return &AttachmentHandler{attachmentSvc: svc}
+}
+
+// Upload Attachment
+//
+//	@Summary		Upload Attachment
+//	@Description	Uploads a file and attaches it to an Order, OrderItem, Measurement or Customer
+//	@Tags			Attachment
+//	@Accept			multipart/form-data
+//	@Success		201			{object}	responseModel.Attachment
+//	@Failure		400			{object}	response.Response
+//	@Failure		501			{object}	response.Response
+//	@Param			file		formData	file	true	"file"
+//	@Param			entityType	formData	string	true	"entity type (Order, OrderItem, Measurement, Customer)"
+//	@Param			entityId	formData	int		true	"entity id"
+//	@Param			kind		formData	string	false	"kind (e.g., FABRIC, DESIGN, REFERENCE)"
+//	@Router			/attachment [post]
+func (h AttachmentHandler) Upload(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	entityId, err := strconv.Atoi(ctx.PostForm("entityId"))
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.INCORRECT_PARAMETER, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	file, err := utils.ExtractFile(fileHeader)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+	file.AddEntityInfo(uint(entityId), ctx.PostForm("entityType"), ctx.PostForm("kind"))
+
+	attachment, errr := h.attachmentSvc.Upload(&context, *file)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(attachment).FormatAndSend(&context, ctx, http.StatusCreated)
+}
+
+// Get attachments of an entity
+//
+//	@Summary		Get attachments of an entity
+//	@Description	Get all active attachments of an Order, OrderItem, Measurement or Customer
+//	@Tags			Attachment
+//	@Accept			json
+//	@Success		200			{object}	responseModel.Attachment
+//	@Failure		400			{object}	response.DataResponse
+//	@Param			entityType	path		string	true	"entity type"
+//	@Param			entityId	path		int		true	"entity id"
+//	@Router			/attachment/entity/{entityType}/{entityId} [get]
+func (h AttachmentHandler) GetByEntity(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	entityId, _ := strconv.Atoi(ctx.Param("entityId"))
+
+	attachments, errr := h.attachmentSvc.GetByEntity(&context, ctx.Param("entityType"), uint(entityId))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(attachments).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// Get download url of an Attachment
+//
+//	@Summary		Get download url of an Attachment
+//	@Description	Get a short lived url to download the file
+//	@Tags			Attachment
+//	@Accept			json
+//	@Success		200	{object}	responseModel.FileResponse
+//	@Failure		400	{object}	response.DataResponse
+//	@Param			id	path		int	true	"Attachment id"
+//	@Router			/attachment/{id}/download [get]
+func (h AttachmentHandler) GetDownloadURL(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	file, errr := h.attachmentSvc.GetDownloadURL(&context, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(file).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// Download file
+//
+//	@Summary		Download file
+//	@Description	Serves the signed download urls when files are stored locally
+//	@Tags			Attachment
+//	@Success		200			{file}		file
+//	@Failure		403			{object}	response.Response
+//	@Param			key			query		string	true	"key"
+//	@Param			name		query		string	false	"file name"
+//	@Param			expires		query		string	true	"expires"
+//	@Param			signature	query		string	true	"signature"
+//	@Router			/attachment/file [get]
+func (h AttachmentHandler) DownloadFile(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	content, contentType, errr := h.attachmentSvc.DownloadFile(&context, ctx.Query("key"), ctx.Query("expires"), ctx.Query("signature"))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusForbidden)
+		return
+	}
+	defer content.Close()
+
+	headers := map[string]string{
+		"Content-Disposition": fmt.Sprintf("attachment; filename=%q", ctx.Query("name")),
+	}
+	ctx.DataFromReader(http.StatusOK, -1, contentType, content, headers)
+}
+
+// Delete Attachment
+//
+//	@Summary		Delete Attachment
+//	@Description	Deletes the Attachment along with the file
+//	@Tags			Attachment
+//	@Accept			json
+//	@Success		200	{object}	response.Response
+//	@Failure		400	{object}	response.Response
+//	@Param			id	path		int	true	"Attachment id"
+//	@Router			/attachment/{id} [delete]
+func (h AttachmentHandler) Delete(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	errr := h.attachmentSvc.Delete(&context, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Delete success").FormatAndSend(&context, ctx, http.StatusOK)
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	"github.com/imkarthi24/sf-backend/internal/service"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/loop-kar/pixie/response"
+	"github.com/loop-kar/pixie/util"
+)
+
+type AuditLogHandler struct {
+	auditLogSvc service.AuditLogService
+	resp        response.Response
+	dataResp    response.DataResponse
+}
+
+func ProvideAuditLogHandler(svc service.AuditLogService) *AuditLogHandler {
+	return &AuditLogHandler{auditLogSvc: svc}
+}
+
+// Get audit logs
+//
+//	@Summary		Get audit logs
+//	@Description	Get the audit logs of the channel, newest first
+//	@Tags			AuditLog
+//	@Accept			json
+//	@Success		200			{object}	responseModel.AuditLog
+//	@Failure		400			{object}	response.DataResponse
+//	@Param			entity		query		string	false	"entity name eg: Customer"
+//	@Param			entityId	query		int		false	"entity id"
+//	@Param			userId		query		int		false	"id of the user who made the change"
+//	@Param			from		query		string	false	"from date (YYYY-MM-DD)"
+//	@Param			to			query		string	false	"to date (YYYY-MM-DD)"
+//	@Router			/audit [get]
+func (h AuditLogHandler) GetAll(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	filter := requestModel.AuditLogFilter{EntityName: ctx.Query("entity")}
+
+	var err error
+	filter.EntityId, err = parseIdQuery(ctx, "entityId")
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, "Invalid entityId", err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	filter.UserId, err = parseIdQuery(ctx, "userId")
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, "Invalid userId", err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	filter.From, err = parseDateQuery(ctx, "from")
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, "Invalid from date, expected YYYY-MM-DD", err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	filter.To, err = parseDateQuery(ctx, "to")
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, "Invalid to date, expected YYYY-MM-DD", err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	auditLogs, errr := h.auditLogSvc.GetAll(&context, filter)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(auditLogs).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// parseIdQuery reads an optional id query param, nil when it is not given
+func parseIdQuery(ctx *gin.Context, key string) (*uint, error) {
+	value := ctx.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	id, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	result := uint(id)
+	return &result, nil
+}
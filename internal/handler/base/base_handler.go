@@ -3,23 +3,34 @@ package base
 import "github.com/imkarthi24/sf-backend/internal/handler"
 
 type BaseHandler struct {
-	HealthHandler             Health
-	UserHandler               *handler.UserHandler
-	ChannelHandler            *handler.ChannelHandler
-	MasterConfigHandler       *handler.MasterConfigHandler
-	AdminHandler              *handler.AdminHandler
-	CustomerHandler           *handler.CustomerHandler
-	EnquiryHandler            *handler.EnquiryHandler
-	OrderHandler              *handler.OrderHandler
-	OrderItemHandler          *handler.OrderItemHandler
-	MeasurementHandler        *handler.MeasurementHandler
-	PersonHandler             *handler.PersonHandler
-	DressTypeHandler          *handler.DressTypeHandler
-	OrderHistoryHandler       *handler.OrderHistoryHandler
-	MeasurementHistoryHandler *handler.MeasurementHistoryHandler
-	EnquiryHistoryHandler     *handler.EnquiryHistoryHandler
-	ExpenseTrackerHandler     *handler.ExpenseTrackerHandler
-	TaskHandler               *handler.TaskHandler
+	HealthHandler              Health
+	UserHandler                *handler.UserHandler
+	ChannelHandler             *handler.ChannelHandler
+	MasterConfigHandler        *handler.MasterConfigHandler
+	AdminHandler               *handler.AdminHandler
+	CustomerHandler            *handler.CustomerHandler
+	EnquiryHandler             *handler.EnquiryHandler
+	OrderHandler               *handler.OrderHandler
+	OrderItemHandler           *handler.OrderItemHandler
+	MeasurementHandler         *handler.MeasurementHandler
+	PersonHandler              *handler.PersonHandler
+	DressTypeHandler           *handler.DressTypeHandler
+	OrderHistoryHandler        *handler.OrderHistoryHandler
+	MeasurementHistoryHandler  *handler.MeasurementHistoryHandler
+	EnquiryHistoryHandler      *handler.EnquiryHistoryHandler
+	ExpenseTrackerHandler      *handler.ExpenseTrackerHandler
+	TaskHandler                *handler.TaskHandler
+	PaymentHandler             *handler.PaymentHandler
+	AttachmentHandler          *handler.AttachmentHandler
+	DashboardHandler           *handler.DashboardHandler
+	AuditLogHandler            *handler.AuditLogHandler
+	OrderItemAssignmentHandler *handler.OrderItemAssignmentHandler
+	PayoutHandler              *handler.PayoutHandler
+	InventoryHandler           *handler.InventoryHandler
+	VendorHandler              *handler.VendorHandler
+	BudgetHandler              *handler.BudgetHandler
+	AppointmentHandler         *handler.AppointmentHandler
+	ApiKeyHandler              *handler.ApiKeyHandler
 }
 
 func ProvideBaseHandler(health Health,
@@ -39,24 +50,46 @@ func ProvideBaseHandler(health Health,
 	enquiryHistoryHandler *handler.EnquiryHistoryHandler,
 	expenseTrackerHandler *handler.ExpenseTrackerHandler,
 	taskHandler *handler.TaskHandler,
+	paymentHandler *handler.PaymentHandler,
+	attachmentHandler *handler.AttachmentHandler,
+	dashboardHandler *handler.DashboardHandler,
+	auditLogHandler *handler.AuditLogHandler,
+	orderItemAssignmentHandler *handler.OrderItemAssignmentHandler,
+	payoutHandler *handler.PayoutHandler,
+	inventoryHandler *handler.InventoryHandler,
+	vendorHandler *handler.VendorHandler,
+	budgetHandler *handler.BudgetHandler,
+	appointmentHandler *handler.AppointmentHandler,
+	apiKeyHandler *handler.ApiKeyHandler,
 ) BaseHandler {
 	return BaseHandler{
-		HealthHandler:             health,
-		UserHandler:               user,
-		ChannelHandler:            channelHandler,
-		MasterConfigHandler:       masterConfigHandler,
-		AdminHandler:              adminHandler,
-		CustomerHandler:           customerHandler,
-		EnquiryHandler:            enquiryHandler,
-		OrderHandler:              orderHandler,
-		OrderItemHandler:          orderItemHandler,
-		MeasurementHandler:        measurementHandler,
-		PersonHandler:             personHandler,
-		DressTypeHandler:          dressTypeHandler,
-		OrderHistoryHandler:       orderHistoryHandler,
-		MeasurementHistoryHandler: measurementHistoryHandler,
-		EnquiryHistoryHandler:     enquiryHistoryHandler,
-		ExpenseTrackerHandler:     expenseTrackerHandler,
-		TaskHandler:               taskHandler,
+		HealthHandler:              health,
+		UserHandler:                user,
+		ChannelHandler:             channelHandler,
+		MasterConfigHandler:        masterConfigHandler,
+		AdminHandler:               adminHandler,
+		CustomerHandler:            customerHandler,
+		EnquiryHandler:             enquiryHandler,
+		OrderHandler:               orderHandler,
+		OrderItemHandler:           orderItemHandler,
+		MeasurementHandler:         measurementHandler,
+		PersonHandler:              personHandler,
+		DressTypeHandler:           dressTypeHandler,
+		OrderHistoryHandler:        orderHistoryHandler,
+		MeasurementHistoryHandler:  measurementHistoryHandler,
+		EnquiryHistoryHandler:      enquiryHistoryHandler,
+		ExpenseTrackerHandler:      expenseTrackerHandler,
+		TaskHandler:                taskHandler,
+		PaymentHandler:             paymentHandler,
+		AttachmentHandler:          attachmentHandler,
+		DashboardHandler:           dashboardHandler,
+		AuditLogHandler:            auditLogHandler,
+		OrderItemAssignmentHandler: orderItemAssignmentHandler,
+		PayoutHandler:              payoutHandler,
+		InventoryHandler:           inventoryHandler,
+		VendorHandler:              vendorHandler,
+		BudgetHandler:              budgetHandler,
+		AppointmentHandler:         appointmentHandler,
+		ApiKeyHandler:              apiKeyHandler,
 	}
 }
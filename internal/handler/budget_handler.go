@@ -0,0 +1,425 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	"github.com/imkarthi24/sf-backend/internal/service"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/loop-kar/pixie/response"
+	"github.com/loop-kar/pixie/util"
+)
+
+type BudgetHandler struct {
+	budgetSvc service.BudgetService
+	resp      response.Response
+	dataResp  response.DataResponse
+}
+
+func ProvideBudgetHandler(svc service.BudgetService) *BudgetHandler {
+	return &BudgetHandler{budgetSvc: svc}
+}
+
+// SaveCategory
+//
+//	@Summary		Save ExpenseCategory
+//	@Description	Adds a category to group the expenses and budgets
+//	@Tags			Budget
+//	@Accept			json
+//	@Success		201			{object}	response.Response
+//	@Failure		400			{object}	response.Response
+//	@Param			category	body		requestModel.ExpenseCategory	true	"category"
+//	@Router			/budget/category [post]
+func (h BudgetHandler) SaveCategory(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var category requestModel.ExpenseCategory
+	err := ctx.Bind(&category)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	errr := h.budgetSvc.SaveCategory(&context, category)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Save success").FormatAndSend(&context, ctx, http.StatusCreated)
+}
+
+// UpdateCategory
+//
+//	@Summary		Update ExpenseCategory
+//	@Description	Updates an instance of ExpenseCategory
+//	@Tags			Budget
+//	@Accept			json
+//	@Success		202			{object}	response.Response
+//	@Failure		400			{object}	response.Response
+//	@Param			category	body		requestModel.ExpenseCategory	true	"category"
+//	@Param			id			path		int								true	"ExpenseCategory id"
+//	@Router			/budget/category/{id} [put]
+func (h BudgetHandler) UpdateCategory(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var category requestModel.ExpenseCategory
+	err := ctx.Bind(&category)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	errr := h.budgetSvc.UpdateCategory(&context, category, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Update success").FormatAndSend(&context, ctx, http.StatusAccepted)
+}
+
+// Get ExpenseCategory
+//
+//	@Summary		Get a specific ExpenseCategory
+//	@Description	Get an instance of ExpenseCategory
+//	@Tags			Budget
+//	@Accept			json
+//	@Success		200	{object}	responseModel.ExpenseCategory
+//	@Failure		400	{object}	response.DataResponse
+//	@Param			id	path		int	true	"ExpenseCategory id"
+//	@Router			/budget/category/{id} [get]
+func (h BudgetHandler) GetCategory(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	category, errr := h.budgetSvc.GetCategory(&context, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(category).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// GetAllCategories
+//
+//	@Summary		Get all active expense categories
+//	@Description	Get all active expense categories
+//	@Tags			Budget
+//	@Accept			json
+//	@Success		200		{object}	[]responseModel.ExpenseCategory
+//	@Failure		400		{object}	response.DataResponse
+//	@Param			search	query		string	false	"search by name or description"
+//	@Router			/budget/category [get]
+func (h BudgetHandler) GetAllCategories(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	search := ctx.Query("search")
+	search = util.EncloseWithSingleQuote(search)
+
+	categories, errr := h.budgetSvc.GetCategories(&context, search)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(categories).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// Delete ExpenseCategory
+//
+//	@Summary		Delete ExpenseCategory
+//	@Description	Deletes an instance of ExpenseCategory
+//	@Tags			Budget
+//	@Accept			json
+//	@Success		200	{object}	response.Response
+//	@Failure		400	{object}	response.Response
+//	@Param			id	path		int	true	"ExpenseCategory id"
+//	@Router			/budget/category/{id} [delete]
+func (h BudgetHandler) DeleteCategory(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	err := h.budgetSvc.DeleteCategory(&context, uint(id))
+	if err != nil {
+		h.resp.DefaultFailureResponse(err).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Delete Success").FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// SaveRecurringExpense
+//
+//	@Summary		Save RecurringExpense
+//	@Description	Adds a bill paid every month, quarter or year, the expense is recorded on every due date from the start date
+//	@Tags			Budget
+//	@Accept			json
+//	@Success		201					{object}	response.Response
+//	@Failure		400					{object}	response.Response
+//	@Param			recurringExpense	body		requestModel.RecurringExpense	true	"recurringExpense"
+//	@Router			/budget/recurring [post]
+func (h BudgetHandler) SaveRecurringExpense(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var recurringExpense requestModel.RecurringExpense
+	err := ctx.Bind(&recurringExpense)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	errr := h.budgetSvc.SaveRecurringExpense(&context, recurringExpense)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Save success").FormatAndSend(&context, ctx, http.StatusCreated)
+}
+
+// UpdateRecurringExpense
+//
+//	@Summary		Update RecurringExpense
+//	@Description	Updates an instance of RecurringExpense, the expenses already recorded are not changed
+//	@Tags			Budget
+//	@Accept			json
+//	@Success		202					{object}	response.Response
+//	@Failure		400					{object}	response.Response
+//	@Param			recurringExpense	body		requestModel.RecurringExpense	true	"recurringExpense"
+//	@Param			id					path		int								true	"RecurringExpense id"
+//	@Router			/budget/recurring/{id} [put]
+func (h BudgetHandler) UpdateRecurringExpense(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var recurringExpense requestModel.RecurringExpense
+	err := ctx.Bind(&recurringExpense)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	errr := h.budgetSvc.UpdateRecurringExpense(&context, recurringExpense, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Update success").FormatAndSend(&context, ctx, http.StatusAccepted)
+}
+
+// Get RecurringExpense
+//
+//	@Summary		Get a specific RecurringExpense
+//	@Description	Get an instance of RecurringExpense
+//	@Tags			Budget
+//	@Accept			json
+//	@Success		200	{object}	responseModel.RecurringExpense
+//	@Failure		400	{object}	response.DataResponse
+//	@Param			id	path		int	true	"RecurringExpense id"
+//	@Router			/budget/recurring/{id} [get]
+func (h BudgetHandler) GetRecurringExpense(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	recurringExpense, errr := h.budgetSvc.GetRecurringExpense(&context, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(recurringExpense).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// GetAllRecurringExpenses
+//
+//	@Summary		Get all active recurring expenses
+//	@Description	Get the recurring expenses, the earliest due first
+//	@Tags			Budget
+//	@Accept			json
+//	@Success		200	{object}	[]responseModel.RecurringExpense
+//	@Failure		400	{object}	response.DataResponse
+//	@Router			/budget/recurring [get]
+func (h BudgetHandler) GetAllRecurringExpenses(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	recurringExpenses, errr := h.budgetSvc.GetRecurringExpenses(&context)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(recurringExpenses).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// Delete RecurringExpense
+//
+//	@Summary		Delete RecurringExpense
+//	@Description	Stops a recurring expense, the expenses already recorded are kept
+//	@Tags			Budget
+//	@Accept			json
+//	@Success		200	{object}	response.Response
+//	@Failure		400	{object}	response.Response
+//	@Param			id	path		int	true	"RecurringExpense id"
+//	@Router			/budget/recurring/{id} [delete]
+func (h BudgetHandler) DeleteRecurringExpense(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	err := h.budgetSvc.DeleteRecurringExpense(&context, uint(id))
+	if err != nil {
+		h.resp.DefaultFailureResponse(err).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Delete Success").FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// SaveBudget
+//
+//	@Summary		Save ExpenseBudget
+//	@Description	Sets the budget of a category for a month, replacing the budget saved earlier for the month
+//	@Tags			Budget
+//	@Accept			json
+//	@Success		201		{object}	response.Response
+//	@Failure		400		{object}	response.Response
+//	@Param			budget	body		requestModel.ExpenseBudget	true	"budget"
+//	@Router			/budget [post]
+func (h BudgetHandler) SaveBudget(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var budget requestModel.ExpenseBudget
+	err := ctx.Bind(&budget)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	errr := h.budgetSvc.SaveBudget(&context, budget)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Save success").FormatAndSend(&context, ctx, http.StatusCreated)
+}
+
+// GetAllBudgets
+//
+//	@Summary		Get the budgets
+//	@Description	Get the budget of each category for the months
+//	@Tags			Budget
+//	@Accept			json
+//	@Success		200		{object}	[]responseModel.ExpenseBudget
+//	@Failure		400		{object}	response.DataResponse
+//	@Param			from	query		string	false	"from month (YYYY-MM), defaults to the current month"
+//	@Param			to		query		string	false	"to month (YYYY-MM), defaults to the from month"
+//	@Router			/budget [get]
+func (h BudgetHandler) GetAllBudgets(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	from, err := parseMonthQuery(ctx, "from")
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, "Invalid from month, expected YYYY-MM", err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	to, err := parseMonthQuery(ctx, "to")
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, "Invalid to month, expected YYYY-MM", err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	budgets, errr := h.budgetSvc.GetBudgets(&context, from, to)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(budgets).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// Delete ExpenseBudget
+//
+//	@Summary		Delete ExpenseBudget
+//	@Description	Deletes an instance of ExpenseBudget
+//	@Tags			Budget
+//	@Accept			json
+//	@Success		200	{object}	response.Response
+//	@Failure		400	{object}	response.Response
+//	@Param			id	path		int	true	"ExpenseBudget id"
+//	@Router			/budget/{id} [delete]
+func (h BudgetHandler) DeleteBudget(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	err := h.budgetSvc.DeleteBudget(&context, uint(id))
+	if err != nil {
+		h.resp.DefaultFailureResponse(err).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Delete Success").FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// Get Budget Report
+//
+//	@Summary		Get budget vs actual
+//	@Description	Compares the expenses of each category with its budget over a range of months
+//	@Tags			Budget
+//	@Accept			json
+//	@Success		200		{object}	responseModel.BudgetReport
+//	@Failure		400		{object}	response.DataResponse
+//	@Param			from	query		string	false	"from month (YYYY-MM), defaults to the current month"
+//	@Param			to		query		string	false	"to month (YYYY-MM), defaults to the from month"
+//	@Router			/budget/report [get]
+func (h BudgetHandler) GetBudgetReport(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	from, err := parseMonthQuery(ctx, "from")
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, "Invalid from month, expected YYYY-MM", err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	to, err := parseMonthQuery(ctx, "to")
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, "Invalid to month, expected YYYY-MM", err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	report, errr := h.budgetSvc.GetBudgetReport(&context, from, to)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(report).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// parseMonthQuery reads an optional YYYY-MM query param, nil when it is not given
+func parseMonthQuery(ctx *gin.Context, key string) (*time.Time, error) {
+	value := ctx.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	month, err := time.Parse(entities.BudgetMonthLayout, value)
+	if err != nil {
+		return nil, err
+	}
+	return &month, nil
+}
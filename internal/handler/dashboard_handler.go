@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imkarthi24/sf-backend/internal/service"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/loop-kar/pixie/response"
+	"github.com/loop-kar/pixie/util"
+)
+
+const dashboardDateLayout = "2006-01-02"
+
+type DashboardHandler struct {
+	dashboardSvc service.DashboardService
+	resp         response.Response
+	dataResp     response.DataResponse
+}
+
+func ProvideDashboardHandler(svc service.DashboardService) *DashboardHandler {
+	return &DashboardHandler{dashboardSvc: svc}
+}
+
+// Get Dashboard Stats
+//
+//	@Summary		Get dashboard stats
+//	@Description	Get the order, revenue, delivery, enquiry, expense and staff stats of the channel for a date range
+//	@Tags			Dashboard
+//	@Accept			json
+//	@Success		200		{object}	responseModel.DashboardStats
+//	@Failure		400		{object}	response.DataResponse
+//	@Param			from	query		string	false	"from date (YYYY-MM-DD), defaults to the start of the month"
+//	@Param			to		query		string	false	"to date (YYYY-MM-DD), defaults to today"
+//	@Router			/dashboard [get]
+func (h DashboardHandler) GetDashboardStats(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	from, err := parseDateQuery(ctx, "from")
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, "Invalid from date, expected YYYY-MM-DD", err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	to, err := parseDateQuery(ctx, "to")
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, "Invalid to date, expected YYYY-MM-DD", err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	stats, errr := h.dashboardSvc.GetDashboardStats(&context, from, to)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(stats).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// parseDateQuery reads an optional YYYY-MM-DD query param, nil when it is not given
+func parseDateQuery(ctx *gin.Context, key string) (*time.Time, error) {
+	value := ctx.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse(dashboardDateLayout, value)
+	if err != nil {
+		return nil, err
+	}
+	return &date, nil
+}
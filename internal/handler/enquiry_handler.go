@@ -52,6 +52,38 @@ func (h EnquiryHandler) SaveEnquiry(ctx *gin.Context) {
 	h.resp.SuccessResponse("Save success").FormatAndSend(&context, ctx, http.StatusCreated)
 }
 
+// Save External Enquiry
+//
+//	@Summary		Save External Enquiry
+//	@Description	Captures a lead of the website contact form or the social lead forms, signed with an api key of the channel having the enquiry:create scope
+//	@Tags			Enquiry
+//	@Accept			json
+//	@Success		201		{object}	response.Response
+//	@Failure		400		{object}	response.Response
+//	@Failure		401		{object}	response.Response
+//	@Failure		429		{object}	response.Response
+//	@Param			enquiry		body		requestModel.ExternalEnquiry	true	"enquiry"
+//	@Param			X-Client-Ip	header		string							false	"ip of the visitor of the form, the requests are rate limited per visitor"
+//	@Router			/external/enquiry [post]
+func (h EnquiryHandler) SaveExternalEnquiry(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var enquiry requesModel.ExternalEnquiry
+	err := ctx.Bind(&enquiry)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	errr := h.enquirySvc.SaveExternalEnquiry(&context, enquiry)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Save success").FormatAndSend(&context, ctx, http.StatusCreated)
+}
+
 // Update Enquiry
 //
 //	@Summary		Update Enquiry
@@ -84,6 +116,38 @@ func (h EnquiryHandler) UpdateEnquiry(ctx *gin.Context) {
 	h.resp.SuccessResponse("Update success").FormatAndSend(&context, ctx, http.StatusAccepted)
 }
 
+// Convert Enquiry to Order
+//
+//	@Summary		Convert Enquiry to Order
+//	@Description	Activates the enquiry's customer, creates the order with its items and accepts the enquiry in one transaction
+//	@Tags			Enquiry
+//	@Accept			json
+//	@Success		201		{object}	responseModel.ConvertedEnquiry
+//	@Failure		400		{object}	response.Response
+//	@Failure		501		{object}	response.Response
+//	@Param			convert	body		requestModel.ConvertEnquiry	true	"order to be created"
+//	@Param			id		path		int							true	"Enquiry id"
+//	@Router			/enquiry/{id}/convert [post]
+func (h EnquiryHandler) ConvertToOrder(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var convert requesModel.ConvertEnquiry
+	err := ctx.Bind(&convert)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	converted, errr := h.enquirySvc.ConvertToOrder(&context, convert, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusInternalServerError)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(converted).FormatAndSend(&context, ctx, http.StatusCreated)
+}
+
 // Get Enquiry
 //
 //	@Summary		Get a specific Enquiry
@@ -0,0 +1,297 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	"github.com/imkarthi24/sf-backend/internal/service"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/loop-kar/pixie/response"
+	"github.com/loop-kar/pixie/util"
+)
+
+type InventoryHandler struct {
+	inventorySvc service.InventoryService
+	resp         response.Response
+	dataResp     response.DataResponse
+}
+
+func ProvideInventoryHandler(svc service.InventoryService) *InventoryHandler {
+	return &InventoryHandler{inventorySvc: svc}
+}
+
+// SaveMaterial
+//
+//	@Summary		Save Material
+//	@Description	Adds a material to the stock catalogue
+//	@Tags			Inventory
+//	@Accept			json
+//	@Success		201			{object}	response.Response
+//	@Failure		400			{object}	response.Response
+//	@Param			material	body		requestModel.Material	true	"material"
+//	@Router			/inventory/material [post]
+func (h InventoryHandler) SaveMaterial(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var material requestModel.Material
+	err := ctx.Bind(&material)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	errr := h.inventorySvc.SaveMaterial(&context, material)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Save success").FormatAndSend(&context, ctx, http.StatusCreated)
+}
+
+// UpdateMaterial
+//
+//	@Summary		Update Material
+//	@Description	Updates an instance of Material
+//	@Tags			Inventory
+//	@Accept			json
+//	@Success		202			{object}	response.Response
+//	@Failure		400			{object}	response.Response
+//	@Param			material	body		requestModel.Material	true	"material"
+//	@Param			id			path		int						true	"Material id"
+//	@Router			/inventory/material/{id} [put]
+func (h InventoryHandler) UpdateMaterial(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var material requestModel.Material
+	err := ctx.Bind(&material)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	errr := h.inventorySvc.UpdateMaterial(&context, material, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Update success").FormatAndSend(&context, ctx, http.StatusAccepted)
+}
+
+// Get Material
+//
+//	@Summary		Get a specific Material
+//	@Description	Get an instance of Material
+//	@Tags			Inventory
+//	@Accept			json
+//	@Success		200	{object}	responseModel.Material
+//	@Failure		400	{object}	response.DataResponse
+//	@Param			id	path		int	true	"Material id"
+//	@Router			/inventory/material/{id} [get]
+func (h InventoryHandler) GetMaterial(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	material, errr := h.inventorySvc.GetMaterial(&context, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(material).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// GetAllMaterials
+//
+//	@Summary		Get all active materials
+//	@Description	Get the materials of the catalogue by name
+//	@Tags			Inventory
+//	@Accept			json
+//	@Success		200		{object}	[]responseModel.Material
+//	@Failure		400		{object}	response.DataResponse
+//	@Param			search	query		string	false	"search by name, code or category"
+//	@Router			/inventory/material [get]
+func (h InventoryHandler) GetAllMaterials(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	materials, errr := h.inventorySvc.GetMaterials(&context, ctx.Query("search"))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(materials).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// Delete Material
+//
+//	@Summary		Delete Material
+//	@Description	Deletes a material which is out of stock
+//	@Tags			Inventory
+//	@Accept			json
+//	@Success		200	{object}	response.Response
+//	@Failure		400	{object}	response.Response
+//	@Param			id	path		int	true	"Material id"
+//	@Router			/inventory/material/{id} [delete]
+func (h InventoryHandler) DeleteMaterial(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	err := h.inventorySvc.DeleteMaterial(&context, uint(id))
+	if err != nil {
+		h.resp.DefaultFailureResponse(err).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Delete Success").FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// SaveMovement
+//
+//	@Summary		Save StockMovement
+//	@Description	Records a stock in (IN) against an expense bill, a consumption (OUT) against an order item or a stock correction (ADJUSTMENT)
+//	@Tags			Inventory
+//	@Accept			json
+//	@Success		201			{object}	response.Response
+//	@Failure		400			{object}	response.Response
+//	@Param			movement	body		requestModel.StockMovement	true	"movement"
+//	@Router			/inventory/movement [post]
+func (h InventoryHandler) SaveMovement(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var movement requestModel.StockMovement
+	err := ctx.Bind(&movement)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	errr := h.inventorySvc.SaveMovement(&context, movement)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Save success").FormatAndSend(&context, ctx, http.StatusCreated)
+}
+
+// GetAllMovements
+//
+//	@Summary		Get all stock movements
+//	@Description	Get the stock movements, latest first
+//	@Tags			Inventory
+//	@Accept			json
+//	@Success		200			{object}	[]responseModel.StockMovement
+//	@Failure		400			{object}	response.DataResponse
+//	@Param			materialId	query		int		false	"Material id"
+//	@Param			expenseId	query		int		false	"Expense id"
+//	@Param			orderItemId	query		int		false	"OrderItem id"
+//	@Param			type		query		string	false	"IN, OUT or ADJUSTMENT"
+//	@Param			from		query		string	false	"Moved on or after, YYYY-MM-DD"
+//	@Param			to			query		string	false	"Moved on or before, YYYY-MM-DD"
+//	@Router			/inventory/movement [get]
+func (h InventoryHandler) GetAllMovements(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	filter := requestModel.StockMovementFilter{
+		Type: ctx.Query("type"),
+	}
+
+	var err error
+	filter.MaterialId, err = parseIdQuery(ctx, "materialId")
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, "Invalid materialId", err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	filter.ExpenseId, err = parseIdQuery(ctx, "expenseId")
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, "Invalid expenseId", err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	filter.OrderItemId, err = parseIdQuery(ctx, "orderItemId")
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, "Invalid orderItemId", err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	filter.From, err = parseDateQuery(ctx, "from")
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, "Invalid from date, expected YYYY-MM-DD", err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	to, err := parseDateQuery(ctx, "to")
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, "Invalid to date, expected YYYY-MM-DD", err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+	if to != nil {
+		end := to.AddDate(0, 0, 1)
+		filter.To = &end
+	}
+
+	movements, errr := h.inventorySvc.GetMovements(&context, filter)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(movements).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// Delete StockMovement
+//
+//	@Summary		Delete StockMovement
+//	@Description	Deletes a wrongly recorded stock movement
+//	@Tags			Inventory
+//	@Accept			json
+//	@Success		200	{object}	response.Response
+//	@Failure		400	{object}	response.Response
+//	@Param			id	path		int	true	"StockMovement id"
+//	@Router			/inventory/movement/{id} [delete]
+func (h InventoryHandler) DeleteMovement(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	err := h.inventorySvc.DeleteMovement(&context, uint(id))
+	if err != nil {
+		h.resp.DefaultFailureResponse(err).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Delete Success").FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// GetStockLevels
+//
+//	@Summary		Get stock on hand
+//	@Description	Get the on hand quantity of each material along with its low stock threshold from master config
+//	@Tags			Inventory
+//	@Accept			json
+//	@Success		200			{object}	[]responseModel.StockLevel
+//	@Failure		400			{object}	response.DataResponse
+//	@Param			lowStock	query		bool	false	"Only the materials low on stock"
+//	@Router			/inventory/stock [get]
+func (h InventoryHandler) GetStockLevels(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	levels, errr := h.inventorySvc.GetStockLevels(&context, ctx.Query("lowStock") == "true")
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(levels).FormatAndSend(&context, ctx, http.StatusOK)
+}
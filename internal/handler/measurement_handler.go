@@ -150,16 +150,17 @@ func (h MeasurementHandler) BulkUpdateMeasurements(ctx *gin.Context) {
 //	@Description	Get an instance of Measurement
 //	@Tags			Measurement
 //	@Accept			json
-//	@Success		200	{object}	responseModel.Measurement
-//	@Failure		400	{object}	response.DataResponse
-//	@Param			id	path		int	true	"Measurement id"
+//	@Success		200		{object}	responseModel.Measurement
+//	@Failure		400		{object}	response.DataResponse
+//	@Param			id		path		int		true	"Measurement id"
+//	@Param			unit	query		string	false	"unit of the values (cm or inch), defaults to the channel unit"
 //	@Router			/measurement/{id} [get]
 func (h MeasurementHandler) Get(ctx *gin.Context) {
 	context := util.CopyContextFromGin(ctx)
 
 	id, _ := strconv.Atoi(ctx.Param("id"))
 
-	measurement, errr := h.measurementSvc.Get(&context, uint(id))
+	measurement, errr := h.measurementSvc.Get(&context, uint(id), ctx.Query("unit"))
 	if errr != nil {
 		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
 		return
@@ -216,3 +217,29 @@ func (h MeasurementHandler) Delete(ctx *gin.Context) {
 
 	h.resp.SuccessResponse("Delete Success").FormatAndSend(&context, ctx, http.StatusOK)
 }
+
+// Restore a Measurement
+//
+//	@Summary		Restore Measurement
+//	@Description	Restores the values of the measurement before the given history and records an UPDATED history
+//	@Tags			Measurement
+//	@Accept			json
+//	@Success		200			{object}	response.Response
+//	@Failure		400			{object}	response.Response
+//	@Param			id			path		int	true	"measurement id"
+//	@Param			historyId	path		int	true	"measurement history id"
+//	@Router			/measurement/{id}/restore/{historyId} [post]
+func (h MeasurementHandler) Restore(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	historyId, _ := strconv.Atoi(ctx.Param("historyId"))
+
+	err := h.measurementSvc.Restore(&context, uint(id), uint(historyId))
+	if err != nil {
+		h.resp.DefaultFailureResponse(err).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Restore success").FormatAndSend(&context, ctx, http.StatusOK)
+}
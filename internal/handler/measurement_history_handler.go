@@ -60,14 +60,15 @@ func (h MeasurementHistoryHandler) SaveMeasurementHistory(ctx *gin.Context) {
 //	@Accept			json
 //	@Success		200	{object}	responseModel.MeasurementHistory
 //	@Failure		400	{object}	response.DataResponse
-//	@Param			id	path		int	true	"MeasurementHistory id"
+//	@Param			id		path		int		true	"MeasurementHistory id"
+//	@Param			unit	query		string	false	"unit of the values (cm or inch), defaults to the channel unit"
 //	@Router			/measurement-history/{id} [get]
 func (h MeasurementHistoryHandler) Get(ctx *gin.Context) {
 	context := util.CopyContextFromGin(ctx)
 
 	id, _ := strconv.Atoi(ctx.Param("id"))
 
-	measurementHistory, errr := h.measurementHistorySvc.Get(&context, uint(id))
+	measurementHistory, errr := h.measurementHistorySvc.Get(&context, uint(id), ctx.Query("unit"))
 	if errr != nil {
 		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
 		return
@@ -85,6 +86,7 @@ func (h MeasurementHistoryHandler) Get(ctx *gin.Context) {
 //	@Success		200		{object}	responseModel.MeasurementHistory
 //	@Failure		400		{object}	response.DataResponse
 //	@Param			search	query		string	false	"search"
+//	@Param			unit	query		string	false	"unit of the values (cm or inch), defaults to the channel unit"
 //	@Router			/measurement-history [get]
 func (h MeasurementHistoryHandler) GetAllMeasurementHistories(ctx *gin.Context) {
 	context := util.CopyContextFromGin(ctx)
@@ -92,7 +94,7 @@ func (h MeasurementHistoryHandler) GetAllMeasurementHistories(ctx *gin.Context)
 	search := ctx.Query("search")
 	search = util.EncloseWithSingleQuote(search)
 
-	measurementHistories, errr := h.measurementHistorySvc.GetAll(&context, search)
+	measurementHistories, errr := h.measurementHistorySvc.GetAll(&context, search, ctx.Query("unit"))
 	if errr != nil {
 		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
 		return
@@ -109,14 +111,15 @@ func (h MeasurementHistoryHandler) GetAllMeasurementHistories(ctx *gin.Context)
 //	@Accept			json
 //	@Success		200				{object}	responseModel.MeasurementHistory
 //	@Failure		400				{object}	response.DataResponse
-//	@Param			measurementId	path		int	true	"measurement id"
+//	@Param			measurementId	path		int		true	"measurement id"
+//	@Param			unit			query		string	false	"unit of the values (cm or inch), defaults to the channel unit"
 //	@Router			/measurement-history/measurement/{measurementId} [get]
 func (h MeasurementHistoryHandler) GetByMeasurementId(ctx *gin.Context) {
 	context := util.CopyContextFromGin(ctx)
 
 	measurementId, _ := strconv.Atoi(ctx.Param("measurementId"))
 
-	measurementHistories, errr := h.measurementHistorySvc.GetByMeasurementId(&context, uint(measurementId))
+	measurementHistories, errr := h.measurementHistorySvc.GetByMeasurementId(&context, uint(measurementId), ctx.Query("unit"))
 	if errr != nil {
 		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
 		return
@@ -124,3 +127,41 @@ func (h MeasurementHistoryHandler) GetByMeasurementId(ctx *gin.Context) {
 
 	h.dataResp.DefaultSuccessResponse(measurementHistories).FormatAndSend(&context, ctx, http.StatusOK)
 }
+
+// Diff measurement history
+//
+//	@Summary		Compare measurement versions
+//	@Description	Compares the values before the history against the values before another history of the same measurement, or against the current values, field by field
+//	@Tags			MeasurementHistory
+//	@Accept			json
+//	@Success		200			{object}	responseModel.MeasurementHistoryDiff
+//	@Failure		400			{object}	response.DataResponse
+//	@Param			id			path		int		true	"MeasurementHistory id"
+//	@Param			compareTo	query		int		false	"MeasurementHistory id to compare with, defaults to the current values"
+//	@Param			unit		query		string	false	"unit of the values (cm or inch), defaults to the channel unit"
+//	@Router			/measurement-history/{id}/diff [get]
+func (h MeasurementHistoryHandler) Diff(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	var compareToId *uint
+	if compareTo := ctx.Query("compareTo"); compareTo != "" {
+		parsed, err := strconv.Atoi(compareTo)
+		if err != nil {
+			x := errs.NewXError(errs.INVALID_REQUEST, "Invalid compareTo, expected a measurement history id", err)
+			h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+			return
+		}
+		historyId := uint(parsed)
+		compareToId = &historyId
+	}
+
+	diff, errr := h.measurementHistorySvc.Diff(&context, uint(id), compareToId, ctx.Query("unit"))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(diff).FormatAndSend(&context, ctx, http.StatusOK)
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
 
@@ -13,13 +14,14 @@ import (
 )
 
 type OrderHandler struct {
-	orderSvc service.OrderService
-	resp     response.Response
-	dataResp response.DataResponse
+	orderSvc   service.OrderService
+	invoiceSvc service.InvoiceService
+	resp       response.Response
+	dataResp   response.DataResponse
 }
 
-func ProvideOrderHandler(svc service.OrderService) *OrderHandler {
-	return &OrderHandler{orderSvc: svc}
+func ProvideOrderHandler(svc service.OrderService, invoiceSvc service.InvoiceService) *OrderHandler {
+	return &OrderHandler{orderSvc: svc, invoiceSvc: invoiceSvc}
 }
 
 // Save Order
@@ -84,6 +86,37 @@ func (h OrderHandler) UpdateOrder(ctx *gin.Context) {
 	h.resp.SuccessResponse("Update success").FormatAndSend(&context, ctx, http.StatusAccepted)
 }
 
+// Update Order Status
+//
+//	@Summary		Update Order Status
+//	@Description	Moves an Order to the given status if the transition is allowed
+//	@Tags			Order
+//	@Accept			json
+//	@Success		202		{object}	response.Response
+//	@Failure		400		{object}	response.Response
+//	@Param			status	body		requestModel.Status	true	"status"
+//	@Param			id		path		int					true	"Order id"
+//	@Router			/order/{id}/status [patch]
+func (h OrderHandler) UpdateOrderStatus(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var status requesModel.Status
+	err := ctx.Bind(&status)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	errr := h.orderSvc.UpdateOrderStatus(&context, status, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Status update success").FormatAndSend(&context, ctx, http.StatusAccepted)
+}
+
 // Get Order
 //
 //	@Summary		Get a specific Order
@@ -117,7 +150,7 @@ func (h OrderHandler) Get(ctx *gin.Context) {
 //	@Success		200		{object}	responseModel.Order
 //	@Failure		400		{object}	response.DataResponse
 //	@Param			search	query		string	false	"search"
-//	@Param			filters	query		string	false	"filters (e.g., name eq 'Shirt', status eq 'Active')"
+//	@Param			filters	query		string	false	"filters (e.g., status eq 'CONFIRMED', balanceAmount gt 0)"
 //	@Router			/order [get]
 func (h OrderHandler) GetAllOrders(ctx *gin.Context) {
 	context := util.CopyContextFromGin(ctx)
@@ -157,3 +190,28 @@ func (h OrderHandler) Delete(ctx *gin.Context) {
 
 	h.resp.SuccessResponse("Delete Success").FormatAndSend(&context, ctx, http.StatusOK)
 }
+
+// Get Order Invoice
+//
+//	@Summary		Get Order Invoice
+//	@Description	Generates the printable PDF invoice of an Order
+//	@Tags			Order
+//	@Produce		application/pdf
+//	@Success		200	{file}		file
+//	@Failure		400	{object}	response.Response
+//	@Param			id	path		int	true	"Order id"
+//	@Router			/order/{id}/invoice [get]
+func (h OrderHandler) GetInvoice(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	content, fileName, errr := h.invoiceSvc.GenerateInvoice(&context, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", fileName))
+	ctx.Data(http.StatusOK, "application/pdf", content)
+}
@@ -103,8 +103,8 @@ func (h OrderHistoryHandler) GetAllOrderHistories(ctx *gin.Context) {
 
 // Get order histories by order id
 //
-//	@Summary		Get order histories by order id
-//	@Description	Get order histories by order id
+//	@Summary		Get order timeline
+//	@Description	Get the order and item level histories of an order, newest first
 //	@Tags			OrderHistory
 //	@Accept			json
 //	@Success		200		{object}	responseModel.OrderHistory
@@ -0,0 +1,238 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	"github.com/imkarthi24/sf-backend/internal/service"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/loop-kar/pixie/response"
+	"github.com/loop-kar/pixie/util"
+)
+
+type OrderItemAssignmentHandler struct {
+	assignmentSvc service.OrderItemAssignmentService
+	resp          response.Response
+	dataResp      response.DataResponse
+}
+
+func ProvideOrderItemAssignmentHandler(svc service.OrderItemAssignmentService) *OrderItemAssignmentHandler {
+	return &OrderItemAssignmentHandler{assignmentSvc: svc}
+}
+
+// SaveAssignment
+//
+//	@Summary		Save OrderItemAssignment
+//	@Description	Assigns a production stage of an order item to a staff or an outsourced tailor
+//	@Tags			OrderItemAssignment
+//	@Accept			json
+//	@Success		201			{object}	response.Response
+//	@Failure		400			{object}	response.Response
+//	@Failure		501			{object}	response.Response
+//	@Param			assignment	body		requestModel.OrderItemAssignment	true	"assignment"
+//	@Router			/order-item-assignment [post]
+func (h OrderItemAssignmentHandler) SaveAssignment(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var assignment requestModel.OrderItemAssignment
+	err := ctx.Bind(&assignment)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	errr := h.assignmentSvc.SaveAssignment(&context, assignment)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusInternalServerError)
+		return
+	}
+
+	h.resp.SuccessResponse("Save success").FormatAndSend(&context, ctx, http.StatusCreated)
+}
+
+// UpdateAssignment
+//
+//	@Summary		Update OrderItemAssignment
+//	@Description	Updates an instance of OrderItemAssignment, the status is changed with the status endpoint
+//	@Tags			OrderItemAssignment
+//	@Accept			json
+//	@Success		202			{object}	response.Response
+//	@Failure		400			{object}	response.Response
+//	@Failure		501			{object}	response.Response
+//	@Param			assignment	body		requestModel.OrderItemAssignment	true	"assignment"
+//	@Param			id			path		int									true	"OrderItemAssignment id"
+//	@Router			/order-item-assignment/{id} [put]
+func (h OrderItemAssignmentHandler) UpdateAssignment(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var assignment requestModel.OrderItemAssignment
+	err := ctx.Bind(&assignment)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	errr := h.assignmentSvc.UpdateAssignment(&context, assignment, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusInternalServerError)
+		return
+	}
+
+	h.resp.SuccessResponse("Update success").FormatAndSend(&context, ctx, http.StatusAccepted)
+}
+
+// Update OrderItemAssignment Status
+//
+//	@Summary		Update OrderItemAssignment Status
+//	@Description	Moves an assignment to the given status, the order moves along with the production stage of its items
+//	@Tags			OrderItemAssignment
+//	@Accept			json
+//	@Success		202		{object}	response.Response
+//	@Failure		400		{object}	response.Response
+//	@Param			status	body		requestModel.Status	true	"status"
+//	@Param			id		path		int					true	"OrderItemAssignment id"
+//	@Router			/order-item-assignment/{id}/status [patch]
+func (h OrderItemAssignmentHandler) UpdateAssignmentStatus(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var status requestModel.Status
+	err := ctx.Bind(&status)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	errr := h.assignmentSvc.UpdateAssignmentStatus(&context, status, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Status update success").FormatAndSend(&context, ctx, http.StatusAccepted)
+}
+
+// Get OrderItemAssignment
+//
+//	@Summary		Get a specific OrderItemAssignment
+//	@Description	Get an instance of OrderItemAssignment
+//	@Tags			OrderItemAssignment
+//	@Accept			json
+//	@Success		200	{object}	responseModel.OrderItemAssignment
+//	@Failure		400	{object}	response.DataResponse
+//	@Param			id	path		int	true	"OrderItemAssignment id"
+//	@Router			/order-item-assignment/{id} [get]
+func (h OrderItemAssignmentHandler) Get(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	assignment, errr := h.assignmentSvc.Get(&context, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(assignment).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// GetAllAssignments
+//
+//	@Summary		Get all active assignments
+//	@Description	Get the active assignments, earliest due first
+//	@Tags			OrderItemAssignment
+//	@Accept			json
+//	@Success		200				{object}	[]responseModel.OrderItemAssignment
+//	@Failure		400				{object}	response.DataResponse
+//	@Param			orderId			query		int		false	"Order id"
+//	@Param			orderItemId		query		int		false	"OrderItem id"
+//	@Param			assignedToId	query		int		false	"Assigned user id"
+//	@Param			stage			query		string	false	"CUTTING, STITCHING or FINISHING"
+//	@Param			status			query		string	false	"PENDING, IN_PROGRESS, DONE or CANCELLED"
+//	@Router			/order-item-assignment [get]
+func (h OrderItemAssignmentHandler) GetAllAssignments(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	filter := requestModel.OrderItemAssignmentFilter{
+		Stage:  ctx.Query("stage"),
+		Status: ctx.Query("status"),
+	}
+
+	var err error
+	filter.OrderId, err = parseIdQuery(ctx, "orderId")
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, "Invalid orderId", err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	filter.OrderItemId, err = parseIdQuery(ctx, "orderItemId")
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, "Invalid orderItemId", err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	filter.AssignedToId, err = parseIdQuery(ctx, "assignedToId")
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, "Invalid assignedToId", err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	assignments, errr := h.assignmentSvc.GetAll(&context, filter)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(assignments).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// GetWorkQueue
+//
+//	@Summary		Get my work queue
+//	@Description	Get the work assigned to the logged in user, the pending and in progress work when status is not given
+//	@Tags			OrderItemAssignment
+//	@Accept			json
+//	@Success		200		{object}	[]responseModel.OrderItemAssignment
+//	@Failure		400		{object}	response.DataResponse
+//	@Param			status	query		string	false	"PENDING, IN_PROGRESS, DONE or CANCELLED"
+//	@Router			/order-item-assignment/my-queue [get]
+func (h OrderItemAssignmentHandler) GetWorkQueue(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	assignments, errr := h.assignmentSvc.GetWorkQueue(&context, ctx.Query("status"))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(assignments).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// Delete OrderItemAssignment
+//
+//	@Summary		Delete OrderItemAssignment
+//	@Description	Deletes an instance of OrderItemAssignment
+//	@Tags			OrderItemAssignment
+//	@Accept			json
+//	@Success		200	{object}	response.Response
+//	@Failure		400	{object}	response.Response
+//	@Param			id	path		int	true	"OrderItemAssignment id"
+//	@Router			/order-item-assignment/{id} [delete]
+func (h OrderItemAssignmentHandler) Delete(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	err := h.assignmentSvc.Delete(&context, uint(id))
+	if err != nil {
+		h.resp.DefaultFailureResponse(err).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Delete Success").FormatAndSend(&context, ctx, http.StatusOK)
+}
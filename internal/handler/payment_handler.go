@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	requesModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	"github.com/imkarthi24/sf-backend/internal/service"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/loop-kar/pixie/response"
+	"github.com/loop-kar/pixie/util"
+)
+
+type PaymentHandler struct {
+	paymentSvc service.PaymentService
+	resp       response.Response
+	dataResp   response.DataResponse
+}
+
+func ProvidePaymentHandler(svc service.PaymentService) *PaymentHandler {
+	return &PaymentHandler{paymentSvc: svc}
+}
+
+// Save Payment
+//
+//	@Summary		Save Payment
+//	@Description	Saves an instance of Payment
+//	@Tags			Payment
+//	@Accept			json
+//	@Success		201		{object}	response.Response
+//	@Failure		400		{object}	response.Response
+//	@Failure		501		{object}	response.Response
+//	@Param			payment	body		requestModel.Payment	true	"payment"
+//	@Router			/payment [post]
+func (h PaymentHandler) SavePayment(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var payment requesModel.Payment
+	err := ctx.Bind(&payment)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	errr := h.paymentSvc.SavePayment(&context, payment)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusInternalServerError)
+		return
+	}
+
+	h.resp.SuccessResponse("Save success").FormatAndSend(&context, ctx, http.StatusCreated)
+}
+
+// Update Payment
+//
+//	@Summary		Update Payment
+//	@Description	Updates an instance of Payment
+//	@Tags			Payment
+//	@Accept			json
+//	@Success		201		{object}	response.Response
+//	@Failure		400		{object}	response.Response
+//	@Failure		501		{object}	response.Response
+//	@Param			payment	body		requestModel.Payment	true	"payment"
+//	@Param			id		path		int						true	"Payment id"
+//	@Router			/payment/{id} [put]
+func (h PaymentHandler) UpdatePayment(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var payment requesModel.Payment
+	err := ctx.Bind(&payment)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	errr := h.paymentSvc.UpdatePayment(&context, payment, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusInternalServerError)
+		return
+	}
+
+	h.resp.SuccessResponse("Update success").FormatAndSend(&context, ctx, http.StatusAccepted)
+}
+
+// Get Payment
+//
+//	@Summary		Get a specific Payment
+//	@Description	Get an instance of Payment
+//	@Tags			Payment
+//	@Accept			json
+//	@Success		200	{object}	responseModel.Payment
+//	@Failure		400	{object}	response.DataResponse
+//	@Param			id	path		int	true	"Payment id"
+//	@Router			/payment/{id} [get]
+func (h PaymentHandler) Get(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	payment, errr := h.paymentSvc.Get(&context, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(payment).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// Get all active payments
+//
+//	@Summary		Get all active payments
+//	@Description	Get all active payments
+//	@Tags			Payment
+//	@Accept			json
+//	@Success		200		{object}	responseModel.Payment
+//	@Failure		400		{object}	response.DataResponse
+//	@Param			search	query		string	false	"search"
+//	@Param			filters	query		string	false	"filters (e.g., paymentMethod eq 'UPI', orderId eq 1)"
+//	@Router			/payment [get]
+func (h PaymentHandler) GetAllPayments(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	search := ctx.Query("search")
+	search = util.EncloseWithSingleQuote(search)
+
+	payments, errr := h.paymentSvc.GetAll(&context, search)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(payments).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// Get payments by order id
+//
+//	@Summary		Get payments by order id
+//	@Description	Get all active payments recorded against an order
+//	@Tags			Payment
+//	@Accept			json
+//	@Success		200		{object}	responseModel.Payment
+//	@Failure		400		{object}	response.DataResponse
+//	@Param			orderId	path		int	true	"order id"
+//	@Router			/payment/order/{orderId} [get]
+func (h PaymentHandler) GetByOrderId(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	orderId, _ := strconv.Atoi(ctx.Param("orderId"))
+
+	payments, errr := h.paymentSvc.GetByOrderId(&context, uint(orderId))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(payments).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// Delete a Payment
+//
+//	@Summary		Delete Payment
+//	@Description	Deletes an instance of Payment
+//	@Tags			Payment
+//	@Accept			json
+//	@Success		200	{object}	response.Response
+//	@Failure		400	{object}	response.Response
+//	@Param			id	path		int	true	"payment id"
+//
+//	@Router			/payment/{id} [delete]
+func (h PaymentHandler) Delete(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	err := h.paymentSvc.Delete(&context, uint(id))
+	if err != nil {
+		h.resp.DefaultFailureResponse(err).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Delete Success").FormatAndSend(&context, ctx, http.StatusOK)
+}
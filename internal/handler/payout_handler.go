@@ -0,0 +1,250 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	"github.com/imkarthi24/sf-backend/internal/service"
+	"github.com/imkarthi24/sf-backend/internal/utils"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/loop-kar/pixie/response"
+	"github.com/loop-kar/pixie/util"
+)
+
+type PayoutHandler struct {
+	payoutSvc service.PayoutService
+	resp      response.Response
+	dataResp  response.DataResponse
+}
+
+func ProvidePayoutHandler(svc service.PayoutService) *PayoutHandler {
+	return &PayoutHandler{payoutSvc: svc}
+}
+
+// SaveSettlement
+//
+//	@Summary		Save PayoutSettlement
+//	@Description	Settles the payouts of an outsourced tailor outstanding till the given date (today by default)
+//	@Tags			Payout
+//	@Accept			json
+//	@Success		201			{object}	response.Response
+//	@Failure		400			{object}	response.Response
+//	@Param			settlement	body		requestModel.PayoutSettlement	true	"settlement"
+//	@Router			/payout/settlement [post]
+func (h PayoutHandler) SaveSettlement(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var settlement requestModel.PayoutSettlement
+	err := ctx.Bind(&settlement)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	errr := h.payoutSvc.SaveSettlement(&context, settlement)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Save success").FormatAndSend(&context, ctx, http.StatusCreated)
+}
+
+// Get PayoutSettlement
+//
+//	@Summary		Get a specific PayoutSettlement
+//	@Description	Get an instance of PayoutSettlement along with the entries it settled
+//	@Tags			Payout
+//	@Accept			json
+//	@Success		200	{object}	responseModel.PayoutSettlement
+//	@Failure		400	{object}	response.DataResponse
+//	@Param			id	path		int	true	"PayoutSettlement id"
+//	@Router			/payout/settlement/{id} [get]
+func (h PayoutHandler) GetSettlement(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	settlement, errr := h.payoutSvc.GetSettlement(&context, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(settlement).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// GetAllSettlements
+//
+//	@Summary		Get all payout settlements
+//	@Description	Get the payout settlements, latest first
+//	@Tags			Payout
+//	@Accept			json
+//	@Success		200			{object}	[]responseModel.PayoutSettlement
+//	@Failure		400			{object}	response.DataResponse
+//	@Param			tailorId	query		int		false	"Tailor id"
+//	@Param			from		query		string	false	"Settled on or after, YYYY-MM-DD"
+//	@Param			to			query		string	false	"Settled on or before, YYYY-MM-DD"
+//	@Router			/payout/settlement [get]
+func (h PayoutHandler) GetAllSettlements(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	filter, x := parsePayoutFilter(ctx)
+	if x != nil {
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	settlements, errr := h.payoutSvc.GetSettlements(&context, filter)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(settlements).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// GetAllEntries
+//
+//	@Summary		Get all payout entries
+//	@Description	Get the payouts accrued for the work done, earliest first
+//	@Tags			Payout
+//	@Accept			json
+//	@Success		200			{object}	[]responseModel.PayoutEntry
+//	@Failure		400			{object}	response.DataResponse
+//	@Param			tailorId	query		int		false	"Tailor id"
+//	@Param			unsettled	query		bool	false	"Only the entries yet to be settled"
+//	@Param			from		query		string	false	"Accrued on or after, YYYY-MM-DD"
+//	@Param			to			query		string	false	"Accrued on or before, YYYY-MM-DD"
+//	@Router			/payout/entry [get]
+func (h PayoutHandler) GetAllEntries(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	filter, x := parsePayoutFilter(ctx)
+	if x != nil {
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+	filter.Unsettled = ctx.Query("unsettled") == "true"
+
+	entries, errr := h.payoutSvc.GetEntries(&context, filter)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(entries).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// GetOutstanding
+//
+//	@Summary		Get outstanding payouts
+//	@Description	Get the amount yet to be settled to each outsourced tailor
+//	@Tags			Payout
+//	@Accept			json
+//	@Success		200	{object}	[]responseModel.PayoutOutstanding
+//	@Failure		400	{object}	response.DataResponse
+//	@Router			/payout/outstanding [get]
+func (h PayoutHandler) GetOutstanding(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	outstanding, errr := h.payoutSvc.GetOutstanding(&context)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(outstanding).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// GetStatement
+//
+//	@Summary		Get payout statement
+//	@Description	Get the payout statement of a tailor for a date range, the current month by default
+//	@Tags			Payout
+//	@Accept			json
+//	@Success		200			{object}	responseModel.PayoutStatement
+//	@Failure		400			{object}	response.DataResponse
+//	@Param			tailorId	query		int		false	"Tailor id, the logged in user by default"
+//	@Param			from		query		string	false	"From date, YYYY-MM-DD"
+//	@Param			to			query		string	false	"To date, YYYY-MM-DD"
+//	@Param			format		query		string	false	"csv to download the statement"
+//	@Router			/payout/statement [get]
+func (h PayoutHandler) GetStatement(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	tailorId, err := parseIdQuery(ctx, "tailorId")
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, "Invalid tailorId", err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+	if tailorId == nil {
+		userId := utils.GetUserId(&context)
+		tailorId = &userId
+	}
+
+	from, err := parseDateQuery(ctx, "from")
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, "Invalid from date, expected YYYY-MM-DD", err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	to, err := parseDateQuery(ctx, "to")
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, "Invalid to date, expected YYYY-MM-DD", err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	if ctx.Query("format") == "csv" {
+		content, fileName, errr := h.payoutSvc.ExportStatement(&context, *tailorId, from, to)
+		if errr != nil {
+			h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+			return
+		}
+
+		ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+		ctx.Data(http.StatusOK, "text/csv", content)
+		return
+	}
+
+	statement, errr := h.payoutSvc.GetStatement(&context, *tailorId, from, to)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(statement).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// parsePayoutFilter reads the tailorId, from and to query params, the to date is inclusive
+func parsePayoutFilter(ctx *gin.Context) (requestModel.PayoutFilter, *errs.XError) {
+	var filter requestModel.PayoutFilter
+
+	var err error
+	filter.TailorId, err = parseIdQuery(ctx, "tailorId")
+	if err != nil {
+		return filter, errs.NewXError(errs.INVALID_REQUEST, "Invalid tailorId", err)
+	}
+
+	filter.From, err = parseDateQuery(ctx, "from")
+	if err != nil {
+		return filter, errs.NewXError(errs.INVALID_REQUEST, "Invalid from date, expected YYYY-MM-DD", err)
+	}
+
+	to, err := parseDateQuery(ctx, "to")
+	if err != nil {
+		return filter, errs.NewXError(errs.INVALID_REQUEST, "Invalid to date, expected YYYY-MM-DD", err)
+	}
+	if to != nil {
+		end := to.AddDate(0, 0, 1)
+		filter.To = &end
+	}
+
+	return filter, nil
+}
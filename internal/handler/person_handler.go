@@ -90,16 +90,17 @@ func (h PersonHandler) UpdatePerson(ctx *gin.Context) {
 //	@Description	Get an instance of Person
 //	@Tags			Person
 //	@Accept			json
-//	@Success		200	{object}	responseModel.Person
-//	@Failure		400	{object}	response.DataResponse
-//	@Param			id	path		int	true	"Person id"
+//	@Success		200		{object}	responseModel.Person
+//	@Failure		400		{object}	response.DataResponse
+//	@Param			id		path		int		true	"Person id"
+//	@Param			unit	query		string	false	"unit of the measurement values (cm or inch), defaults to the channel unit"
 //	@Router			/person/{id} [get]
 func (h PersonHandler) Get(ctx *gin.Context) {
 	context := util.CopyContextFromGin(ctx)
 
 	id, _ := strconv.Atoi(ctx.Param("id"))
 
-	person, errr := h.personSvc.Get(&context, uint(id))
+	person, errr := h.personSvc.Get(&context, uint(id), ctx.Query("unit"))
 	if errr != nil {
 		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
 		return
@@ -165,14 +166,15 @@ func (h PersonHandler) Delete(ctx *gin.Context) {
 //	@Accept			json
 //	@Success		200			{object}	responseModel.Person
 //	@Failure		400			{object}	response.DataResponse
-//	@Param			customerId	path		int	true	"customer id"
+//	@Param			customerId	path		int		true	"customer id"
+//	@Param			unit		query		string	false	"unit of the measurement values (cm or inch), defaults to the channel unit"
 //	@Router			/person/customer/{customerId} [get]
 func (h PersonHandler) GetByCustomerId(ctx *gin.Context) {
 	context := util.CopyContextFromGin(ctx)
 
 	customerId, _ := strconv.Atoi(ctx.Param("customerId"))
 
-	persons, errr := h.personSvc.GetByCustomerId(&context, uint(customerId))
+	persons, errr := h.personSvc.GetByCustomerId(&context, uint(customerId), ctx.Query("unit"))
 	if errr != nil {
 		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
 		return
@@ -0,0 +1,217 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	"github.com/imkarthi24/sf-backend/internal/service"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/loop-kar/pixie/response"
+	"github.com/loop-kar/pixie/util"
+)
+
+type VendorHandler struct {
+	vendorSvc service.VendorService
+	resp      response.Response
+	dataResp  response.DataResponse
+}
+
+func ProvideVendorHandler(svc service.VendorService) *VendorHandler {
+	return &VendorHandler{vendorSvc: svc}
+}
+
+// SaveVendor
+//
+//	@Summary		Save Vendor
+//	@Description	Saves an instance of Vendor
+//	@Tags			Vendor
+//	@Accept			json
+//	@Success		201		{object}	response.Response
+//	@Failure		400		{object}	response.Response
+//	@Param			vendor	body		requestModel.Vendor	true	"vendor"
+//	@Router			/vendor [post]
+func (h VendorHandler) SaveVendor(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var vendor requestModel.Vendor
+	err := ctx.Bind(&vendor)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	errr := h.vendorSvc.SaveVendor(&context, vendor)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Save success").FormatAndSend(&context, ctx, http.StatusCreated)
+}
+
+// UpdateVendor
+//
+//	@Summary		Update Vendor
+//	@Description	Updates an instance of Vendor
+//	@Tags			Vendor
+//	@Accept			json
+//	@Success		202		{object}	response.Response
+//	@Failure		400		{object}	response.Response
+//	@Param			vendor	body		requestModel.Vendor	true	"vendor"
+//	@Param			id		path		int					true	"Vendor id"
+//	@Router			/vendor/{id} [put]
+func (h VendorHandler) UpdateVendor(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+	var vendor requestModel.Vendor
+	err := ctx.Bind(&vendor)
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	errr := h.vendorSvc.UpdateVendor(&context, vendor, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Update success").FormatAndSend(&context, ctx, http.StatusAccepted)
+}
+
+// Get Vendor
+//
+//	@Summary		Get a specific Vendor
+//	@Description	Get an instance of Vendor
+//	@Tags			Vendor
+//	@Accept			json
+//	@Success		200	{object}	responseModel.Vendor
+//	@Failure		400	{object}	response.DataResponse
+//	@Param			id	path		int	true	"Vendor id"
+//	@Router			/vendor/{id} [get]
+func (h VendorHandler) Get(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	vendor, errr := h.vendorSvc.Get(&context, uint(id))
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(vendor).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// GetAllVendors
+//
+//	@Summary		Get all active vendors
+//	@Description	Get all active vendors
+//	@Tags			Vendor
+//	@Accept			json
+//	@Success		200		{object}	[]responseModel.Vendor
+//	@Failure		400		{object}	response.DataResponse
+//	@Param			search	query		string	false	"search by name, contact person, phone number or GSTIN"
+//	@Router			/vendor [get]
+func (h VendorHandler) GetAllVendors(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	search := ctx.Query("search")
+	search = util.EncloseWithSingleQuote(search)
+
+	vendors, errr := h.vendorSvc.GetAll(&context, search)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(vendors).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// Delete Vendor
+//
+//	@Summary		Delete Vendor
+//	@Description	Deletes an instance of Vendor
+//	@Tags			Vendor
+//	@Accept			json
+//	@Success		200	{object}	response.Response
+//	@Failure		400	{object}	response.Response
+//	@Param			id	path		int	true	"Vendor id"
+//	@Router			/vendor/{id} [delete]
+func (h VendorHandler) Delete(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	err := h.vendorSvc.Delete(&context, uint(id))
+	if err != nil {
+		h.resp.DefaultFailureResponse(err).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.resp.SuccessResponse("Delete Success").FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// Autocomplete for vendors
+//
+//	@Summary		Autocomplete for vendors
+//	@Description	Autocomplete for vendors while recording an expense
+//	@Tags			Vendor
+//	@Accept			json
+//	@Success		200		{object}	responseModel.VendorAutoComplete
+//	@Failure		400		{object}	response.DataResponse
+//	@Param			search	query		string	false	"search"
+//	@Router			/vendor/autocomplete [get]
+func (h VendorHandler) AutocompleteVendor(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	search := ctx.Query("search")
+	search = util.EncloseWithSingleQuote(search)
+
+	vendors, errr := h.vendorSvc.AutocompleteVendor(&context, search)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(vendors).FormatAndSend(&context, ctx, http.StatusOK)
+}
+
+// Get Vendor Spend
+//
+//	@Summary		Get spend per vendor
+//	@Description	Get the bill count and total expense of each vendor for a date range, the highest spend first
+//	@Tags			Vendor
+//	@Accept			json
+//	@Success		200		{object}	[]responseModel.VendorSpend
+//	@Failure		400		{object}	response.DataResponse
+//	@Param			from	query		string	false	"from date (YYYY-MM-DD), defaults to the start of the month"
+//	@Param			to		query		string	false	"to date (YYYY-MM-DD), defaults to today"
+//	@Router			/vendor/spend [get]
+func (h VendorHandler) GetSpend(ctx *gin.Context) {
+	context := util.CopyContextFromGin(ctx)
+
+	from, err := parseDateQuery(ctx, "from")
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, "Invalid from date, expected YYYY-MM-DD", err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	to, err := parseDateQuery(ctx, "to")
+	if err != nil {
+		x := errs.NewXError(errs.INVALID_REQUEST, "Invalid to date, expected YYYY-MM-DD", err)
+		h.resp.DefaultFailureResponse(x).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	spend, errr := h.vendorSvc.GetSpend(&context, from, to)
+	if errr != nil {
+		h.resp.DefaultFailureResponse(errr).FormatAndSend(&context, ctx, http.StatusBadRequest)
+		return
+	}
+
+	h.dataResp.DefaultSuccessResponse(spend).FormatAndSend(&context, ctx, http.StatusOK)
+}
@@ -1,6 +1,8 @@
 package mapper
 
 import (
+	"errors"
+	"strings"
 	"time"
 
 	"github.com/imkarthi24/sf-backend/internal/entities"
@@ -28,6 +30,16 @@ type Mapper interface {
 	MeasurementHistory(e requestModel.MeasurementHistory) (*entities.MeasurementHistory, error)
 	ExpenseTracker(e requestModel.ExpenseTracker) (*entities.Expense, error)
 	Task(e requestModel.Task) (*entities.Task, error)
+	Payment(e requestModel.Payment) (*entities.Payment, error)
+	OrderItemAssignment(e requestModel.OrderItemAssignment) (*entities.OrderItemAssignment, error)
+	Material(e requestModel.Material) (*entities.Material, error)
+	StockMovement(e requestModel.StockMovement) (*entities.StockMovement, error)
+	Vendor(e requestModel.Vendor) (*entities.Vendor, error)
+	ExpenseCategory(e requestModel.ExpenseCategory) (*entities.ExpenseCategory, error)
+	RecurringExpense(e requestModel.RecurringExpense) (*entities.RecurringExpense, error)
+	ExpenseBudget(e requestModel.ExpenseBudget) (*entities.ExpenseBudget, error)
+	Appointment(e requestModel.Appointment) (*entities.Appointment, error)
+	ApiKey(e requestModel.ApiKey) (*entities.ApiKey, error)
 }
 
 type mapper struct{}
@@ -215,11 +227,23 @@ func (m *mapper) Person(e requestModel.Person) (*entities.Person, error) {
 }
 
 func (m *mapper) DressType(e requestModel.DressType) (*entities.DressType, error) {
+	var measurementFields entities.MeasurementFields
+	for _, field := range e.MeasurementFields {
+		measurementFields = append(measurementFields, entities.MeasurementField{
+			Name:     field.Name,
+			Unit:     entities.MeasurementUnit(field.Unit),
+			Required: field.Required,
+			Min:      field.Min,
+			Max:      field.Max,
+		})
+	}
+
 	return &entities.DressType{
-		Model:        &entities.Model{ID: e.ID, IsActive: e.IsActive},
-		Name:         e.Name,
-		Description:  e.Description,
-		Measurements: e.Measurements,
+		Model:             &entities.Model{ID: e.ID, IsActive: e.IsActive},
+		Name:              e.Name,
+		Description:       e.Description,
+		Measurements:      e.Measurements,
+		MeasurementFields: measurementFields,
 	}, nil
 }
 
@@ -378,6 +402,7 @@ func (m *mapper) OrderHistory(e requestModel.OrderHistory) (*entities.OrderHisto
 		Status:               status,
 		ExpectedDeliveryDate: expectedDeliveryDate,
 		DeliveredDate:        deliveredDate,
+		Reason:               e.Reason,
 		OrderItemId:          e.OrderItemId,
 		OrderItemData:        &orderItemData,
 		OrderId:              e.OrderId,
@@ -435,6 +460,8 @@ func (m *mapper) ExpenseTracker(e requestModel.ExpenseTracker) (*entities.Expens
 		Price:        e.Price,
 		Location:     e.Location,
 		Notes:        e.Notes,
+		VendorId:     e.VendorId,
+		CategoryId:   e.CategoryId,
 	}, nil
 }
 
@@ -483,3 +510,232 @@ func (m *mapper) Task(e requestModel.Task) (*entities.Task, error) {
 		AssignedToId: e.AssignedToId,
 	}, nil
 }
+
+func (m *mapper) Payment(e requestModel.Payment) (*entities.Payment, error) {
+	var paymentDate *time.Time
+	if e.PaymentDate != nil {
+		date, err := util.GenerateDateTimeFromString(e.PaymentDate)
+		if err != nil {
+			return nil, err
+		}
+		paymentDate = date
+	}
+
+	var isActive bool = true
+	if e.IsActive != nil {
+		isActive = *e.IsActive
+	}
+
+	return &entities.Payment{
+		Model:           &entities.Model{ID: e.ID, IsActive: isActive},
+		Amount:          e.Amount,
+		PaymentType:     entities.PaymentType(e.PaymentType),
+		PaymentMethod:   entities.PaymentMethod(e.PaymentMethod),
+		PaymentDate:     paymentDate,
+		ReferenceNumber: e.ReferenceNumber,
+		Notes:           e.Notes,
+		OrderId:         e.OrderId,
+		ReceivedById:    e.ReceivedById,
+	}, nil
+}
+
+func (m *mapper) OrderItemAssignment(e requestModel.OrderItemAssignment) (*entities.OrderItemAssignment, error) {
+	var dueDate *time.Time
+	if e.DueDate != nil {
+		date, err := util.GenerateDateTimeFromString(e.DueDate)
+		if err != nil {
+			return nil, err
+		}
+		dueDate = date
+	}
+
+	var isActive bool = true
+	if e.IsActive != nil {
+		isActive = *e.IsActive
+	}
+
+	return &entities.OrderItemAssignment{
+		Model:        &entities.Model{ID: e.ID, IsActive: isActive},
+		Stage:        entities.ProductionStage(e.Stage),
+		Status:       entities.AssignmentStatus(e.Status),
+		Quantity:     e.Quantity,
+		PieceRate:    e.PieceRate,
+		DueDate:      dueDate,
+		Notes:        e.Notes,
+		OrderItemId:  e.OrderItemId,
+		AssignedToId: e.AssignedToId,
+	}, nil
+}
+
+func (m *mapper) Material(e requestModel.Material) (*entities.Material, error) {
+	var isActive bool = true
+	if e.IsActive != nil {
+		isActive = *e.IsActive
+	}
+
+	return &entities.Material{
+		Model:    &entities.Model{ID: e.ID, IsActive: isActive},
+		Name:     e.Name,
+		Code:     e.Code,
+		Category: e.Category,
+		Unit:     e.Unit,
+		Notes:    e.Notes,
+	}, nil
+}
+
+func (m *mapper) StockMovement(e requestModel.StockMovement) (*entities.StockMovement, error) {
+	movedAt := util.GetLocalTime()
+	if e.MovedAt != nil {
+		date, err := util.GenerateDateTimeFromString(e.MovedAt)
+		if err != nil {
+			return nil, err
+		}
+		movedAt = *date
+	}
+
+	return &entities.StockMovement{
+		Model:       &entities.Model{IsActive: true},
+		Type:        entities.StockMovementType(e.Type),
+		Quantity:    e.Quantity,
+		UnitCost:    e.UnitCost,
+		MovedAt:     movedAt,
+		Notes:       e.Notes,
+		MaterialId:  e.MaterialId,
+		ExpenseId:   e.ExpenseId,
+		OrderItemId: e.OrderItemId,
+	}, nil
+}
+
+func (m *mapper) Vendor(e requestModel.Vendor) (*entities.Vendor, error) {
+	var isActive bool = true
+	if e.IsActive != nil {
+		isActive = *e.IsActive
+	}
+
+	return &entities.Vendor{
+		Model:         &entities.Model{ID: e.ID, IsActive: isActive},
+		Name:          e.Name,
+		ContactPerson: e.ContactPerson,
+		PhoneNumber:   e.PhoneNumber,
+		Email:         e.Email,
+		GSTIN:         e.GSTIN,
+		Address:       e.Address,
+		Notes:         e.Notes,
+	}, nil
+}
+
+func (m *mapper) ExpenseCategory(e requestModel.ExpenseCategory) (*entities.ExpenseCategory, error) {
+	var isActive bool = true
+	if e.IsActive != nil {
+		isActive = *e.IsActive
+	}
+
+	return &entities.ExpenseCategory{
+		Model:       &entities.Model{ID: e.ID, IsActive: isActive},
+		Name:        e.Name,
+		Description: e.Description,
+	}, nil
+}
+
+func (m *mapper) RecurringExpense(e requestModel.RecurringExpense) (*entities.RecurringExpense, error) {
+	startDate, err := util.GenerateDateTimeFromString(e.StartDate)
+	if err != nil {
+		return nil, err
+	}
+
+	var endDate *time.Time
+	if e.EndDate != nil {
+		endDate, err = util.GenerateDateTimeFromString(e.EndDate)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var isActive bool = true
+	if e.IsActive != nil {
+		isActive = *e.IsActive
+	}
+
+	return &entities.RecurringExpense{
+		Model:       &entities.Model{ID: e.ID, IsActive: isActive},
+		Name:        e.Name,
+		Frequency:   entities.RecurringFrequency(e.Frequency),
+		StartDate:   *startDate,
+		EndDate:     endDate,
+		CompanyName: e.CompanyName,
+		Material:    e.Material,
+		Price:       e.Price,
+		Location:    e.Location,
+		Notes:       e.Notes,
+		CategoryId:  e.CategoryId,
+		VendorId:    e.VendorId,
+	}, nil
+}
+
+func (m *mapper) ExpenseBudget(e requestModel.ExpenseBudget) (*entities.ExpenseBudget, error) {
+	month, err := time.ParseInLocation(entities.BudgetMonthLayout, e.Month, util.GetLocalTime().Location())
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.ExpenseBudget{
+		Model:      &entities.Model{IsActive: true},
+		Month:      month,
+		Amount:     e.Amount,
+		Notes:      e.Notes,
+		CategoryId: e.CategoryId,
+	}, nil
+}
+
+func (m *mapper) Appointment(e requestModel.Appointment) (*entities.Appointment, error) {
+	startTime, err := util.GenerateDateTimeFromString(e.StartTime)
+	if err != nil {
+		return nil, err
+	}
+	endTime, err := util.GenerateDateTimeFromString(e.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	if startTime == nil || endTime == nil {
+		return nil, errors.New("start time and end time are required")
+	}
+
+	var isActive bool = true
+	if e.IsActive != nil {
+		isActive = *e.IsActive
+	}
+
+	return &entities.Appointment{
+		Model:      &entities.Model{ID: e.ID, IsActive: isActive},
+		Type:       entities.AppointmentType(e.Type),
+		Status:     entities.AppointmentStatus(e.Status),
+		StartTime:  *startTime,
+		EndTime:    *endTime,
+		Notes:      e.Notes,
+		CustomerId: e.CustomerId,
+		OrderId:    e.OrderId,
+		StaffId:    e.StaffId,
+	}, nil
+}
+
+func (m *mapper) ApiKey(e requestModel.ApiKey) (*entities.ApiKey, error) {
+	expiresAt, err := util.GenerateDateTimeFromString(e.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := make([]string, 0)
+	for _, scope := range e.Scopes {
+		scope = strings.TrimSpace(scope)
+		if scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return &entities.ApiKey{
+		Model:     &entities.Model{IsActive: true},
+		Name:      strings.TrimSpace(e.Name),
+		Scopes:    strings.Join(scopes, ","),
+		ExpiresAt: expiresAt,
+	}, nil
+}
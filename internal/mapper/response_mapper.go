@@ -2,6 +2,7 @@ package mapper
 
 import (
 	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/imkarthi24/sf-backend/internal/entities"
@@ -47,6 +48,34 @@ type ResponseMapper interface {
 	ExpenseTrackers(items []entities.Expense) ([]responseModel.ExpenseTracker, error)
 	Task(e *entities.Task) (*responseModel.Task, error)
 	Tasks(items []entities.Task) ([]responseModel.Task, error)
+	Payment(e *entities.Payment) (*responseModel.Payment, error)
+	Payments(items []entities.Payment) ([]responseModel.Payment, error)
+	Attachment(e *entities.Attachment) (*responseModel.Attachment, error)
+	Attachments(items []entities.Attachment) ([]responseModel.Attachment, error)
+	AuditLog(e *entities.AuditLog) (*responseModel.AuditLog, error)
+	AuditLogs(items []entities.AuditLog) ([]responseModel.AuditLog, error)
+	OrderItemAssignment(e *entities.OrderItemAssignment) (*responseModel.OrderItemAssignment, error)
+	OrderItemAssignments(items []entities.OrderItemAssignment) ([]responseModel.OrderItemAssignment, error)
+	PayoutEntry(e *entities.PayoutEntry) (*responseModel.PayoutEntry, error)
+	PayoutEntries(items []entities.PayoutEntry) ([]responseModel.PayoutEntry, error)
+	PayoutSettlement(e *entities.PayoutSettlement) (*responseModel.PayoutSettlement, error)
+	PayoutSettlements(items []entities.PayoutSettlement) ([]responseModel.PayoutSettlement, error)
+	Material(e *entities.Material) (*responseModel.Material, error)
+	Materials(items []entities.Material) ([]responseModel.Material, error)
+	StockMovement(e *entities.StockMovement) (*responseModel.StockMovement, error)
+	StockMovements(items []entities.StockMovement) ([]responseModel.StockMovement, error)
+	Vendor(e *entities.Vendor) (*responseModel.Vendor, error)
+	Vendors(items []entities.Vendor) ([]responseModel.Vendor, error)
+	ExpenseCategory(e *entities.ExpenseCategory) (*responseModel.ExpenseCategory, error)
+	ExpenseCategories(items []entities.ExpenseCategory) ([]responseModel.ExpenseCategory, error)
+	RecurringExpense(e *entities.RecurringExpense) (*responseModel.RecurringExpense, error)
+	RecurringExpenses(items []entities.RecurringExpense) ([]responseModel.RecurringExpense, error)
+	ExpenseBudget(e *entities.ExpenseBudget) (*responseModel.ExpenseBudget, error)
+	ExpenseBudgets(items []entities.ExpenseBudget) ([]responseModel.ExpenseBudget, error)
+	Appointment(e *entities.Appointment) (*responseModel.Appointment, error)
+	Appointments(items []entities.Appointment) ([]responseModel.Appointment, error)
+	ApiKey(e *entities.ApiKey) (*responseModel.ApiKey, error)
+	ApiKeys(items []entities.ApiKey) ([]responseModel.ApiKey, error)
 }
 
 func ProvideResponseMapper() ResponseMapper {
@@ -196,6 +225,12 @@ func (m *responseMapper) EnquiryHistory(e *entities.EnquiryHistory) (*responseMo
 		statusStr = &str
 	}
 
+	var oldStatusStr *string
+	if e.OldStatus != nil {
+		str := string(*e.OldStatus)
+		oldStatusStr = &str
+	}
+
 	var performedBy *responseModel.User
 	if e.PerformedBy != nil {
 		user, err := m.User(e.PerformedBy)
@@ -209,6 +244,8 @@ func (m *responseMapper) EnquiryHistory(e *entities.EnquiryHistory) (*responseMo
 		ID:              e.ID,
 		IsActive:        e.IsActive,
 		Status:          statusStr,
+		OldStatus:       oldStatusStr,
+		ChangedFields:   e.ChangedFields,
 		EmployeeComment: e.EmployeeComment,
 		CustomerComment: e.CustomerComment,
 		VisitingDate:    visitingDateStr,
@@ -335,13 +372,25 @@ func (m *responseMapper) DressType(e *entities.DressType) (*responseModel.DressT
 		return nil, nil
 	}
 
+	var measurementFields []responseModel.MeasurementField
+	for _, field := range e.MeasurementFields {
+		measurementFields = append(measurementFields, responseModel.MeasurementField{
+			Name:     field.Name,
+			Unit:     string(field.Unit),
+			Required: field.Required,
+			Min:      field.Min,
+			Max:      field.Max,
+		})
+	}
+
 	return &responseModel.DressType{
-		ID:           e.ID,
-		IsActive:     e.IsActive,
-		Name:         e.Name,
-		Description:  e.Description,
-		Measurements: e.Measurements,
-		AuditFields:  responseModel.AuditFields{CreatedAt: e.CreatedAt, UpdatedAt: e.UpdatedAt, CreatedById: e.CreatedById, UpdatedById: e.UpdatedById},
+		ID:                e.ID,
+		IsActive:          e.IsActive,
+		Name:              e.Name,
+		Description:       e.Description,
+		Measurements:      e.Measurements,
+		MeasurementFields: measurementFields,
+		AuditFields:       responseModel.AuditFields{CreatedAt: e.CreatedAt, UpdatedAt: e.UpdatedAt, CreatedById: e.CreatedById, UpdatedById: e.UpdatedById},
 	}, nil
 }
 
@@ -398,6 +447,7 @@ func (m *responseMapper) Measurement(e *entities.Measurement) (*responseModel.Me
 		ID:          e.ID,
 		IsActive:    e.IsActive,
 		Values:      json.RawMessage(e.Value),
+		Unit:        string(e.Unit),
 		PersonId:    &e.PersonId,
 		Person:      person,
 		PersonName:  personName,
@@ -455,6 +505,19 @@ func (m *responseMapper) Order(e *entities.Order) (*responseModel.Order, error)
 		customerName = e.Customer.FirstName + " " + e.Customer.LastName
 	}
 
+	payments, err := m.Payments(e.Payments)
+	if err != nil {
+		return nil, err
+	}
+
+	paidAmount := e.PaidAmount
+	if paidAmount == 0 && len(e.Payments) > 0 {
+		for _, payment := range e.Payments {
+			paidAmount += payment.Amount
+		}
+	}
+	balanceAmount := orderValue + e.AdditionalCharges - paidAmount
+
 	return &responseModel.Order{
 		ID:                   e.ID,
 		IsActive:             e.IsActive,
@@ -469,8 +532,12 @@ func (m *responseMapper) Order(e *entities.Order) (*responseModel.Order, error)
 		OrderTakenBy:         orderTakenBy,
 		OrderQuantity:        orderQuantity,
 		OrderValue:           orderValue,
+		PaidAmount:           paidAmount,
+		BalanceAmount:        balanceAmount,
+		PaymentStatus:        string(paymentStatus(orderValue+e.AdditionalCharges, paidAmount)),
 		AuditFields:          responseModel.AuditFields{CreatedAt: e.CreatedAt, UpdatedAt: e.UpdatedAt, CreatedById: e.CreatedById, UpdatedById: e.UpdatedById},
 		OrderItems:           orderItems,
+		Payments:             payments,
 	}, nil
 }
 
@@ -506,6 +573,14 @@ func (m *responseMapper) OrderItem(e *entities.OrderItem) (*responseModel.OrderI
 		return nil, err
 	}
 
+	var assignments []responseModel.OrderItemAssignment
+	if len(e.Assignments) > 0 {
+		assignments, err = m.OrderItemAssignments(e.Assignments)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &responseModel.OrderItem{
 		ID:                   e.ID,
 		IsActive:             e.IsActive,
@@ -522,6 +597,7 @@ func (m *responseMapper) OrderItem(e *entities.OrderItem) (*responseModel.OrderI
 		Measurement:          measurement,
 		OrderId:              e.OrderId,
 		Order:                order,
+		Assignments:          assignments,
 		AuditFields:          responseModel.AuditFields{CreatedAt: e.CreatedAt, UpdatedAt: e.UpdatedAt, CreatedById: e.CreatedById, UpdatedById: e.UpdatedById},
 	}, nil
 }
@@ -549,9 +625,9 @@ func (m *responseMapper) OrderHistory(e *entities.OrderHistory) (*responseModel.
 		status = &s
 	}
 
-	var orderItemData string
+	var orderItemData json.RawMessage
 	if e.OrderItemData != nil {
-		orderItemData = string(*e.OrderItemData)
+		orderItemData = json.RawMessage(*e.OrderItemData)
 	}
 
 	order, err := m.Order(e.Order)
@@ -572,6 +648,7 @@ func (m *responseMapper) OrderHistory(e *entities.OrderHistory) (*responseModel.
 		Status:               status,
 		ExpectedDeliveryDate: e.ExpectedDeliveryDate,
 		DeliveredDate:        e.DeliveredDate,
+		Reason:               e.Reason,
 		OrderItemId:          e.OrderItemId,
 		OrderItemData:        orderItemData,
 		OrderId:              e.OrderId,
@@ -620,6 +697,7 @@ func (m *responseMapper) MeasurementHistory(e *entities.MeasurementHistory) (*re
 		IsActive:      e.IsActive,
 		Action:        string(e.Action),
 		OldValues:     oldValues,
+		Unit:          string(e.Unit),
 		MeasurementId: e.MeasurementId,
 		Measurement:   measurement,
 		PerformedAt:   e.PerformedAt,
@@ -656,7 +734,11 @@ func (m *responseMapper) ExpenseTracker(e *entities.Expense) (*responseModel.Exp
 		Price:        e.Price,
 		Location:     e.Location,
 		Notes:        e.Notes,
-		AuditFields:  responseModel.AuditFields{CreatedAt: e.CreatedAt, UpdatedAt: e.UpdatedAt, CreatedById: e.CreatedById, UpdatedById: e.UpdatedById},
+		VendorId:     e.VendorId,
+		CategoryId:   e.CategoryId,
+
+		RecurringExpenseId: e.RecurringExpenseId,
+		AuditFields:        responseModel.AuditFields{CreatedAt: e.CreatedAt, UpdatedAt: e.UpdatedAt, CreatedById: e.CreatedById, UpdatedById: e.UpdatedById},
 	}, nil
 }
 
@@ -702,3 +784,563 @@ func (m *responseMapper) Tasks(items []entities.Task) ([]responseModel.Task, err
 	}
 	return result, nil
 }
+
+func (m *responseMapper) Payment(e *entities.Payment) (*responseModel.Payment, error) {
+	if e == nil {
+		return nil, nil
+	}
+
+	var receivedBy string
+	if e.ReceivedBy != nil {
+		receivedBy = e.ReceivedBy.FirstName + " " + e.ReceivedBy.LastName
+	}
+
+	return &responseModel.Payment{
+		ID:              e.ID,
+		IsActive:        e.IsActive,
+		Amount:          e.Amount,
+		PaymentType:     string(e.PaymentType),
+		PaymentMethod:   string(e.PaymentMethod),
+		PaymentDate:     e.PaymentDate,
+		ReferenceNumber: e.ReferenceNumber,
+		Notes:           e.Notes,
+		OrderId:         e.OrderId,
+		ReceivedById:    e.ReceivedById,
+		ReceivedBy:      receivedBy,
+		AuditFields:     responseModel.AuditFields{CreatedAt: e.CreatedAt, UpdatedAt: e.UpdatedAt, CreatedById: e.CreatedById, UpdatedById: e.UpdatedById},
+	}, nil
+}
+
+func (m *responseMapper) Payments(items []entities.Payment) ([]responseModel.Payment, error) {
+	result := make([]responseModel.Payment, 0)
+	for _, item := range items {
+		mappedItem, err := m.Payment(&item)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *mappedItem)
+	}
+	return result, nil
+}
+
+func (m *responseMapper) Attachment(e *entities.Attachment) (*responseModel.Attachment, error) {
+	if e == nil {
+		return nil, nil
+	}
+
+	var uploadedBy string
+	if e.UploadedBy != nil {
+		uploadedBy = e.UploadedBy.FirstName + " " + e.UploadedBy.LastName
+	}
+
+	return &responseModel.Attachment{
+		ID:           e.ID,
+		IsActive:     e.IsActive,
+		EntityType:   string(e.EntityType),
+		EntityId:     e.EntityId,
+		Kind:         e.Kind,
+		FileName:     e.FileName,
+		ContentType:  e.ContentType,
+		Size:         e.Size,
+		UploadedById: e.UploadedById,
+		UploadedBy:   uploadedBy,
+		AuditFields:  responseModel.AuditFields{CreatedAt: e.CreatedAt, UpdatedAt: e.UpdatedAt, CreatedById: e.CreatedById, UpdatedById: e.UpdatedById},
+	}, nil
+}
+
+func (m *responseMapper) Attachments(items []entities.Attachment) ([]responseModel.Attachment, error) {
+	result := make([]responseModel.Attachment, 0)
+	for _, item := range items {
+		mappedItem, err := m.Attachment(&item)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *mappedItem)
+	}
+	return result, nil
+}
+
+func (m *responseMapper) AuditLog(e *entities.AuditLog) (*responseModel.AuditLog, error) {
+	if e == nil {
+		return nil, nil
+	}
+
+	var actor string
+	if e.Actor != nil {
+		actor = e.Actor.FirstName + " " + e.Actor.LastName
+	}
+
+	var changes json.RawMessage
+	if len(e.Changes) > 0 {
+		changes = json.RawMessage(e.Changes)
+	}
+
+	return &responseModel.AuditLog{
+		ID:          e.ID,
+		EntityName:  string(e.EntityName),
+		EntityId:    e.EntityId,
+		Action:      string(e.Action),
+		Changes:     changes,
+		ActorId:     e.ActorId,
+		Actor:       actor,
+		PerformedAt: e.PerformedAt,
+	}, nil
+}
+
+func (m *responseMapper) AuditLogs(items []entities.AuditLog) ([]responseModel.AuditLog, error) {
+	result := make([]responseModel.AuditLog, 0)
+	for _, item := range items {
+		mappedItem, err := m.AuditLog(&item)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *mappedItem)
+	}
+	return result, nil
+}
+
+func (m *responseMapper) OrderItemAssignment(e *entities.OrderItemAssignment) (*responseModel.OrderItemAssignment, error) {
+	if e == nil {
+		return nil, nil
+	}
+
+	orderItem, err := m.OrderItem(e.OrderItem)
+	if err != nil {
+		return nil, err
+	}
+
+	var assignedTo string
+	if e.AssignedTo != nil {
+		assignedTo = e.AssignedTo.FirstName + " " + e.AssignedTo.LastName
+	}
+
+	isOverdue := e.IsOpen() && e.DueDate != nil && e.DueDate.Before(util.GetLocalTime())
+
+	return &responseModel.OrderItemAssignment{
+		ID:           e.ID,
+		IsActive:     e.IsActive,
+		Stage:        string(e.Stage),
+		Status:       string(e.Status),
+		Quantity:     e.Quantity,
+		PieceRate:    e.PieceRate,
+		Amount:       float64(e.Quantity) * e.PieceRate,
+		DueDate:      e.DueDate,
+		StartedAt:    e.StartedAt,
+		CompletedAt:  e.CompletedAt,
+		IsOverdue:    isOverdue,
+		Notes:        e.Notes,
+		OrderItemId:  e.OrderItemId,
+		OrderItem:    orderItem,
+		AssignedToId: e.AssignedToId,
+		AssignedTo:   assignedTo,
+		AuditFields:  responseModel.AuditFields{CreatedAt: e.CreatedAt, UpdatedAt: e.UpdatedAt, CreatedById: e.CreatedById, UpdatedById: e.UpdatedById},
+	}, nil
+}
+
+func (m *responseMapper) OrderItemAssignments(items []entities.OrderItemAssignment) ([]responseModel.OrderItemAssignment, error) {
+	result := make([]responseModel.OrderItemAssignment, 0)
+	for _, item := range items {
+		mappedItem, err := m.OrderItemAssignment(&item)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *mappedItem)
+	}
+	return result, nil
+}
+
+func (m *responseMapper) PayoutEntry(e *entities.PayoutEntry) (*responseModel.PayoutEntry, error) {
+	if e == nil {
+		return nil, nil
+	}
+
+	var tailor string
+	if e.Tailor != nil {
+		tailor = e.Tailor.FirstName + " " + e.Tailor.LastName
+	}
+
+	return &responseModel.PayoutEntry{
+		ID:           e.ID,
+		IsActive:     e.IsActive,
+		Type:         string(e.Type),
+		Amount:       e.Amount,
+		Quantity:     e.Quantity,
+		PieceRate:    e.PieceRate,
+		Description:  e.Description,
+		AccruedAt:    e.AccruedAt,
+		TailorId:     e.TailorId,
+		Tailor:       tailor,
+		AssignmentId: e.AssignmentId,
+		SettlementId: e.SettlementId,
+		AuditFields:  responseModel.AuditFields{CreatedAt: e.CreatedAt, UpdatedAt: e.UpdatedAt, CreatedById: e.CreatedById, UpdatedById: e.UpdatedById},
+	}, nil
+}
+
+func (m *responseMapper) PayoutEntries(items []entities.PayoutEntry) ([]responseModel.PayoutEntry, error) {
+	result := make([]responseModel.PayoutEntry, 0)
+	for _, item := range items {
+		mappedItem, err := m.PayoutEntry(&item)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *mappedItem)
+	}
+	return result, nil
+}
+
+func (m *responseMapper) PayoutSettlement(e *entities.PayoutSettlement) (*responseModel.PayoutSettlement, error) {
+	if e == nil {
+		return nil, nil
+	}
+
+	var tailor string
+	if e.Tailor != nil {
+		tailor = e.Tailor.FirstName + " " + e.Tailor.LastName
+	}
+
+	var settledBy string
+	if e.SettledBy != nil {
+		settledBy = e.SettledBy.FirstName + " " + e.SettledBy.LastName
+	}
+
+	var entries []responseModel.PayoutEntry
+	if len(e.Entries) > 0 {
+		var err error
+		entries, err = m.PayoutEntries(e.Entries)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &responseModel.PayoutSettlement{
+		ID:              e.ID,
+		IsActive:        e.IsActive,
+		Amount:          e.Amount,
+		EntryCount:      e.EntryCount,
+		PeriodFrom:      e.PeriodFrom,
+		PeriodTo:        e.PeriodTo,
+		SettledAt:       e.SettledAt,
+		PaymentMethod:   string(e.PaymentMethod),
+		ReferenceNumber: e.ReferenceNumber,
+		Notes:           e.Notes,
+		TailorId:        e.TailorId,
+		Tailor:          tailor,
+		SettledById:     e.SettledById,
+		SettledBy:       settledBy,
+		Entries:         entries,
+		AuditFields:     responseModel.AuditFields{CreatedAt: e.CreatedAt, UpdatedAt: e.UpdatedAt, CreatedById: e.CreatedById, UpdatedById: e.UpdatedById},
+	}, nil
+}
+
+func (m *responseMapper) PayoutSettlements(items []entities.PayoutSettlement) ([]responseModel.PayoutSettlement, error) {
+	result := make([]responseModel.PayoutSettlement, 0)
+	for _, item := range items {
+		mappedItem, err := m.PayoutSettlement(&item)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *mappedItem)
+	}
+	return result, nil
+}
+
+// paymentStatus derives the payment status of an order from the payable and paid amounts
+func paymentStatus(payableAmount float64, paidAmount float64) entities.PaymentStatus {
+	if paidAmount <= 0 {
+		return entities.PaymentStatusUnpaid
+	}
+	if paidAmount < payableAmount {
+		return entities.PaymentStatusPartiallyPaid
+	}
+	return entities.PaymentStatusPaid
+}
+
+func (m *responseMapper) Material(e *entities.Material) (*responseModel.Material, error) {
+	if e == nil {
+		return nil, nil
+	}
+
+	return &responseModel.Material{
+		ID:          e.ID,
+		IsActive:    e.IsActive,
+		Name:        e.Name,
+		Code:        e.Code,
+		Category:    e.Category,
+		Unit:        e.Unit,
+		Notes:       e.Notes,
+		AuditFields: responseModel.AuditFields{CreatedAt: e.CreatedAt, UpdatedAt: e.UpdatedAt, CreatedById: e.CreatedById, UpdatedById: e.UpdatedById},
+	}, nil
+}
+
+func (m *responseMapper) Materials(items []entities.Material) ([]responseModel.Material, error) {
+	result := make([]responseModel.Material, 0)
+	for _, item := range items {
+		mappedItem, err := m.Material(&item)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *mappedItem)
+	}
+	return result, nil
+}
+
+func (m *responseMapper) StockMovement(e *entities.StockMovement) (*responseModel.StockMovement, error) {
+	if e == nil {
+		return nil, nil
+	}
+
+	var material, unit string
+	if e.Material != nil {
+		material = e.Material.Name
+		unit = e.Material.Unit
+	}
+
+	var billNumber *string
+	if e.Expense != nil {
+		billNumber = &e.Expense.BillNumber
+	}
+
+	return &responseModel.StockMovement{
+		ID:          e.ID,
+		IsActive:    e.IsActive,
+		Type:        string(e.Type),
+		Quantity:    e.Quantity,
+		UnitCost:    e.UnitCost,
+		MovedAt:     e.MovedAt,
+		Notes:       e.Notes,
+		MaterialId:  e.MaterialId,
+		Material:    material,
+		Unit:        unit,
+		ExpenseId:   e.ExpenseId,
+		BillNumber:  billNumber,
+		OrderItemId: e.OrderItemId,
+		AuditFields: responseModel.AuditFields{CreatedAt: e.CreatedAt, UpdatedAt: e.UpdatedAt, CreatedById: e.CreatedById, UpdatedById: e.UpdatedById},
+	}, nil
+}
+
+func (m *responseMapper) StockMovements(items []entities.StockMovement) ([]responseModel.StockMovement, error) {
+	result := make([]responseModel.StockMovement, 0)
+	for _, item := range items {
+		mappedItem, err := m.StockMovement(&item)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *mappedItem)
+	}
+	return result, nil
+}
+
+func (m *responseMapper) Vendor(e *entities.Vendor) (*responseModel.Vendor, error) {
+	if e == nil {
+		return nil, nil
+	}
+
+	return &responseModel.Vendor{
+		ID:            e.ID,
+		IsActive:      e.IsActive,
+		Name:          e.Name,
+		ContactPerson: e.ContactPerson,
+		PhoneNumber:   e.PhoneNumber,
+		Email:         e.Email,
+		GSTIN:         e.GSTIN,
+		Address:       e.Address,
+		Notes:         e.Notes,
+		AuditFields:   responseModel.AuditFields{CreatedAt: e.CreatedAt, UpdatedAt: e.UpdatedAt, CreatedById: e.CreatedById, UpdatedById: e.UpdatedById},
+	}, nil
+}
+
+func (m *responseMapper) Vendors(items []entities.Vendor) ([]responseModel.Vendor, error) {
+	result := make([]responseModel.Vendor, 0)
+	for _, item := range items {
+		mappedItem, err := m.Vendor(&item)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *mappedItem)
+	}
+	return result, nil
+}
+
+func (m *responseMapper) ExpenseCategory(e *entities.ExpenseCategory) (*responseModel.ExpenseCategory, error) {
+	if e == nil {
+		return nil, nil
+	}
+
+	return &responseModel.ExpenseCategory{
+		ID:          e.ID,
+		IsActive:    e.IsActive,
+		Name:        e.Name,
+		Description: e.Description,
+		AuditFields: responseModel.AuditFields{CreatedAt: e.CreatedAt, UpdatedAt: e.UpdatedAt, CreatedById: e.CreatedById, UpdatedById: e.UpdatedById},
+	}, nil
+}
+
+func (m *responseMapper) ExpenseCategories(items []entities.ExpenseCategory) ([]responseModel.ExpenseCategory, error) {
+	result := make([]responseModel.ExpenseCategory, 0)
+	for _, item := range items {
+		mappedItem, err := m.ExpenseCategory(&item)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *mappedItem)
+	}
+	return result, nil
+}
+
+func (m *responseMapper) RecurringExpense(e *entities.RecurringExpense) (*responseModel.RecurringExpense, error) {
+	if e == nil {
+		return nil, nil
+	}
+
+	var category *string
+	if e.Category != nil {
+		category = &e.Category.Name
+	}
+
+	return &responseModel.RecurringExpense{
+		ID:          e.ID,
+		IsActive:    e.IsActive,
+		Name:        e.Name,
+		Frequency:   string(e.Frequency),
+		StartDate:   e.StartDate,
+		EndDate:     e.EndDate,
+		NextDueDate: e.NextDueDate,
+		CompanyName: e.CompanyName,
+		Material:    e.Material,
+		Price:       e.Price,
+		Location:    e.Location,
+		Notes:       e.Notes,
+		CategoryId:  e.CategoryId,
+		Category:    category,
+		VendorId:    e.VendorId,
+		AuditFields: responseModel.AuditFields{CreatedAt: e.CreatedAt, UpdatedAt: e.UpdatedAt, CreatedById: e.CreatedById, UpdatedById: e.UpdatedById},
+	}, nil
+}
+
+func (m *responseMapper) RecurringExpenses(items []entities.RecurringExpense) ([]responseModel.RecurringExpense, error) {
+	result := make([]responseModel.RecurringExpense, 0)
+	for _, item := range items {
+		mappedItem, err := m.RecurringExpense(&item)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *mappedItem)
+	}
+	return result, nil
+}
+
+func (m *responseMapper) ExpenseBudget(e *entities.ExpenseBudget) (*responseModel.ExpenseBudget, error) {
+	if e == nil {
+		return nil, nil
+	}
+
+	var category string
+	if e.Category != nil {
+		category = e.Category.Name
+	}
+
+	return &responseModel.ExpenseBudget{
+		ID:          e.ID,
+		Month:       e.Month.Format(entities.BudgetMonthLayout),
+		Amount:      e.Amount,
+		Notes:       e.Notes,
+		CategoryId:  e.CategoryId,
+		Category:    category,
+		AuditFields: responseModel.AuditFields{CreatedAt: e.CreatedAt, UpdatedAt: e.UpdatedAt, CreatedById: e.CreatedById, UpdatedById: e.UpdatedById},
+	}, nil
+}
+
+func (m *responseMapper) ExpenseBudgets(items []entities.ExpenseBudget) ([]responseModel.ExpenseBudget, error) {
+	result := make([]responseModel.ExpenseBudget, 0)
+	for _, item := range items {
+		mappedItem, err := m.ExpenseBudget(&item)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *mappedItem)
+	}
+	return result, nil
+}
+
+func (m *responseMapper) Appointment(e *entities.Appointment) (*responseModel.Appointment, error) {
+	if e == nil {
+		return nil, nil
+	}
+
+	var customerName, customerPhone string
+	if e.Customer != nil {
+		customerName = strings.TrimSpace(e.Customer.FirstName + " " + e.Customer.LastName)
+		customerPhone = e.Customer.PhoneNumber
+	}
+
+	var staff string
+	if e.Staff != nil {
+		staff = e.Staff.FirstName + " " + e.Staff.LastName
+	}
+
+	return &responseModel.Appointment{
+		ID:               e.ID,
+		IsActive:         e.IsActive,
+		Type:             string(e.Type),
+		Status:           string(e.Status),
+		StartTime:        e.StartTime,
+		EndTime:          e.EndTime,
+		Notes:            e.Notes,
+		ReminderQueuedAt: e.ReminderQueuedAt,
+		CustomerId:       e.CustomerId,
+		CustomerName:     customerName,
+		CustomerPhone:    customerPhone,
+		OrderId:          e.OrderId,
+		StaffId:          e.StaffId,
+		Staff:            staff,
+		AuditFields:      responseModel.AuditFields{CreatedAt: e.CreatedAt, UpdatedAt: e.UpdatedAt, CreatedById: e.CreatedById, UpdatedById: e.UpdatedById},
+	}, nil
+}
+
+func (m *responseMapper) Appointments(items []entities.Appointment) ([]responseModel.Appointment, error) {
+	result := make([]responseModel.Appointment, 0)
+	for _, item := range items {
+		mappedItem, err := m.Appointment(&item)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *mappedItem)
+	}
+	return result, nil
+}
+
+func (m *responseMapper) ApiKey(e *entities.ApiKey) (*responseModel.ApiKey, error) {
+	if e == nil {
+		return nil, nil
+	}
+
+	scopes := make([]string, 0)
+	if e.Scopes != "" {
+		scopes = strings.Split(e.Scopes, ",")
+	}
+
+	return &responseModel.ApiKey{
+		ID:          e.ID,
+		IsActive:    e.IsActive,
+		Name:        e.Name,
+		KeyId:       e.KeyId,
+		Scopes:      scopes,
+		Status:      string(e.Status(time.Now())),
+		ExpiresAt:   e.ExpiresAt,
+		LastUsedAt:  e.LastUsedAt,
+		RevokedAt:   e.RevokedAt,
+		AuditFields: responseModel.AuditFields{CreatedAt: e.CreatedAt, UpdatedAt: e.UpdatedAt, CreatedById: e.CreatedById, UpdatedById: e.UpdatedById},
+	}, nil
+}
+
+func (m *responseMapper) ApiKeys(items []entities.ApiKey) ([]responseModel.ApiKey, error) {
+	result := make([]responseModel.ApiKey, 0)
+	for _, item := range items {
+		mappedItem, err := m.ApiKey(&item)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *mappedItem)
+	}
+	return result, nil
+}
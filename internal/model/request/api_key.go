@@ -0,0 +1,22 @@
+package requestModel
+
+// ApiKey issues a key for an external integration of the channel, the secret is returned only once
+type ApiKey struct {
+	Name string `json:"name" binding:"required"`
+
+	// Permissions of the key in the "resource:action" form, eg: ["master-config:read"]
+	Scopes []string `json:"scopes" binding:"required"`
+
+	ExpiresAt *string `json:"expiresAt,omitempty"`
+}
+
+// SignedRequest is an external request signed with the secret of an api key, read from the request headers
+type SignedRequest struct {
+	KeyId     string
+	Timestamp string // unix seconds
+	Signature string // hex HMAC-SHA256
+
+	Method     string
+	RequestURI string
+	Body       []byte
+}
@@ -0,0 +1,19 @@
+package requestModel
+
+// Appointment books a slot with a staff, use the status endpoint to complete or cancel it
+type Appointment struct {
+	ID       uint  `json:"id,omitempty"`
+	IsActive *bool `json:"isActive,omitempty"`
+
+	Type   string `json:"type" binding:"required"` // MEASUREMENT, FITTING or PICKUP
+	Status string `json:"status,omitempty"`        // defaults to SCHEDULED
+
+	StartTime *string `json:"startTime" binding:"required"`
+	EndTime   *string `json:"endTime" binding:"required"`
+
+	Notes string `json:"notes,omitempty"`
+
+	CustomerId uint  `json:"customerId" binding:"required"`
+	OrderId    *uint `json:"orderId,omitempty"`
+	StaffId    uint  `json:"staffId" binding:"required"`
+}
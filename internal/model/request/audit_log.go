@@ -0,0 +1,12 @@
+package requestModel
+
+import "time"
+
+// AuditLogFilter filters the audit logs, every filter is optional
+type AuditLogFilter struct {
+	EntityName string
+	EntityId   *uint
+	UserId     *uint
+	From       *time.Time
+	To         *time.Time // inclusive
+}
@@ -0,0 +1,36 @@
+package requestModel
+
+type ExpenseCategory struct {
+	ID       uint  `json:"id,omitempty"`
+	IsActive *bool `json:"isActive,omitempty"`
+
+	Name        string  `json:"name" binding:"required"`
+	Description *string `json:"description,omitempty"`
+}
+
+// RecurringExpense is a bill recorded as an expense on every due date, eg: rent on the 5th of every month
+type RecurringExpense struct {
+	ID       uint  `json:"id,omitempty"`
+	IsActive *bool `json:"isActive,omitempty"`
+
+	Name      string  `json:"name" binding:"required"`
+	Frequency string  `json:"frequency" binding:"required"` // MONTHLY, QUARTERLY or YEARLY
+	StartDate *string `json:"startDate" binding:"required"` // the first due date
+	EndDate   *string `json:"endDate,omitempty"`
+
+	CompanyName string  `json:"companyName"`
+	Material    string  `json:"material"`
+	Price       float64 `json:"price" binding:"required"`
+	Location    *string `json:"location,omitempty"`
+	Notes       *string `json:"notes,omitempty"`
+	CategoryId  *uint   `json:"categoryId,omitempty"`
+	VendorId    *uint   `json:"vendorId,omitempty"`
+}
+
+// ExpenseBudget sets the budget of a category for a month, saving it again for the month replaces the amount
+type ExpenseBudget struct {
+	CategoryId uint    `json:"categoryId" binding:"required"`
+	Month      string  `json:"month" binding:"required"` // YYYY-MM
+	Amount     float64 `json:"amount"`
+	Notes      *string `json:"notes,omitempty"`
+}
@@ -7,4 +7,14 @@ type DressType struct {
 	Name         string `json:"name,omitempty"`
 	Description  string `json:"description,omitempty"`
 	Measurements string `json:"measurements,omitempty"`
+
+	MeasurementFields []MeasurementField `json:"measurementFields,omitempty"`
+}
+
+type MeasurementField struct {
+	Name     string   `json:"name"`
+	Unit     string   `json:"unit"`
+	Required bool     `json:"required,omitempty"`
+	Min      *float64 `json:"min,omitempty"`
+	Max      *float64 `json:"max,omitempty"`
 }
@@ -20,3 +20,27 @@ type Enquiry struct {
 	ReferredBy          string `json:"referredBy,omitempty"`
 	ReferrerPhoneNumber string `json:"referrerPhoneNumber,omitempty"`
 }
+
+// ExternalEnquiry is the lead posted by the website contact form and the social lead forms.
+// Website is a honeypot which is hidden in the form, a filled value means the lead is from a bot
+type ExternalEnquiry struct {
+	FirstName      string `json:"firstName,omitempty"`
+	LastName       string `json:"lastName,omitempty"`
+	Email          string `json:"email,omitempty"`
+	PhoneNumber    string `json:"phoneNumber" binding:"required"`
+	WhatsappNumber string `json:"whatsappNumber,omitempty"`
+	Subject        string `json:"subject,omitempty"`
+	Notes          string `json:"notes,omitempty"`
+
+	// Platform of the lead form, eg: instagram, defaults to website
+	Platform string `json:"platform,omitempty"`
+
+	Website string `json:"website,omitempty"`
+}
+
+// ConvertEnquiry is the order to be created while converting an enquiry,
+// the customer of the order is always the customer of the enquiry
+type ConvertEnquiry struct {
+	Order   Order  `json:"order"`
+	Comment string `json:"comment,omitempty"`
+}
@@ -11,4 +11,6 @@ type ExpenseTracker struct {
 	Price        float64 `json:"price,omitempty"`
 	Location     *string `json:"location,omitempty"`
 	Notes        *string `json:"notes,omitempty"`
+	VendorId     *uint   `json:"vendorId,omitempty"` // the vendor is matched by the company name when not given
+	CategoryId   *uint   `json:"categoryId,omitempty"`
 }
@@ -0,0 +1,38 @@
+package requestModel
+
+import "time"
+
+type Material struct {
+	ID       uint  `json:"id,omitempty"`
+	IsActive *bool `json:"isActive,omitempty"`
+
+	Name     string  `json:"name" binding:"required"`
+	Code     *string `json:"code,omitempty"`
+	Category *string `json:"category,omitempty"`
+	Unit     string  `json:"unit" binding:"required"`
+	Notes    *string `json:"notes,omitempty"`
+}
+
+// StockMovement records a stock in (IN), a consumption (OUT) or a correction (ADJUSTMENT) of a material.
+// Quantity is positive for IN and OUT, an ADJUSTMENT takes the signed change.
+type StockMovement struct {
+	Type     string   `json:"type" binding:"required"`
+	Quantity float64  `json:"quantity" binding:"required"`
+	UnitCost *float64 `json:"unitCost,omitempty"`
+	MovedAt  *string  `json:"movedAt,omitempty"` // defaults to now
+	Notes    *string  `json:"notes,omitempty"`
+
+	MaterialId  uint  `json:"materialId" binding:"required"`
+	ExpenseId   *uint `json:"expenseId,omitempty"`   // the bill the material is bought with
+	OrderItemId *uint `json:"orderItemId,omitempty"` // required for OUT
+}
+
+// StockMovementFilter filters the stock movements, every filter is optional
+type StockMovementFilter struct {
+	MaterialId  *uint
+	ExpenseId   *uint
+	OrderItemId *uint
+	Type        string
+	From        *time.Time // inclusive
+	To          *time.Time // exclusive
+}
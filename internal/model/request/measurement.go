@@ -9,6 +9,7 @@ type Measurement struct {
 	IsActive bool `json:"isActive,omitempty"`
 
 	Values json.RawMessage `json:"values,omitempty"`
+	Unit   string          `json:"unit,omitempty"` // unit of the values, defaults to the channel unit
 
 	PersonId    *uint `json:"personId,omitempty"`
 	DressTypeId *uint `json:"dressTypeId,omitempty"`
@@ -18,9 +19,11 @@ type Measurement struct {
 type BulkMeasurementItem struct {
 	DressTypeId uint            `json:"dressTypeId"`
 	Values      json.RawMessage `json:"values"`
+	Unit        string          `json:"unit,omitempty"` // overrides the unit of the request
 }
 
 type BulkMeasurementRequest struct {
 	PersonId     uint                  `json:"personId"`
+	Unit         string                `json:"unit,omitempty"` // unit of the values, defaults to the channel unit
 	Measurements []BulkMeasurementItem `json:"measurements"`
 }
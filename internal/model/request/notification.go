@@ -14,3 +14,11 @@ type EmaiNotification struct {
 	Body          string `json:"body,omitempty"`
 	EmailContent  *email.EmailContent
 }
+
+type WhatsappNotification struct {
+	*Notification
+	ReceipientNumber    string `json:"receipientNumber,omitempty"`
+	ReceipientExtension string `json:"receipientExtension,omitempty"`
+	Subject             string `json:"subject,omitempty"`
+	Body                string `json:"body,omitempty"`
+}
@@ -8,6 +8,7 @@ type OrderHistory struct {
 	Status               *string `json:"status,omitempty"`
 	ExpectedDeliveryDate *string `json:"expectedDeliveryDate,omitempty"`
 	DeliveredDate        *string `json:"deliveredDate,omitempty"`
+	Reason               *string `json:"reason,omitempty"`
 	OrderItemId          *uint   `json:"orderItemId,omitempty"`
 	OrderItemData        string  `json:"orderItemData,omitempty"` // JSON string
 	OrderId              uint    `json:"orderId,omitempty"`
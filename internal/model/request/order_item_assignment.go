@@ -0,0 +1,29 @@
+package requestModel
+
+type OrderItemAssignment struct {
+	ID       uint  `json:"id,omitempty"`
+	IsActive *bool `json:"isActive,omitempty"`
+
+	Stage  string `json:"stage"`
+	Status string `json:"status,omitempty"`
+
+	Quantity  int     `json:"quantity,omitempty"`
+	PieceRate float64 `json:"pieceRate"`
+
+	DueDate *string `json:"dueDate,omitempty"`
+
+	Notes string `json:"notes,omitempty"`
+
+	OrderItemId  uint `json:"orderItemId"`
+	AssignedToId uint `json:"assignedToId"`
+}
+
+// OrderItemAssignmentFilter filters the assignments, every filter is optional
+type OrderItemAssignmentFilter struct {
+	OrderId      *uint
+	OrderItemId  *uint
+	AssignedToId *uint
+	Stage        string
+	Status       string
+	OpenOnly     bool // only the pending and in progress assignments, ignored when Status is given
+}
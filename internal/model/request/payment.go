@@ -0,0 +1,16 @@
+package requestModel
+
+type Payment struct {
+	ID       uint  `json:"id,omitempty"`
+	IsActive *bool `json:"isActive,omitempty"`
+
+	Amount          float64 `json:"amount"`
+	PaymentType     string  `json:"paymentType"`
+	PaymentMethod   string  `json:"paymentMethod"`
+	PaymentDate     *string `json:"paymentDate,omitempty"`
+	ReferenceNumber *string `json:"referenceNumber,omitempty"`
+	Notes           *string `json:"notes,omitempty"`
+
+	OrderId      uint  `json:"orderId"`
+	ReceivedById *uint `json:"receivedById,omitempty"`
+}
@@ -0,0 +1,19 @@
+package requestModel
+
+import "time"
+
+type PayoutSettlement struct {
+	TailorId        uint    `json:"tailorId" binding:"required"`
+	UpTo            *string `json:"upTo,omitempty"` // the entries accrued till this date (inclusive) are settled, defaults to today
+	PaymentMethod   string  `json:"paymentMethod"`
+	ReferenceNumber *string `json:"referenceNumber,omitempty"`
+	Notes           *string `json:"notes,omitempty"`
+}
+
+// PayoutFilter filters the payout entries and settlements, every filter is optional
+type PayoutFilter struct {
+	TailorId  *uint
+	Unsettled bool       // only the outstanding entries
+	From      *time.Time // inclusive
+	To        *time.Time // exclusive
+}
@@ -1,6 +1,6 @@
 package requestModel
 
-// Model used for Student and Enquiry status updates
+// Model used for Student, Enquiry and Order status updates
 type Status struct {
 	Status       string `json:"status,omitempty" binding:"required"`
 	StatusReason string `json:"statusReason,omitempty"`
@@ -0,0 +1,14 @@
+package requestModel
+
+type Vendor struct {
+	ID       uint  `json:"id,omitempty"`
+	IsActive *bool `json:"isActive,omitempty"`
+
+	Name          string  `json:"name" binding:"required"`
+	ContactPerson *string `json:"contactPerson,omitempty"`
+	PhoneNumber   *string `json:"phoneNumber,omitempty"`
+	Email         *string `json:"email,omitempty"`
+	GSTIN         *string `json:"gstin,omitempty"`
+	Address       *string `json:"address,omitempty"`
+	Notes         *string `json:"notes,omitempty"`
+}
@@ -0,0 +1,25 @@
+package responseModel
+
+import "time"
+
+type ApiKey struct {
+	ID       uint `json:"id,omitempty"`
+	IsActive bool `json:"isActive,omitempty"`
+
+	Name   string   `json:"name,omitempty"`
+	KeyId  string   `json:"keyId,omitempty"`
+	Scopes []string `json:"scopes"`
+	Status string   `json:"status,omitempty"` // ACTIVE, EXPIRED or REVOKED
+
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+
+	AuditFields
+}
+
+// ApiKeySecret is the issued or rotated key with its signing secret, the secret cannot be retrieved again
+type ApiKeySecret struct {
+	ApiKey
+	Secret string `json:"secret"`
+}
@@ -0,0 +1,43 @@
+package responseModel
+
+import "time"
+
+type Appointment struct {
+	ID       uint `json:"id,omitempty"`
+	IsActive bool `json:"isActive,omitempty"`
+
+	Type   string `json:"type,omitempty"`
+	Status string `json:"status,omitempty"`
+
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+
+	Notes string `json:"notes,omitempty"`
+
+	ReminderQueuedAt *time.Time `json:"reminderQueuedAt,omitempty"`
+
+	CustomerId    uint   `json:"customerId,omitempty"`
+	CustomerName  string `json:"customerName,omitempty"`
+	CustomerPhone string `json:"customerPhone,omitempty"`
+
+	OrderId *uint `json:"orderId,omitempty"`
+
+	StaffId uint   `json:"staffId,omitempty"`
+	Staff   string `json:"staff,omitempty"` // first_name + last_name
+
+	AuditFields
+}
+
+// AppointmentCalendar is the appointments of a day or a week grouped by day, the days without appointments are included
+type AppointmentCalendar struct {
+	View string `json:"view"` // day or week
+	From string `json:"from"` // YYYY-MM-DD
+	To   string `json:"to"`   // YYYY-MM-DD
+
+	Days []AppointmentCalendarDay `json:"days"`
+}
+
+type AppointmentCalendarDay struct {
+	Date         string        `json:"date"` // YYYY-MM-DD
+	Appointments []Appointment `json:"appointments"`
+}
@@ -0,0 +1,18 @@
+package responseModel
+
+type Attachment struct {
+	ID       uint `json:"id,omitempty"`
+	IsActive bool `json:"isActive,omitempty"`
+
+	EntityType  string `json:"entityType,omitempty"`
+	EntityId    uint   `json:"entityId,omitempty"`
+	Kind        string `json:"kind,omitempty"`
+	FileName    string `json:"fileName,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+	Size        int64  `json:"size,omitempty"`
+
+	UploadedById *uint  `json:"uploadedById,omitempty"`
+	UploadedBy   string `json:"uploadedBy,omitempty"` // first_name + last_name
+
+	AuditFields
+}
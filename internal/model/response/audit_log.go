@@ -0,0 +1,20 @@
+package responseModel
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditLog struct {
+	ID uint `json:"id,omitempty"`
+
+	EntityName string          `json:"entityName,omitempty"`
+	EntityId   uint            `json:"entityId,omitempty"`
+	Action     string          `json:"action,omitempty"`
+	Changes    json.RawMessage `json:"changes,omitempty"`
+
+	ActorId *uint  `json:"actorId,omitempty"`
+	Actor   string `json:"actor,omitempty"` // first_name + last_name
+
+	PerformedAt time.Time `json:"performedAt,omitempty"`
+}
@@ -0,0 +1,73 @@
+package responseModel
+
+import "time"
+
+type ExpenseCategory struct {
+	ID       uint `json:"id,omitempty"`
+	IsActive bool `json:"isActive,omitempty"`
+
+	Name        string  `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+
+	AuditFields
+}
+
+type RecurringExpense struct {
+	ID       uint `json:"id,omitempty"`
+	IsActive bool `json:"isActive,omitempty"`
+
+	Name        string     `json:"name,omitempty"`
+	Frequency   string     `json:"frequency,omitempty"`
+	StartDate   time.Time  `json:"startDate"`
+	EndDate     *time.Time `json:"endDate,omitempty"`
+	NextDueDate time.Time  `json:"nextDueDate"`
+
+	CompanyName string  `json:"companyName,omitempty"`
+	Material    string  `json:"material,omitempty"`
+	Price       float64 `json:"price"`
+	Location    *string `json:"location,omitempty"`
+	Notes       *string `json:"notes,omitempty"`
+
+	CategoryId *uint   `json:"categoryId,omitempty"`
+	Category   *string `json:"category,omitempty"`
+	VendorId   *uint   `json:"vendorId,omitempty"`
+
+	AuditFields
+}
+
+type ExpenseBudget struct {
+	ID uint `json:"id,omitempty"`
+
+	Month  string  `json:"month,omitempty"` // YYYY-MM
+	Amount float64 `json:"amount"`
+	Notes  *string `json:"notes,omitempty"`
+
+	CategoryId uint   `json:"categoryId,omitempty"`
+	Category   string `json:"category,omitempty"`
+
+	AuditFields
+}
+
+// BudgetReport compares the expenses of each category with its budget over a range of months
+type BudgetReport struct {
+	From string `json:"from"` // YYYY-MM
+	To   string `json:"to"`   // YYYY-MM
+
+	TotalBudget   float64 `json:"totalBudget"`
+	TotalActual   float64 `json:"totalActual"`
+	TotalVariance float64 `json:"totalVariance"`
+
+	Categories []BudgetReportLine `json:"categories"`
+}
+
+// BudgetReportLine is the budget and actual spend of a category, expenses without a category are grouped with a zero category id.
+// Variance is the budget left, negative when the category is over budget.
+type BudgetReportLine struct {
+	CategoryId         uint     `json:"categoryId" gorm:"column:category_id"`
+	Category           string   `json:"category" gorm:"column:category"`
+	Budget             float64  `json:"budget" gorm:"column:budget"`
+	Actual             float64  `json:"actual" gorm:"column:actual"`
+	Variance           float64  `json:"variance" gorm:"-"`
+	UtilizationPercent *float64 `json:"utilizationPercent,omitempty" gorm:"-"`
+	IsOverBudget       bool     `json:"isOverBudget" gorm:"-"`
+}
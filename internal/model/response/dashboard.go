@@ -1,66 +1,87 @@
 package responseModel
 
+import "time"
+
 type DashboardStats struct {
-	EnquiryStats []StatusCountStat `json:"enquiryStats,omitempty"`
-	StudentStats []StatusCountStat `json:"studentStats,omitempty"`
-	FeeStats     *FeeStat          `json:"feeStats,omitempty"`
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
 
-	AdmissionStats   *AdmissionStats   `json:"admissionStats,omitempty"`
-	PlacementStats   *PlacementStats   `json:"placementStats,omitempty"`
-	TelecallingStats *TelecallingStats `json:"telecallingStats,omitempty"`
-	BatchStats       *BatchStats       `json:"batchStats,omitempty"`
-	ReferrerStats    *ReferrerStats    `json:"referrerStats,omitempty"`
+	OrderStats        []StatusCountStat      `json:"orderStats,omitempty"`
+	RevenueStats      *RevenueStat           `json:"revenueStats,omitempty"`
+	OverdueDeliveries *OverdueDeliveryStats  `json:"overdueDeliveries,omitempty"`
+	EnquiryStats      *EnquiryConversionStat `json:"enquiryStats,omitempty"`
+	ReferrerStats     []ReferrerCountStat    `json:"referrerStats,omitempty"`
+	ExpenseStats      *ExpenseStat           `json:"expenseStats,omitempty"`
+	StaffWorkload     []StaffWorkloadStat    `json:"staffWorkload,omitempty"`
 }
 
 type StatusCountStat struct {
-	Status string `json:"status,omitempty"`
-	Count  int    `json:"count,omitempty"`
-}
-
-type FeeStat struct {
-	TotalFeeAmount float64 `json:"totalFeeAmount,omitempty"`
-	PaidAmount     float64 `json:"paidAmount,omitempty"`
-	PendingAmount  float64 `json:"pendingAmount,omitempty"`
+	Status string `json:"status,omitempty" gorm:"column:status"`
+	Count  int    `json:"count,omitempty" gorm:"column:count"`
 }
 
-type AdmissionStats struct {
-	SourceStats []SourceCountStat `json:"sourceStats,omitempty"`
-	JoinedCount int               `json:"joinedCount,omitempty"`
+type SourceCountStat struct {
+	Source         string  `json:"source,omitempty" gorm:"column:source"`
+	Count          int     `json:"count,omitempty" gorm:"column:count"`
+	AcceptedCount  int     `json:"acceptedCount,omitempty" gorm:"column:accepted_count"`
+	ConversionRate float64 `json:"conversionRate,omitempty" gorm:"-"`
 }
 
-type SourceCountStat struct {
-	Source string `json:"source,omitempty"`
-	Count  int    `json:"count,omitempty"`
+type DateAmountStat struct {
+	Date   string  `json:"date,omitempty" gorm:"column:date"`
+	Amount float64 `json:"amount,omitempty" gorm:"column:amount"`
 }
 
-type PlacementStats struct {
-	OffersCount         int     `json:"offersCount,omitempty"`
-	InterviewRatio      float64 `json:"interviewRatio,omitempty"`
-	TotalInterviews     int     `json:"totalInterviews,omitempty"`
-	SecondRoundsWaiting int     `json:"secondRoundsWaiting,omitempty"`
+// RevenueStat has the value of the orders booked and the payments collected in the range
+type RevenueStat struct {
+	BookedValue     float64          `json:"bookedValue,omitempty"`
+	CollectedAmount float64          `json:"collectedAmount,omitempty"`
+	DailyCollection []DateAmountStat `json:"dailyCollection,omitempty"`
 }
 
-type TelecallingStats struct {
-	CandidatesReached int `json:"candidatesReached,omitempty"`
-	PositiveCount     int `json:"positiveCount,omitempty"`
-	NegativeCount     int `json:"negativeCount,omitempty"`
-	MovedToStudents   int `json:"movedToStudents,omitempty"`
+type OverdueDeliveryStats struct {
+	Count  int                `json:"count,omitempty"`
+	Orders []OverdueOrderStat `json:"orders,omitempty"`
 }
 
-type BatchStats struct {
-	BatchesStarted         int               `json:"batchesStarted,omitempty"`
-	BatchStatusStats       []StatusCountStat `json:"batchStatusStats,omitempty"`
-	StudentAttendanceRatio float64           `json:"studentAttendanceRatio,omitempty"`
-	StudentTestRatio       float64           `json:"studentTestRatio,omitempty"`
+type OverdueOrderStat struct {
+	OrderId              uint       `json:"orderId,omitempty" gorm:"column:order_id"`
+	Status               string     `json:"status,omitempty" gorm:"column:status"`
+	CustomerName         string     `json:"customerName,omitempty" gorm:"column:customer_name"`
+	PhoneNumber          string     `json:"phoneNumber,omitempty" gorm:"column:phone_number"`
+	ExpectedDeliveryDate *time.Time `json:"expectedDeliveryDate,omitempty" gorm:"column:expected_delivery_date"`
+	DaysOverdue          int        `json:"daysOverdue,omitempty" gorm:"column:days_overdue"`
 }
 
-type ReferrerStats struct {
-	ReferrerStats []ReferrerCountStat `json:"referrerStats,omitempty"`
+type EnquiryConversionStat struct {
+	TotalCount     int               `json:"totalCount,omitempty"`
+	AcceptedCount  int               `json:"acceptedCount,omitempty"`
+	ConversionRate float64           `json:"conversionRate,omitempty"`
+	StatusStats    []StatusCountStat `json:"statusStats,omitempty"`
+	SourceStats    []SourceCountStat `json:"sourceStats,omitempty"`
 }
 
 type ReferrerCountStat struct {
 	Referrer       string `json:"referrer,omitempty" gorm:"column:referrer"`
 	ReferrerPhone  string `json:"referrerPhone,omitempty" gorm:"column:referrer_phone"`
 	EnquiriesCount int    `json:"enquiriesCount,omitempty" gorm:"column:enquiries_count"`
-	StudentsCount  int    `json:"studentsCount,omitempty" gorm:"column:students_count"`
+	AcceptedCount  int    `json:"acceptedCount,omitempty" gorm:"column:accepted_count"`
+}
+
+// ExpenseStat compares the expenses and the outsourced labour cost against the payments collected in the range
+type ExpenseStat struct {
+	TotalExpense    float64 `json:"totalExpense,omitempty"`
+	LabourCost      float64 `json:"labourCost,omitempty"`
+	TotalCost       float64 `json:"totalCost,omitempty"`
+	CollectedAmount float64 `json:"collectedAmount,omitempty"`
+	NetAmount       float64 `json:"netAmount,omitempty"`
+}
+
+type StaffWorkloadStat struct {
+	UserId            uint    `json:"userId,omitempty" gorm:"column:user_id"`
+	Name              string  `json:"name,omitempty" gorm:"column:name"`
+	OpenOrders        int     `json:"openOrders,omitempty" gorm:"column:open_orders"`
+	OverdueOrders     int     `json:"overdueOrders,omitempty" gorm:"column:overdue_orders"`
+	OrdersInRange     int     `json:"ordersInRange,omitempty" gorm:"column:orders_in_range"`
+	OrderValueInRange float64 `json:"orderValueInRange,omitempty" gorm:"column:order_value_in_range"`
 }
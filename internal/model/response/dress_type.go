@@ -8,5 +8,15 @@ type DressType struct {
 	Description  string `json:"description,omitempty"`
 	Measurements string `json:"measurements,omitempty"`
 
+	MeasurementFields []MeasurementField `json:"measurementFields,omitempty"`
+
 	AuditFields
 }
+
+type MeasurementField struct {
+	Name     string   `json:"name"`
+	Unit     string   `json:"unit"`
+	Required bool     `json:"required"`
+	Min      *float64 `json:"min,omitempty"`
+	Max      *float64 `json:"max,omitempty"`
+}
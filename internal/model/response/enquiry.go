@@ -15,3 +15,9 @@ type Enquiry struct {
 
 	AuditFields
 }
+
+type ConvertedEnquiry struct {
+	EnquiryId  uint `json:"enquiryId,omitempty"`
+	CustomerId uint `json:"customerId,omitempty"`
+	OrderId    uint `json:"orderId,omitempty"`
+}
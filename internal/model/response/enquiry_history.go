@@ -6,6 +6,8 @@ type EnquiryHistory struct {
 	ID              uint      `json:"id,omitempty"`
 	IsActive        bool      `json:"isActive,omitempty"`
 	Status          *string   `json:"status,omitempty"`
+	OldStatus       *string   `json:"oldStatus,omitempty"`
+	ChangedFields   string    `json:"changedFields,omitempty"`
 	EmployeeComment string    `json:"employeeComment,omitempty"`
 	CustomerComment string    `json:"customerComment,omitempty"`
 	VisitingDate    *string   `json:"visitingDate,omitempty"`
@@ -21,3 +23,18 @@ type EnquiryHistory struct {
 
 	AuditFields
 }
+
+// EnquiryCallback is an open enquiry whose latest history asks for a call back, with the employee responsible for it
+type EnquiryCallback struct {
+	EnquiryId       uint      `json:"enquiryId" gorm:"column:enquiry_id"`
+	ChannelId       uint      `json:"channelId" gorm:"column:channel_id"`
+	Subject         string    `json:"subject" gorm:"column:subject"`
+	CustomerName    string    `json:"customerName" gorm:"column:customer_name"`
+	CustomerPhone   string    `json:"customerPhone" gorm:"column:customer_phone"`
+	CallBackDate    time.Time `json:"callBackDate" gorm:"column:call_back_date"`
+	EmployeeComment string    `json:"employeeComment" gorm:"column:employee_comment"`
+	CustomerComment string    `json:"customerComment" gorm:"column:customer_comment"`
+	EmployeeId      uint      `json:"employeeId" gorm:"column:employee_id"`
+	EmployeeName    string    `json:"employeeName" gorm:"column:employee_name"`
+	EmployeeEmail   string    `json:"employeeEmail" gorm:"column:employee_email"`
+}
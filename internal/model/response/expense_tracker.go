@@ -13,6 +13,10 @@ type ExpenseTracker struct {
 	Price        float64    `json:"price,omitempty"`
 	Location     *string    `json:"location,omitempty"`
 	Notes        *string    `json:"notes,omitempty"`
+	VendorId     *uint      `json:"vendorId,omitempty"`
+	CategoryId   *uint      `json:"categoryId,omitempty"`
+
+	RecurringExpenseId *uint `json:"recurringExpenseId,omitempty"`
 
 	AuditFields
 }
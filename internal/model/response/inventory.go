@@ -0,0 +1,51 @@
+package responseModel
+
+import "time"
+
+type Material struct {
+	ID       uint `json:"id,omitempty"`
+	IsActive bool `json:"isActive,omitempty"`
+
+	Name     string  `json:"name,omitempty"`
+	Code     *string `json:"code,omitempty"`
+	Category *string `json:"category,omitempty"`
+	Unit     string  `json:"unit,omitempty"`
+	Notes    *string `json:"notes,omitempty"`
+
+	AuditFields
+}
+
+type StockMovement struct {
+	ID       uint `json:"id,omitempty"`
+	IsActive bool `json:"isActive,omitempty"`
+
+	Type     string    `json:"type,omitempty"`
+	Quantity float64   `json:"quantity"`
+	UnitCost *float64  `json:"unitCost,omitempty"`
+	MovedAt  time.Time `json:"movedAt"`
+	Notes    *string   `json:"notes,omitempty"`
+
+	MaterialId uint   `json:"materialId,omitempty"`
+	Material   string `json:"material,omitempty"`
+	Unit       string `json:"unit,omitempty"`
+
+	ExpenseId   *uint   `json:"expenseId,omitempty"`
+	BillNumber  *string `json:"billNumber,omitempty"`
+	OrderItemId *uint   `json:"orderItemId,omitempty"`
+
+	AuditFields
+}
+
+// StockLevel is the on hand quantity of a material in a channel
+type StockLevel struct {
+	MaterialId uint    `json:"materialId" gorm:"column:material_id"`
+	Material   string  `json:"material" gorm:"column:material"`
+	Code       *string `json:"code,omitempty" gorm:"column:code"`
+	Category   *string `json:"category,omitempty" gorm:"column:category"`
+	Unit       string  `json:"unit" gorm:"column:unit"`
+	ChannelId  uint    `json:"channelId" gorm:"column:channel_id"`
+	OnHand     float64 `json:"onHand" gorm:"column:on_hand"`
+
+	LowStockThreshold *float64 `json:"lowStockThreshold,omitempty" gorm:"-"`
+	IsLowStock        bool     `json:"isLowStock" gorm:"-"`
+}
@@ -9,6 +9,7 @@ type Measurement struct {
 	IsActive bool `json:"isActive,omitempty"`
 
 	Values json.RawMessage `json:"values,omitempty"`
+	Unit   string          `json:"unit,omitempty"`
 
 	PersonId   *uint   `json:"personId,omitempty"`
 	Person     *Person `json:"person,omitempty"`
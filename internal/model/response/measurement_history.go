@@ -11,6 +11,7 @@ type MeasurementHistory struct {
 	Action        string          `json:"action,omitempty"`
 	ChangedValues string          `json:"changedValues,omitempty"`
 	OldValues     json.RawMessage `json:"oldValues,omitempty"`
+	Unit          string          `json:"unit,omitempty"`
 	MeasurementId uint            `json:"measurementId,omitempty"`
 	Measurement   *Measurement    `json:"measurement,omitempty"`
 	PerformedAt   time.Time       `json:"performedAt,omitempty"`
@@ -19,3 +20,28 @@ type MeasurementHistory struct {
 
 	AuditFields
 }
+
+// MeasurementHistoryDiff compares two versions of a measurement field by field
+type MeasurementHistoryDiff struct {
+	MeasurementId uint                     `json:"measurementId,omitempty"`
+	Unit          string                   `json:"unit,omitempty"`
+	From          MeasurementVersion       `json:"from"`
+	To            MeasurementVersion       `json:"to"`
+	Changes       []MeasurementFieldChange `json:"changes"`
+}
+
+// MeasurementVersion is the values of a measurement before the action of a history,
+// HistoryId is empty for the current values
+type MeasurementVersion struct {
+	HistoryId   *uint           `json:"historyId,omitempty"`
+	Action      string          `json:"action,omitempty"`
+	PerformedAt *time.Time      `json:"performedAt,omitempty"`
+	Values      json.RawMessage `json:"values,omitempty"`
+}
+
+type MeasurementFieldChange struct {
+	Field    string      `json:"field"`
+	Change   string      `json:"change"`
+	OldValue interface{} `json:"oldValue,omitempty"`
+	NewValue interface{} `json:"newValue,omitempty"`
+}
@@ -25,9 +25,14 @@ type Order struct {
 	OrderQuantity int     `json:"orderQuantity,omitempty"` // sum of quantity from order items
 	OrderValue    float64 `json:"orderValue,omitempty"`    // sum of total from order items
 
+	PaidAmount    float64 `json:"paidAmount"`              // sum of amount from payments
+	BalanceAmount float64 `json:"balanceAmount"`           // order value + additional charges - paid amount
+	PaymentStatus string  `json:"paymentStatus,omitempty"` // UNPAID, PARTIALLY_PAID, PAID
+
 	AuditFields
 
 	OrderItems []OrderItem `json:"orderItems,omitempty"`
+	Payments   []Payment   `json:"payments,omitempty"`
 }
 
 type OrderItem struct {
@@ -51,5 +56,7 @@ type OrderItem struct {
 	OrderId uint   `json:"orderId,omitempty"`
 	Order   *Order `json:"order,omitempty"`
 
+	Assignments []OrderItemAssignment `json:"assignments,omitempty"`
+
 	AuditFields
 }
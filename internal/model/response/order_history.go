@@ -1,22 +1,26 @@
 package responseModel
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type OrderHistory struct {
-	ID                   uint       `json:"id,omitempty"`
-	IsActive             bool       `json:"isActive,omitempty"`
-	Action               string     `json:"action,omitempty"`
-	ChangedFields        string     `json:"changedFields,omitempty"`
-	Status               *string    `json:"status,omitempty"`
-	ExpectedDeliveryDate *time.Time `json:"expectedDeliveryDate,omitempty"`
-	DeliveredDate        *time.Time `json:"deliveredDate,omitempty"`
-	OrderItemId          *uint      `json:"orderItemId,omitempty"`
-	OrderItemData        string     `json:"orderItemData,omitempty"` // JSON string
-	OrderId              uint       `json:"orderId,omitempty"`
-	Order                *Order     `json:"order,omitempty"`
-	PerformedAt          time.Time  `json:"performedAt,omitempty"`
-	PerformedById        uint       `json:"performedById,omitempty"`
-	PerformedBy          *User      `json:"performedBy,omitempty"`
+	ID                   uint            `json:"id,omitempty"`
+	IsActive             bool            `json:"isActive,omitempty"`
+	Action               string          `json:"action,omitempty"`
+	ChangedFields        string          `json:"changedFields,omitempty"`
+	Status               *string         `json:"status,omitempty"`
+	ExpectedDeliveryDate *time.Time      `json:"expectedDeliveryDate,omitempty"`
+	DeliveredDate        *time.Time      `json:"deliveredDate,omitempty"`
+	Reason               *string         `json:"reason,omitempty"`
+	OrderItemId          *uint           `json:"orderItemId,omitempty"`
+	OrderItemData        json.RawMessage `json:"orderItemData,omitempty"` // description and field changes of the item
+	OrderId              uint            `json:"orderId,omitempty"`
+	Order                *Order          `json:"order,omitempty"`
+	PerformedAt          time.Time       `json:"performedAt,omitempty"`
+	PerformedById        uint            `json:"performedById,omitempty"`
+	PerformedBy          *User           `json:"performedBy,omitempty"`
 
 	AuditFields
 }
@@ -0,0 +1,30 @@
+package responseModel
+
+import "time"
+
+type OrderItemAssignment struct {
+	ID       uint `json:"id,omitempty"`
+	IsActive bool `json:"isActive,omitempty"`
+
+	Stage  string `json:"stage,omitempty"`
+	Status string `json:"status,omitempty"`
+
+	Quantity  int     `json:"quantity,omitempty"`
+	PieceRate float64 `json:"pieceRate"`
+	Amount    float64 `json:"amount"` // quantity * piece rate
+
+	DueDate     *time.Time `json:"dueDate,omitempty"`
+	StartedAt   *time.Time `json:"startedAt,omitempty"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	IsOverdue   bool       `json:"isOverdue"`
+
+	Notes string `json:"notes,omitempty"`
+
+	OrderItemId uint       `json:"orderItemId,omitempty"`
+	OrderItem   *OrderItem `json:"orderItem,omitempty"`
+
+	AssignedToId uint   `json:"assignedToId,omitempty"`
+	AssignedTo   string `json:"assignedTo,omitempty"` // first_name + last_name
+
+	AuditFields
+}
@@ -0,0 +1,22 @@
+package responseModel
+
+import "time"
+
+type Payment struct {
+	ID       uint `json:"id,omitempty"`
+	IsActive bool `json:"isActive,omitempty"`
+
+	Amount          float64    `json:"amount,omitempty"`
+	PaymentType     string     `json:"paymentType,omitempty"`
+	PaymentMethod   string     `json:"paymentMethod,omitempty"`
+	PaymentDate     *time.Time `json:"paymentDate,omitempty"`
+	ReferenceNumber *string    `json:"referenceNumber,omitempty"`
+	Notes           *string    `json:"notes,omitempty"`
+
+	OrderId uint `json:"orderId,omitempty"`
+
+	ReceivedById *uint  `json:"receivedById,omitempty"`
+	ReceivedBy   string `json:"receivedBy,omitempty"` // first_name + last_name
+
+	AuditFields
+}
@@ -0,0 +1,81 @@
+package responseModel
+
+import "time"
+
+type PayoutEntry struct {
+	ID       uint `json:"id,omitempty"`
+	IsActive bool `json:"isActive,omitempty"`
+
+	Type        string    `json:"type,omitempty"`
+	Amount      float64   `json:"amount"`
+	Quantity    int       `json:"quantity,omitempty"`
+	PieceRate   float64   `json:"pieceRate"`
+	Description string    `json:"description,omitempty"`
+	AccruedAt   time.Time `json:"accruedAt"`
+
+	TailorId uint   `json:"tailorId,omitempty"`
+	Tailor   string `json:"tailor,omitempty"` // first_name + last_name
+
+	AssignmentId uint  `json:"assignmentId,omitempty"`
+	SettlementId *uint `json:"settlementId,omitempty"`
+
+	AuditFields
+}
+
+type PayoutSettlement struct {
+	ID       uint `json:"id,omitempty"`
+	IsActive bool `json:"isActive,omitempty"`
+
+	Amount     float64    `json:"amount"`
+	EntryCount int        `json:"entryCount"`
+	PeriodFrom *time.Time `json:"periodFrom,omitempty"`
+	PeriodTo   time.Time  `json:"periodTo"`
+	SettledAt  time.Time  `json:"settledAt"`
+
+	PaymentMethod   string  `json:"paymentMethod,omitempty"`
+	ReferenceNumber *string `json:"referenceNumber,omitempty"`
+	Notes           *string `json:"notes,omitempty"`
+
+	TailorId uint   `json:"tailorId,omitempty"`
+	Tailor   string `json:"tailor,omitempty"` // first_name + last_name
+
+	SettledById *uint  `json:"settledById,omitempty"`
+	SettledBy   string `json:"settledBy,omitempty"` // first_name + last_name
+
+	Entries []PayoutEntry `json:"entries,omitempty"`
+
+	AuditFields
+}
+
+// PayoutOutstanding is the amount yet to be settled to a tailor
+type PayoutOutstanding struct {
+	TailorId        uint       `json:"tailorId,omitempty" gorm:"column:tailor_id"`
+	Tailor          string     `json:"tailor,omitempty" gorm:"column:tailor"`
+	Amount          float64    `json:"amount" gorm:"column:amount"`
+	EntryCount      int        `json:"entryCount" gorm:"column:entry_count"`
+	OldestAccruedAt *time.Time `json:"oldestAccruedAt,omitempty" gorm:"column:oldest_accrued_at"`
+}
+
+// PayoutStatement is the ledger of a tailor for a period, the work done adds to the balance and the settlements reduce it
+type PayoutStatement struct {
+	TailorId uint      `json:"tailorId"`
+	Tailor   string    `json:"tailor,omitempty"`
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+
+	OpeningBalance float64 `json:"openingBalance"`
+	Accrued        float64 `json:"accrued"`
+	Settled        float64 `json:"settled"`
+	ClosingBalance float64 `json:"closingBalance"`
+
+	Lines []PayoutStatementLine `json:"lines"`
+}
+
+type PayoutStatementLine struct {
+	Date        time.Time `json:"date"`
+	Type        string    `json:"type"` // ACCRUAL, REVERSAL or SETTLEMENT
+	Description string    `json:"description,omitempty"`
+	Reference   string    `json:"reference,omitempty"`
+	Amount      float64   `json:"amount"` // negative for reversals and settlements
+	Balance     float64   `json:"balance"`
+}
@@ -0,0 +1,34 @@
+package responseModel
+
+import "time"
+
+type Vendor struct {
+	ID       uint `json:"id,omitempty"`
+	IsActive bool `json:"isActive,omitempty"`
+
+	Name          string  `json:"name,omitempty"`
+	ContactPerson *string `json:"contactPerson,omitempty"`
+	PhoneNumber   *string `json:"phoneNumber,omitempty"`
+	Email         *string `json:"email,omitempty"`
+	GSTIN         *string `json:"gstin,omitempty"`
+	Address       *string `json:"address,omitempty"`
+	Notes         *string `json:"notes,omitempty"`
+
+	AuditFields
+}
+
+type VendorAutoComplete struct {
+	VendorID    uint    `json:"vendorId,omitempty"`
+	Name        string  `json:"name,omitempty"`
+	PhoneNumber *string `json:"phoneNumber,omitempty"`
+	GSTIN       *string `json:"gstin,omitempty"`
+}
+
+// VendorSpend is the expense billed by a vendor in a range, expenses without a vendor are grouped with a zero vendor id
+type VendorSpend struct {
+	VendorId         uint       `json:"vendorId" gorm:"column:vendor_id"`
+	Vendor           string     `json:"vendor" gorm:"column:vendor"`
+	BillCount        int        `json:"billCount" gorm:"column:bill_count"`
+	TotalSpend       float64    `json:"totalSpend" gorm:"column:total_spend"`
+	LastPurchaseDate *time.Time `json:"lastPurchaseDate,omitempty" gorm:"column:last_purchase_date"`
+}
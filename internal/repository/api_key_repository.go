@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/repository/scopes"
+	"github.com/loop-kar/pixie/errs"
+)
+
+type ApiKeyRepository interface {
+	Create(*context.Context, *entities.ApiKey) *errs.XError
+	Get(*context.Context, uint) (*entities.ApiKey, *errs.XError)
+	GetAll(*context.Context) ([]entities.ApiKey, *errs.XError)
+	GetByKeyId(*context.Context, string) (*entities.ApiKey, *errs.XError)
+	UpdateSecret(*context.Context, uint, string, string) *errs.XError
+	SetLastUsed(*context.Context, uint, time.Time) *errs.XError
+	Revoke(*context.Context, uint, time.Time) *errs.XError
+}
+
+type apiKeyRepository struct {
+	GormDAL
+}
+
+func ProvideApiKeyRepository(customDB GormDAL) ApiKeyRepository {
+	return &apiKeyRepository{GormDAL: customDB}
+}
+
+func (ar *apiKeyRepository) Create(ctx *context.Context, apiKey *entities.ApiKey) *errs.XError {
+	res := ar.WithDB(ctx).Create(&apiKey)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to save api key", res.Error)
+	}
+	return nil
+}
+
+func (ar *apiKeyRepository) Get(ctx *context.Context, id uint) (*entities.ApiKey, *errs.XError) {
+	apiKey := entities.ApiKey{}
+	res := ar.WithDB(ctx).
+		Scopes(scopes.Channel()).
+		Find(&apiKey, id)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find api key", res.Error)
+	}
+	return &apiKey, nil
+}
+
+// GetAll returns the keys of the channel including the revoked keys, so that their last use can be audited
+func (ar *apiKeyRepository) GetAll(ctx *context.Context) ([]entities.ApiKey, *errs.XError) {
+	var apiKeys []entities.ApiKey
+	res := ar.WithDB(ctx).
+		Scopes(scopes.Channel()).
+		Order("id DESC").
+		Find(&apiKeys)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find api keys", res.Error)
+	}
+	return apiKeys, nil
+}
+
+// GetByKeyId returns the key of any channel along with its channel, the request has no session yet
+func (ar *apiKeyRepository) GetByKeyId(ctx *context.Context, keyId string) (*entities.ApiKey, *errs.XError) {
+	apiKey := entities.ApiKey{}
+	res := ar.WithDB(ctx).
+		Preload("Channel", scopes.SelectFields("name", "status", "is_active")).
+		Where("key_id = ?", keyId).
+		Find(&apiKey)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find api key", res.Error)
+	}
+	return &apiKey, nil
+}
+
+func (ar *apiKeyRepository) UpdateSecret(ctx *context.Context, id uint, salt string, secretHash string) *errs.XError {
+	res := ar.WithDB(ctx).Model(&entities.ApiKey{Model: &entities.Model{ID: id}}).
+		Updates(map[string]interface{}{
+			"salt":        salt,
+			"secret_hash": secretHash,
+		})
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to rotate api key", res.Error)
+	}
+	return nil
+}
+
+func (ar *apiKeyRepository) SetLastUsed(ctx *context.Context, id uint, usedAt time.Time) *errs.XError {
+	res := ar.WithDB(ctx).Model(&entities.ApiKey{Model: &entities.Model{ID: id}}).
+		UpdateColumn("last_used_at", usedAt)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to update api key usage", res.Error)
+	}
+	return nil
+}
+
+func (ar *apiKeyRepository) Revoke(ctx *context.Context, id uint, revokedAt time.Time) *errs.XError {
+	res := ar.WithDB(ctx).Model(&entities.ApiKey{Model: &entities.Model{ID: id}}).
+		Updates(map[string]interface{}{
+			"revoked_at": revokedAt,
+			"is_active":  false,
+		})
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to revoke api key", res.Error)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/repository/scopes"
+	"github.com/loop-kar/pixie/errs"
+)
+
+type AppointmentRepository interface {
+	Create(*context.Context, *entities.Appointment) *errs.XError
+	Update(*context.Context, *entities.Appointment) *errs.XError
+	UpdateStatus(*context.Context, uint, entities.AppointmentStatus, string) *errs.XError
+	Get(*context.Context, uint) (*entities.Appointment, *errs.XError)
+	GetInRange(*context.Context, time.Time, time.Time, *uint) ([]entities.Appointment, *errs.XError)
+	GetStaffConflicts(*context.Context, uint, time.Time, time.Time, uint) ([]entities.Appointment, *errs.XError)
+	GetUpcomingForReminder(*context.Context, time.Time, time.Time) ([]entities.Appointment, *errs.XError)
+	SetReminderQueued(*context.Context, uint, time.Time) *errs.XError
+	Delete(*context.Context, uint) *errs.XError
+}
+
+type appointmentRepository struct {
+	GormDAL
+}
+
+func ProvideAppointmentRepository(customDB GormDAL) AppointmentRepository {
+	return &appointmentRepository{GormDAL: customDB}
+}
+
+func (ar *appointmentRepository) Create(ctx *context.Context, appointment *entities.Appointment) *errs.XError {
+	res := ar.WithDB(ctx).Create(&appointment)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to save appointment", res.Error)
+	}
+	return nil
+}
+
+func (ar *appointmentRepository) Update(ctx *context.Context, appointment *entities.Appointment) *errs.XError {
+	return ar.GormDAL.Update(ctx, *appointment)
+}
+
+// UpdateStatus updates only the status and the notes of an appointment
+func (ar *appointmentRepository) UpdateStatus(ctx *context.Context, id uint, status entities.AppointmentStatus, notes string) *errs.XError {
+	res := ar.WithDB(ctx).Model(&entities.Appointment{Model: &entities.Model{ID: id}}).
+		Updates(map[string]interface{}{
+			"status": status,
+			"notes":  notes,
+		})
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to update appointment status", res.Error)
+	}
+	return nil
+}
+
+func (ar *appointmentRepository) Get(ctx *context.Context, id uint) (*entities.Appointment, *errs.XError) {
+	appointment := entities.Appointment{}
+	res := ar.WithDB(ctx).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Preload("Customer", scopes.SelectFields("first_name", "last_name", "phone_number")).
+		Preload("Staff", scopes.SelectFields("first_name", "last_name")).
+		Find(&appointment, id)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find appointment", res.Error)
+	}
+	return &appointment, nil
+}
+
+// GetInRange returns the appointments starting on or after from and before to, optionally of a staff
+func (ar *appointmentRepository) GetInRange(ctx *context.Context, from time.Time, to time.Time, staffId *uint) ([]entities.Appointment, *errs.XError) {
+	var appointments []entities.Appointment
+	query := ar.WithDB(ctx).Table(entities.Appointment{}.TableNameForQuery()).
+		Scopes(scopes.Channel("E"), scopes.IsActive("E")).
+		Where("E.start_time >= ? AND E.start_time < ?", from, to)
+
+	if staffId != nil {
+		query = query.Where("E.staff_id = ?", *staffId)
+	}
+
+	res := query.
+		Preload("Customer", scopes.SelectFields("first_name", "last_name", "phone_number")).
+		Preload("Staff", scopes.SelectFields("first_name", "last_name")).
+		Order("E.start_time ASC, E.id ASC").
+		Find(&appointments)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find appointments", res.Error)
+	}
+	return appointments, nil
+}
+
+// GetStaffConflicts returns the appointments of the staff that overlap the slot, ignoring the cancelled
+// and missed appointments and the appointment being updated
+func (ar *appointmentRepository) GetStaffConflicts(ctx *context.Context, staffId uint, start time.Time, end time.Time, excludeId uint) ([]entities.Appointment, *errs.XError) {
+	var appointments []entities.Appointment
+	res := ar.WithDB(ctx).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Where("staff_id = ? AND id <> ?", staffId, excludeId).
+		Where("status IN ?", []entities.AppointmentStatus{entities.AppointmentStatusScheduled, entities.AppointmentStatusCompleted}).
+		Where("start_time < ? AND end_time > ?", end, start).
+		Order("start_time ASC").
+		Find(&appointments)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find conflicting appointments", res.Error)
+	}
+	return appointments, nil
+}
+
+// GetUpcomingForReminder returns the scheduled appointments between from and to that are yet to be reminded, ordered by channel.
+// Without a channel in the session (eg: the cron jobs) the appointments of all the channels are returned.
+func (ar *appointmentRepository) GetUpcomingForReminder(ctx *context.Context, from time.Time, to time.Time) ([]entities.Appointment, *errs.XError) {
+	var appointments []entities.Appointment
+	res := ar.WithDB(ctx).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Where("status = ? AND reminder_queued_at IS NULL", entities.AppointmentStatusScheduled).
+		Where("start_time > ? AND start_time <= ?", from, to).
+		Preload("Customer").
+		Preload("Staff", scopes.SelectFields("first_name", "last_name")).
+		Order("channel_id ASC, start_time ASC").
+		Find(&appointments)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find upcoming appointments", res.Error)
+	}
+	return appointments, nil
+}
+
+func (ar *appointmentRepository) SetReminderQueued(ctx *context.Context, id uint, queuedAt time.Time) *errs.XError {
+	res := ar.WithDB(ctx).Model(&entities.Appointment{Model: &entities.Model{ID: id}}).
+		Update("reminder_queued_at", queuedAt)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to update appointment reminder", res.Error)
+	}
+	return nil
+}
+
+func (ar *appointmentRepository) Delete(ctx *context.Context, id uint) *errs.XError {
+	appointment := &entities.Appointment{Model: &entities.Model{ID: id, IsActive: false}}
+	return ar.GormDAL.Delete(ctx, appointment)
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/repository/scopes"
+	"github.com/loop-kar/pixie/errs"
+)
+
+type AttachmentRepository interface {
+	Create(*context.Context, *entities.Attachment) *errs.XError
+	Get(*context.Context, uint) (*entities.Attachment, *errs.XError)
+	GetByEntity(*context.Context, entities.EntityName, uint) ([]entities.Attachment, *errs.XError)
+	EntityExists(*context.Context, entities.EntityName, uint) (bool, *errs.XError)
+	Delete(*context.Context, uint) *errs.XError
+}
+
+type attachmentRepository struct {
+	GormDAL
+}
+
+func ProvideAttachmentRepository(customDB GormDAL) AttachmentRepository {
+	return &attachmentRepository{GormDAL: customDB}
+}
+
+func (ar *attachmentRepository) Create(ctx *context.Context, attachment *entities.Attachment) *errs.XError {
+	res := ar.WithDB(ctx).Create(&attachment)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to save attachment", res.Error)
+	}
+	return nil
+}
+
+func (ar *attachmentRepository) Get(ctx *context.Context, id uint) (*entities.Attachment, *errs.XError) {
+	attachment := entities.Attachment{}
+	res := ar.WithDB(ctx).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Preload("UploadedBy", scopes.SelectFields("first_name", "last_name")).
+		Find(&attachment, id)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find attachment", res.Error)
+	}
+	return &attachment, nil
+}
+
+func (ar *attachmentRepository) GetByEntity(ctx *context.Context, entityType entities.EntityName, entityId uint) ([]entities.Attachment, *errs.XError) {
+	var attachments []entities.Attachment
+	res := ar.WithDB(ctx).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Where("entity_type = ? AND entity_id = ?", entityType, entityId).
+		Preload("UploadedBy", scopes.SelectFields("first_name", "last_name")).
+		Order("created_at DESC").
+		Find(&attachments)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find attachments", res.Error)
+	}
+	return attachments, nil
+}
+
+// EntityExists checks if the entity the file is attached to exists in the channel
+func (ar *attachmentRepository) EntityExists(ctx *context.Context, entityType entities.EntityName, entityId uint) (bool, *errs.XError) {
+	var model interface{}
+	switch entityType {
+	case entities.Entity_Customer:
+		model = &entities.Customer{}
+	case entities.Entity_Order:
+		model = &entities.Order{}
+	case entities.Entity_OrderItem:
+		model = &entities.OrderItem{}
+	case entities.Entity_Measurement:
+		model = &entities.Measurement{}
+	default:
+		return false, nil
+	}
+
+	var count int64
+	res := ar.WithDB(ctx).Model(model).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Where("id = ?", entityId).
+		Count(&count)
+	if res.Error != nil {
+		return false, errs.NewXError(errs.DATABASE, "Unable to find the attached entity", res.Error)
+	}
+	return count > 0, nil
+}
+
+func (ar *attachmentRepository) Delete(ctx *context.Context, id uint) *errs.XError {
+	attachment := &entities.Attachment{Model: &entities.Model{ID: id, IsActive: false}}
+	err := ar.GormDAL.Delete(ctx, attachment)
+	if err != nil {
+		return err
+	}
+	return nil
+}
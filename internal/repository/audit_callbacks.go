@@ -0,0 +1,315 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	entitiy_types "github.com/imkarthi24/sf-backend/internal/entities/types"
+	"github.com/loop-kar/pixie/constants"
+	"github.com/loop-kar/pixie/util"
+	"gorm.io/gorm"
+)
+
+const auditOldRowsKey = "audit:old_rows"
+
+// Entities which are not audited, the audit log itself and the outgoing messages
+var auditSkippedEntities = map[string]bool{
+	string(entities.Entity_AuditLog):             true,
+	string(entities.Entity_Notification):         true,
+	string(entities.Entity_WhatsappNotification): true,
+	"EmailNotification":                          true,
+}
+
+// Columns left out of the changes, they are tracked by the audit log itself
+var auditIgnoredColumns = map[string]bool{
+	"id":            true,
+	"created_at":    true,
+	"updated_at":    true,
+	"created_by_id": true,
+	"updated_by_id": true,
+	"channel_id":    true,
+}
+
+// Values of columns containing these are never written to the audit log
+var auditRedactedColumns = []string{"password", "secret", "token", "salt", "api_key"}
+
+const auditRedactedValue = "***"
+
+type auditChange struct {
+	Old interface{} `json:"old,omitempty"`
+	New interface{} `json:"new,omitempty"`
+}
+
+// RegisterAuditCallbacks records an AuditLog for every create, update and delete done through gorm.
+// The rows are read before and after the change to find the changed columns, so only statements on
+// entities with a primary key set are audited (eg: batch updates with only a where clause are not).
+// A soft delete, ie: is_active set to false, is recorded as a delete.
+func RegisterAuditCallbacks(db *gorm.DB) error {
+	err := db.Callback().Create().After("gorm:create").Register("audit:after_create", auditAfterCreate)
+	if err != nil {
+		return err
+	}
+
+	err = db.Callback().Update().Before("gorm:update").Register("audit:before_update", auditBeforeChange)
+	if err != nil {
+		return err
+	}
+	err = db.Callback().Update().After("gorm:update").Register("audit:after_update", auditAfterUpdate)
+	if err != nil {
+		return err
+	}
+
+	err = db.Callback().Delete().Before("gorm:delete").Register("audit:before_delete", auditBeforeChange)
+	if err != nil {
+		return err
+	}
+	return db.Callback().Delete().After("gorm:delete").Register("audit:after_delete", auditAfterDelete)
+}
+
+func auditAfterCreate(db *gorm.DB) {
+	if !isAudited(db) || db.Error != nil {
+		return
+	}
+
+	rows := fetchAuditRows(db, auditPrimaryKeys(db))
+	for id, row := range rows {
+		changes := make(map[string]auditChange)
+		for column, value := range row {
+			if value != nil {
+				changes[column] = auditChange{New: value}
+			}
+		}
+		createAuditLog(db, id, entities.AuditActionCreate, changes)
+	}
+}
+
+// auditBeforeChange keeps the rows as they were before the update or delete
+func auditBeforeChange(db *gorm.DB) {
+	if !isAudited(db) || db.Error != nil {
+		return
+	}
+
+	ids := auditPrimaryKeys(db)
+	if len(ids) == 0 {
+		return
+	}
+	db.InstanceSet(auditOldRowsKey, fetchAuditRows(db, ids))
+}
+
+func auditAfterUpdate(db *gorm.DB) {
+	if !isAudited(db) || db.Error != nil || db.RowsAffected == 0 {
+		return
+	}
+
+	oldRows := auditOldRows(db)
+	if len(oldRows) == 0 {
+		return
+	}
+
+	ids := make([]interface{}, 0, len(oldRows))
+	for id := range oldRows {
+		ids = append(ids, id)
+	}
+
+	newRows := fetchAuditRows(db, ids)
+	for id, oldRow := range oldRows {
+		newRow, ok := newRows[id]
+		if !ok {
+			continue
+		}
+
+		changes := make(map[string]auditChange)
+		for column, newValue := range newRow {
+			oldValue := oldRow[column]
+			if !isSameAuditValue(oldValue, newValue) {
+				changes[column] = auditChange{Old: oldValue, New: newValue}
+			}
+		}
+		if len(changes) == 0 {
+			continue
+		}
+
+		action := entities.AuditActionUpdate
+		if oldRow["is_active"] == true && newRow["is_active"] == false {
+			action = entities.AuditActionDelete
+		}
+		createAuditLog(db, id, action, changes)
+	}
+}
+
+func auditAfterDelete(db *gorm.DB) {
+	if !isAudited(db) || db.Error != nil || db.RowsAffected == 0 {
+		return
+	}
+
+	for id, oldRow := range auditOldRows(db) {
+		changes := make(map[string]auditChange)
+		for column, value := range oldRow {
+			if value != nil {
+				changes[column] = auditChange{Old: value}
+			}
+		}
+		createAuditLog(db, id, entities.AuditActionDelete, changes)
+	}
+}
+
+func isAudited(db *gorm.DB) bool {
+	return db.Statement.Schema != nil && db.Statement.Schema.PrioritizedPrimaryField != nil &&
+		!auditSkippedEntities[db.Statement.Schema.Name]
+}
+
+// auditPrimaryKeys returns the primary keys of the entities of the statement
+func auditPrimaryKeys(db *gorm.DB) []interface{} {
+	stmt := db.Statement
+	field := stmt.Schema.PrioritizedPrimaryField
+
+	ids := make([]interface{}, 0)
+	value := reflect.Indirect(stmt.ReflectValue)
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			id, isZero := field.ValueOf(stmt.Context, reflect.Indirect(value.Index(i)))
+			if !isZero {
+				ids = append(ids, id)
+			}
+		}
+	case reflect.Struct:
+		id, isZero := field.ValueOf(stmt.Context, value)
+		if !isZero {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// fetchAuditRows reads the rows as column name to value, keyed by the primary key.
+// A new session on the same connection is used so that the read is part of the same transaction.
+func fetchAuditRows(db *gorm.DB, ids []interface{}) map[uint]map[string]interface{} {
+	result := make(map[uint]map[string]interface{})
+	if len(ids) == 0 {
+		return result
+	}
+
+	primaryKey := db.Statement.Schema.PrioritizedPrimaryField.DBName
+	var rows []map[string]interface{}
+	res := db.Session(&gorm.Session{NewDB: true, SkipHooks: true}).
+		Table(db.Statement.Table).
+		Where(fmt.Sprintf("%s IN ?", primaryKey), ids).
+		Find(&rows)
+	if res.Error != nil {
+		db.AddError(res.Error)
+		return result
+	}
+
+	for _, row := range rows {
+		id, ok := toAuditId(row[primaryKey])
+		if !ok {
+			continue
+		}
+
+		values := make(map[string]interface{})
+		for column, value := range row {
+			if auditIgnoredColumns[column] {
+				continue
+			}
+			values[column] = normalizeAuditValue(value)
+		}
+		result[id] = values
+	}
+	return result
+}
+
+func auditOldRows(db *gorm.DB) map[uint]map[string]interface{} {
+	value, ok := db.InstanceGet(auditOldRowsKey)
+	if !ok {
+		return nil
+	}
+	rows, _ := value.(map[uint]map[string]interface{})
+	return rows
+}
+
+// createAuditLog saves the audit log with the user and channel of the statement
+func createAuditLog(db *gorm.DB, entityId uint, action entities.AuditAction, changes map[string]auditChange) {
+	redactAuditChanges(changes)
+
+	data, err := json.Marshal(changes)
+	if err != nil {
+		db.AddError(err)
+		return
+	}
+
+	auditLog := entities.AuditLog{
+		Model:       &entities.Model{IsActive: true},
+		EntityName:  entities.ToEntityName(db.Statement.Schema.Name),
+		EntityId:    entityId,
+		Action:      action,
+		Changes:     entitiy_types.JSON(data),
+		PerformedAt: util.GetLocalTime(),
+	}
+	if userId, ok := db.Get(constants.USER_ID); ok {
+		auditLog.ActorId, _ = userId.(*uint)
+		auditLog.CreatedById = auditLog.ActorId
+	}
+	if channelId, ok := db.Get(constants.CHANNEL_ID); ok {
+		auditLog.ChannelId, _ = channelId.(uint)
+	}
+
+	res := db.Session(&gorm.Session{NewDB: true, SkipHooks: true}).Create(&auditLog)
+	if res.Error != nil {
+		db.AddError(res.Error)
+	}
+}
+
+func normalizeAuditValue(value interface{}) interface{} {
+	if bytes, ok := value.([]byte); ok {
+		if json.Valid(bytes) {
+			return json.RawMessage(bytes)
+		}
+		return string(bytes)
+	}
+	return value
+}
+
+// redactAuditChanges hides the values of the sensitive columns, the column is still listed as changed
+func redactAuditChanges(changes map[string]auditChange) {
+	for column, change := range changes {
+		for _, redacted := range auditRedactedColumns {
+			if !strings.Contains(column, redacted) {
+				continue
+			}
+			if change.Old != nil {
+				change.Old = auditRedactedValue
+			}
+			if change.New != nil {
+				change.New = auditRedactedValue
+			}
+			changes[column] = change
+			break
+		}
+	}
+}
+
+func isSameAuditValue(oldValue interface{}, newValue interface{}) bool {
+	oldJSON, oldErr := json.Marshal(oldValue)
+	newJSON, newErr := json.Marshal(newValue)
+	return oldErr == nil && newErr == nil && string(oldJSON) == string(newJSON)
+}
+
+func toAuditId(value interface{}) (uint, bool) {
+	switch v := value.(type) {
+	case int64:
+		return uint(v), true
+	case int32:
+		return uint(v), true
+	case int:
+		return uint(v), true
+	case uint:
+		return v, true
+	case uint64:
+		return uint(v), true
+	}
+	return 0, false
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	"github.com/imkarthi24/sf-backend/internal/repository/scopes"
+	"github.com/loop-kar/pixie/db"
+	"github.com/loop-kar/pixie/errs"
+)
+
+type AuditLogRepository interface {
+	GetAll(*context.Context, requestModel.AuditLogFilter) ([]entities.AuditLog, *errs.XError)
+}
+
+type auditLogRepository struct {
+	GormDAL
+}
+
+func ProvideAuditLogRepository(customDB GormDAL) AuditLogRepository {
+	return &auditLogRepository{GormDAL: customDB}
+}
+
+func (alr *auditLogRepository) GetAll(ctx *context.Context, filter requestModel.AuditLogFilter) ([]entities.AuditLog, *errs.XError) {
+	var auditLogs []entities.AuditLog
+	query := alr.WithDB(ctx).Table(entities.AuditLog{}.TableNameForQuery()).
+		Scopes(scopes.Channel("E"))
+
+	if filter.EntityName != "" {
+		query = query.Where("E.entity_name = ?", filter.EntityName)
+	}
+	if filter.EntityId != nil {
+		query = query.Where("E.entity_id = ?", *filter.EntityId)
+	}
+	if filter.UserId != nil {
+		query = query.Where("E.actor_id = ?", *filter.UserId)
+	}
+	if filter.From != nil {
+		query = query.Where("E.performed_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("E.performed_at < ?", *filter.To) // exclusive
+	}
+
+	res := query.
+		Scopes(db.Paginate(ctx)).
+		Preload("Actor", scopes.SelectFields("first_name", "last_name")).
+		Order("E.performed_at DESC, E.id DESC").
+		Find(&auditLogs)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find audit logs", res.Error)
+	}
+	return auditLogs, nil
+}
@@ -0,0 +1,275 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	responseModel "github.com/imkarthi24/sf-backend/internal/model/response"
+	"github.com/imkarthi24/sf-backend/internal/repository/scopes"
+	"github.com/imkarthi24/sf-backend/internal/utils"
+	"github.com/loop-kar/pixie/db"
+	"github.com/loop-kar/pixie/errs"
+)
+
+type BudgetRepository interface {
+	CreateCategory(*context.Context, *entities.ExpenseCategory) *errs.XError
+	UpdateCategory(*context.Context, *entities.ExpenseCategory) *errs.XError
+	GetCategory(*context.Context, uint) (*entities.ExpenseCategory, *errs.XError)
+	GetCategoryByName(*context.Context, string) (*entities.ExpenseCategory, *errs.XError)
+	GetCategories(*context.Context, string) ([]entities.ExpenseCategory, *errs.XError)
+	DeleteCategory(*context.Context, uint) *errs.XError
+
+	CreateRecurringExpense(*context.Context, *entities.RecurringExpense) *errs.XError
+	UpdateRecurringExpense(*context.Context, *entities.RecurringExpense) *errs.XError
+	GetRecurringExpense(*context.Context, uint) (*entities.RecurringExpense, *errs.XError)
+	GetRecurringExpenses(*context.Context) ([]entities.RecurringExpense, *errs.XError)
+	DeleteRecurringExpense(*context.Context, uint) *errs.XError
+	GetDueRecurringExpenses(*context.Context, time.Time) ([]entities.RecurringExpense, *errs.XError)
+	SetNextDueDate(*context.Context, uint, time.Time) *errs.XError
+	IsRecurringExpenseRecorded(*context.Context, uint, time.Time) (bool, *errs.XError)
+
+	CreateBudget(*context.Context, *entities.ExpenseBudget) *errs.XError
+	UpdateBudget(*context.Context, *entities.ExpenseBudget) *errs.XError
+	GetBudget(*context.Context, uint, time.Time) (*entities.ExpenseBudget, *errs.XError)
+	GetBudgets(*context.Context, time.Time, time.Time) ([]entities.ExpenseBudget, *errs.XError)
+	DeleteBudget(*context.Context, uint) *errs.XError
+
+	GetBudgetTotals(*context.Context, time.Time, time.Time) ([]responseModel.BudgetReportLine, *errs.XError)
+	GetActualTotals(*context.Context, time.Time, time.Time) ([]responseModel.BudgetReportLine, *errs.XError)
+}
+
+type budgetRepository struct {
+	GormDAL
+}
+
+func ProvideBudgetRepository(customDB GormDAL) BudgetRepository {
+	return &budgetRepository{GormDAL: customDB}
+}
+
+func (br *budgetRepository) CreateCategory(ctx *context.Context, category *entities.ExpenseCategory) *errs.XError {
+	res := br.WithDB(ctx).Create(&category)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to save expense category", res.Error)
+	}
+	return nil
+}
+
+func (br *budgetRepository) UpdateCategory(ctx *context.Context, category *entities.ExpenseCategory) *errs.XError {
+	return br.GormDAL.Update(ctx, *category)
+}
+
+func (br *budgetRepository) GetCategory(ctx *context.Context, id uint) (*entities.ExpenseCategory, *errs.XError) {
+	category := entities.ExpenseCategory{}
+	res := br.WithDB(ctx).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Find(&category, id)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find expense category", res.Error)
+	}
+	return &category, nil
+}
+
+// GetCategoryByName finds the active category of the channel with the name, ignoring the case
+func (br *budgetRepository) GetCategoryByName(ctx *context.Context, name string) (*entities.ExpenseCategory, *errs.XError) {
+	category := entities.ExpenseCategory{}
+	res := br.WithDB(ctx).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Where("LOWER(name) = LOWER(?)", name).
+		Limit(1).
+		Find(&category)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find expense category by name", res.Error)
+	}
+	return &category, nil
+}
+
+func (br *budgetRepository) GetCategories(ctx *context.Context, search string) ([]entities.ExpenseCategory, *errs.XError) {
+	var categories []entities.ExpenseCategory
+	res := br.WithDB(ctx).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Scopes(scopes.ILike(search, "name", "description")).
+		Scopes(db.Paginate(ctx)).
+		Order("name ASC").
+		Find(&categories)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find expense categories", res.Error)
+	}
+	return categories, nil
+}
+
+func (br *budgetRepository) DeleteCategory(ctx *context.Context, id uint) *errs.XError {
+	category := &entities.ExpenseCategory{Model: &entities.Model{ID: id, IsActive: false}}
+	return br.GormDAL.Delete(ctx, category)
+}
+
+func (br *budgetRepository) CreateRecurringExpense(ctx *context.Context, recurringExpense *entities.RecurringExpense) *errs.XError {
+	res := br.WithDB(ctx).Create(&recurringExpense)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to save recurring expense", res.Error)
+	}
+	return nil
+}
+
+func (br *budgetRepository) UpdateRecurringExpense(ctx *context.Context, recurringExpense *entities.RecurringExpense) *errs.XError {
+	return br.GormDAL.Update(ctx, *recurringExpense)
+}
+
+func (br *budgetRepository) GetRecurringExpense(ctx *context.Context, id uint) (*entities.RecurringExpense, *errs.XError) {
+	recurringExpense := entities.RecurringExpense{}
+	res := br.WithDB(ctx).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Preload("Category").
+		Find(&recurringExpense, id)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find recurring expense", res.Error)
+	}
+	return &recurringExpense, nil
+}
+
+// GetRecurringExpenses returns the recurring expenses of the channel, the earliest due first
+func (br *budgetRepository) GetRecurringExpenses(ctx *context.Context) ([]entities.RecurringExpense, *errs.XError) {
+	var recurringExpenses []entities.RecurringExpense
+	res := br.WithDB(ctx).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Preload("Category").
+		Scopes(db.Paginate(ctx)).
+		Order("next_due_date ASC, name ASC").
+		Find(&recurringExpenses)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find recurring expenses", res.Error)
+	}
+	return recurringExpenses, nil
+}
+
+func (br *budgetRepository) DeleteRecurringExpense(ctx *context.Context, id uint) *errs.XError {
+	recurringExpense := &entities.RecurringExpense{Model: &entities.Model{ID: id, IsActive: false}}
+	return br.GormDAL.Delete(ctx, recurringExpense)
+}
+
+// GetDueRecurringExpenses returns the recurring expenses due on or before the date which have not ended, ordered by channel.
+// Without a channel in the session (eg: the cron jobs) the recurring expenses of all the channels are returned.
+func (br *budgetRepository) GetDueRecurringExpenses(ctx *context.Context, date time.Time) ([]entities.RecurringExpense, *errs.XError) {
+	var recurringExpenses []entities.RecurringExpense
+	res := br.WithDB(ctx).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Where("next_due_date <= ?", date).
+		Where("(end_date IS NULL OR next_due_date <= end_date)").
+		Order("channel_id ASC, id ASC").
+		Find(&recurringExpenses)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find due recurring expenses", res.Error)
+	}
+	return recurringExpenses, nil
+}
+
+func (br *budgetRepository) SetNextDueDate(ctx *context.Context, id uint, nextDueDate time.Time) *errs.XError {
+	res := br.WithDB(ctx).Model(&entities.RecurringExpense{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"next_due_date": nextDueDate,
+			"updated_by_id": utils.GetUserId(ctx),
+		})
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to update the next due date of the recurring expense", res.Error)
+	}
+	return nil
+}
+
+// IsRecurringExpenseRecorded tells whether the expense of the due date is already recorded from the recurring expense
+func (br *budgetRepository) IsRecurringExpenseRecorded(ctx *context.Context, recurringExpenseId uint, dueDate time.Time) (bool, *errs.XError) {
+	var count int64
+	res := br.WithDB(ctx).Model(&entities.Expense{}).
+		Scopes(scopes.IsActive()).
+		Where("recurring_expense_id = ? AND purchase_date = ?", recurringExpenseId, dueDate).
+		Count(&count)
+	if res.Error != nil {
+		return false, errs.NewXError(errs.DATABASE, "Unable to find the expenses of the recurring expense", res.Error)
+	}
+	return count > 0, nil
+}
+
+func (br *budgetRepository) CreateBudget(ctx *context.Context, budget *entities.ExpenseBudget) *errs.XError {
+	res := br.WithDB(ctx).Create(&budget)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to save budget", res.Error)
+	}
+	return nil
+}
+
+func (br *budgetRepository) UpdateBudget(ctx *context.Context, budget *entities.ExpenseBudget) *errs.XError {
+	return br.GormDAL.Update(ctx, *budget)
+}
+
+// GetBudget finds the budget of the category for the month
+func (br *budgetRepository) GetBudget(ctx *context.Context, categoryId uint, month time.Time) (*entities.ExpenseBudget, *errs.XError) {
+	budget := entities.ExpenseBudget{}
+	res := br.WithDB(ctx).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Where("category_id = ? AND month = ?", categoryId, month).
+		Limit(1).
+		Find(&budget)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find budget", res.Error)
+	}
+	return &budget, nil
+}
+
+// GetBudgets returns the budgets of the months in the range, to is exclusive
+func (br *budgetRepository) GetBudgets(ctx *context.Context, from, to time.Time) ([]entities.ExpenseBudget, *errs.XError) {
+	var budgets []entities.ExpenseBudget
+	res := br.WithDB(ctx).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Where("month >= ? AND month < ?", from, to).
+		Preload("Category").
+		Order("month ASC, category_id ASC").
+		Find(&budgets)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find budgets", res.Error)
+	}
+	return budgets, nil
+}
+
+func (br *budgetRepository) DeleteBudget(ctx *context.Context, id uint) *errs.XError {
+	budget := &entities.ExpenseBudget{Model: &entities.Model{ID: id, IsActive: false}}
+	return br.GormDAL.Delete(ctx, budget)
+}
+
+// GetBudgetTotals returns the budget of each category over the months in the range, to is exclusive
+func (br *budgetRepository) GetBudgetTotals(ctx *context.Context, from, to time.Time) ([]responseModel.BudgetReportLine, *errs.XError) {
+	var result []responseModel.BudgetReportLine
+	res := br.WithDB(ctx).
+		Table(entities.ExpenseBudget{}.TableNameForQuery()).
+		Select(`E.category_id AS category_id,
+			C.name AS category,
+			COALESCE(SUM(E.amount), 0) AS budget`).
+		Joins(`INNER JOIN "stich"."ExpenseCategories" C ON C.id = E.category_id`).
+		Scopes(scopes.Channel("E"), scopes.IsActive("E")).
+		Where("E.month >= ? AND E.month < ?", from, to).
+		Group("E.category_id, C.name").
+		Scan(&result)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find budget totals", res.Error)
+	}
+	return result, nil
+}
+
+// GetActualTotals returns the expenses of each category in the range, to is exclusive.
+// Expenses without a purchase date are taken on the day they were recorded.
+func (br *budgetRepository) GetActualTotals(ctx *context.Context, from, to time.Time) ([]responseModel.BudgetReportLine, *errs.XError) {
+	var result []responseModel.BudgetReportLine
+	res := br.WithDB(ctx).
+		Table(entities.Expense{}.TableNameForQuery()).
+		Select(`COALESCE(C.id, 0) AS category_id,
+			COALESCE(C.name, 'Uncategorized') AS category,
+			COALESCE(SUM(E.price), 0) AS actual`).
+		Joins(`LEFT JOIN "stich"."ExpenseCategories" C ON C.id = E.category_id`).
+		Scopes(scopes.Channel("E"), scopes.IsActive("E")).
+		Where("COALESCE(E.purchase_date, E.created_at) >= ? AND COALESCE(E.purchase_date, E.created_at) < ?", from, to).
+		Group("C.id, C.name").
+		Scan(&result)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find expense totals", res.Error)
+	}
+	return result, nil
+}
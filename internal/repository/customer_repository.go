@@ -83,7 +83,10 @@ func (cr *customerRepository) GetByPhoneNumber(ctx *context.Context, phoneNumber
 		return nil, nil
 	}
 	customer := entities.Customer{}
-	res := cr.WithDB(ctx).Where("phone_number = ?", phoneNumber).First(&customer)
+	res := cr.WithDB(ctx).
+		Scopes(scopes.Channel()).
+		Where("phone_number = ?", phoneNumber).
+		First(&customer)
 	if res.Error != nil {
 		if errors.Is(res.Error, gorm.ErrRecordNotFound) {
 			return nil, nil
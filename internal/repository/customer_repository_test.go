@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/model/models"
+	"github.com/loop-kar/pixie/constants"
+	"github.com/loop-kar/pixie/db"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// dryRunTransactionManager builds the statements without a database, the last query is kept to be asserted
+type dryRunTransactionManager struct {
+	db.DBTransactionManager
+	db *gorm.DB
+}
+
+func (tm dryRunTransactionManager) WithTransaction(ctx *context.Context, opts ...db.TransactionOption) *gorm.DB {
+	tx := tm.db.Session(&gorm.Session{})
+	for _, opt := range opts {
+		tx = opt(tx)
+	}
+	return tx
+}
+
+func newDryRunDAL(t *testing.T) (GormDAL, *string) {
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	require.NoError(t, err)
+
+	var lastQuery string
+	err = gormDB.Callback().Query().After("gorm:query").Register("test:last_query", func(tx *gorm.DB) {
+		lastQuery = tx.Dialector.Explain(tx.Statement.SQL.String(), tx.Statement.Vars...)
+	})
+	require.NoError(t, err)
+
+	return ProvideGormDAL(dryRunTransactionManager{db: gormDB}), &lastQuery
+}
+
+func sessionContext(channelId uint) *context.Context {
+	userId := uint(1)
+	ctx := context.WithValue(context.Background(), constants.SESSION, &models.Session{Role: entities.SUPERADMIN, UserId: &userId, ChannelId: channelId, IsSystemSession: true})
+	return &ctx
+}
+
+// A lead of a channel carrying the phone number of a customer of another channel should not be matched to that customer
+func Test_GetByPhoneNumber_ScopedToChannel(t *testing.T) {
+	dal, lastQuery := newDryRunDAL(t)
+	customerRepo := ProvideCustomerRepository(dal)
+
+	_, errr := customerRepo.GetByPhoneNumber(sessionContext(2), "9876543210")
+	require.Nil(t, errr)
+	require.Contains(t, *lastQuery, "phone_number = '9876543210'")
+	require.Contains(t, *lastQuery, `"channel_id" = 2`)
+
+	// system admins look up across the channels
+	_, errr = customerRepo.GetByPhoneNumber(sessionContext(0), "9876543210")
+	require.Nil(t, errr)
+	require.NotContains(t, *lastQuery, "channel_id")
+}
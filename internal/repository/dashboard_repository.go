@@ -0,0 +1,230 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	responseModel "github.com/imkarthi24/sf-backend/internal/model/response"
+	"github.com/imkarthi24/sf-backend/internal/repository/scopes"
+	"github.com/loop-kar/pixie/errs"
+	"gorm.io/gorm"
+)
+
+// closedOrderStatuses are the statuses of the orders which are no longer worked on
+var closedOrderStatuses = []entities.OrderStatus{entities.DELIVERED, entities.CANCELLED}
+
+// orderItemTotals is the value of the active items of each order
+const orderItemTotals = `LEFT JOIN (SELECT order_id, SUM(total) AS total FROM "stich"."OrderItems"
+	WHERE is_active = true GROUP BY order_id) OI ON OI.order_id = E.id`
+
+// DashboardRepository has the aggregate queries of the dashboard.
+// All the queries are scoped to the channel of the session,
+// the date range is inclusive of from and exclusive of to.
+type DashboardRepository interface {
+	GetOrderStatusCounts(ctx *context.Context, from, to time.Time) ([]responseModel.StatusCountStat, *errs.XError)
+	GetBookedOrderValue(ctx *context.Context, from, to time.Time) (float64, *errs.XError)
+	GetDailyCollection(ctx *context.Context, from, to time.Time) ([]responseModel.DateAmountStat, *errs.XError)
+	GetOverdueOrders(ctx *context.Context, asOf time.Time, limit int) ([]responseModel.OverdueOrderStat, int, *errs.XError)
+	GetEnquiryStatusCounts(ctx *context.Context, from, to time.Time) ([]responseModel.StatusCountStat, *errs.XError)
+	GetEnquirySourceCounts(ctx *context.Context, from, to time.Time) ([]responseModel.SourceCountStat, *errs.XError)
+	GetTopReferrers(ctx *context.Context, from, to time.Time, limit int) ([]responseModel.ReferrerCountStat, *errs.XError)
+	GetTotalExpense(ctx *context.Context, from, to time.Time) (float64, *errs.XError)
+	GetTotalLabourCost(ctx *context.Context, from, to time.Time) (float64, *errs.XError)
+	GetStaffWorkload(ctx *context.Context, from, to, asOf time.Time) ([]responseModel.StaffWorkloadStat, *errs.XError)
+}
+
+type dashboardRepository struct {
+	GormDAL
+}
+
+func ProvideDashboardRepository(customDB GormDAL) DashboardRepository {
+	return &dashboardRepository{GormDAL: customDB}
+}
+
+func (dr *dashboardRepository) GetOrderStatusCounts(ctx *context.Context, from, to time.Time) ([]responseModel.StatusCountStat, *errs.XError) {
+	var result []responseModel.StatusCountStat
+	res := dr.WithDB(ctx).Model(&entities.Order{}).
+		Select("status, COUNT(*) AS count").
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Group("status").
+		Order("count DESC").
+		Scan(&result)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find order stats", res.Error)
+	}
+	return result, nil
+}
+
+// GetBookedOrderValue returns the value of the orders taken in the range, cancelled orders are excluded
+func (dr *dashboardRepository) GetBookedOrderValue(ctx *context.Context, from, to time.Time) (float64, *errs.XError) {
+	var value float64
+	res := dr.WithDB(ctx).
+		Table(entities.Order{}.TableNameForQuery()).
+		Select("COALESCE(SUM(COALESCE(OI.total, 0) + E.additional_charges), 0)").
+		Joins(orderItemTotals).
+		Scopes(scopes.Channel("E"), scopes.IsActive("E")).
+		Where("E.created_at >= ? AND E.created_at < ?", from, to).
+		Where("E.status <> ?", entities.CANCELLED).
+		Scan(&value)
+	if res.Error != nil {
+		return 0, errs.NewXError(errs.DATABASE, "Unable to calculate booked order value", res.Error)
+	}
+	return value, nil
+}
+
+func (dr *dashboardRepository) GetDailyCollection(ctx *context.Context, from, to time.Time) ([]responseModel.DateAmountStat, *errs.XError) {
+	var result []responseModel.DateAmountStat
+	res := dr.WithDB(ctx).Model(&entities.Payment{}).
+		Select("TO_CHAR(payment_date, 'YYYY-MM-DD') AS date, SUM(amount) AS amount").
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Where("payment_date >= ? AND payment_date < ?", from, to).
+		Group("TO_CHAR(payment_date, 'YYYY-MM-DD')").
+		Order("date").
+		Scan(&result)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find collection stats", res.Error)
+	}
+	return result, nil
+}
+
+// GetOverdueOrders returns the open orders whose expected delivery date has passed,
+// the most overdue first, along with the total count
+func (dr *dashboardRepository) GetOverdueOrders(ctx *context.Context, asOf time.Time, limit int) ([]responseModel.OverdueOrderStat, int, *errs.XError) {
+	query := dr.WithDB(ctx).
+		Table(entities.Order{}.TableNameForQuery()).
+		Scopes(scopes.Channel("E"), scopes.IsActive("E")).
+		Where("E.expected_delivery_date < ?", asOf).
+		Where("E.status NOT IN ?", closedOrderStatuses)
+
+	var count int64
+	res := query.Session(&gorm.Session{}).Count(&count)
+	if res.Error != nil {
+		return nil, 0, errs.NewXError(errs.DATABASE, "Unable to count overdue orders", res.Error)
+	}
+
+	var result []responseModel.OverdueOrderStat
+	res = query.
+		Select(`E.id AS order_id,
+			E.status,
+			CONCAT(C.first_name, ' ', C.last_name) AS customer_name,
+			C.phone_number,
+			E.expected_delivery_date,
+			(?::date - E.expected_delivery_date::date) AS days_overdue`, asOf).
+		Joins(`LEFT JOIN "stich"."Customers" C ON C.id = E.customer_id`).
+		Order("E.expected_delivery_date ASC").
+		Limit(limit).
+		Scan(&result)
+	if res.Error != nil {
+		return nil, 0, errs.NewXError(errs.DATABASE, "Unable to find overdue orders", res.Error)
+	}
+	return result, int(count), nil
+}
+
+func (dr *dashboardRepository) GetEnquiryStatusCounts(ctx *context.Context, from, to time.Time) ([]responseModel.StatusCountStat, *errs.XError) {
+	var result []responseModel.StatusCountStat
+	res := dr.WithDB(ctx).Model(&entities.Enquiry{}).
+		Select("status, COUNT(*) AS count").
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Group("status").
+		Order("count DESC").
+		Scan(&result)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find enquiry stats", res.Error)
+	}
+	return result, nil
+}
+
+func (dr *dashboardRepository) GetEnquirySourceCounts(ctx *context.Context, from, to time.Time) ([]responseModel.SourceCountStat, *errs.XError) {
+	var result []responseModel.SourceCountStat
+	res := dr.WithDB(ctx).Model(&entities.Enquiry{}).
+		Select(`COALESCE(NULLIF(TRIM(source), ''), 'Unknown') AS source,
+			COUNT(*) AS count,
+			COUNT(*) FILTER (WHERE status = ?) AS accepted_count`, entities.EnquiryStatusAccepted).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Group("1").
+		Order("count DESC").
+		Scan(&result)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find enquiry source stats", res.Error)
+	}
+	return result, nil
+}
+
+func (dr *dashboardRepository) GetTopReferrers(ctx *context.Context, from, to time.Time, limit int) ([]responseModel.ReferrerCountStat, *errs.XError) {
+	var result []responseModel.ReferrerCountStat
+	res := dr.WithDB(ctx).Model(&entities.Enquiry{}).
+		Select(`TRIM(referred_by) AS referrer,
+			MAX(referrer_phone_number) AS referrer_phone,
+			COUNT(*) AS enquiries_count,
+			COUNT(*) FILTER (WHERE status = ?) AS accepted_count`, entities.EnquiryStatusAccepted).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Where("TRIM(COALESCE(referred_by, '')) <> ''").
+		Group("TRIM(referred_by)").
+		Order("enquiries_count DESC, accepted_count DESC").
+		Limit(limit).
+		Scan(&result)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find referrer stats", res.Error)
+	}
+	return result, nil
+}
+
+// GetTotalExpense returns the sum of the expenses in the range,
+// expenses without a purchase date are taken on the day they were recorded
+func (dr *dashboardRepository) GetTotalExpense(ctx *context.Context, from, to time.Time) (float64, *errs.XError) {
+	var total float64
+	res := dr.WithDB(ctx).Model(&entities.Expense{}).
+		Select("COALESCE(SUM(price), 0)").
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Where("COALESCE(purchase_date, created_at) >= ? AND COALESCE(purchase_date, created_at) < ?", from, to).
+		Scan(&total)
+	if res.Error != nil {
+		return 0, errs.NewXError(errs.DATABASE, "Unable to calculate expenses", res.Error)
+	}
+	return total, nil
+}
+
+// GetTotalLabourCost returns the payouts accrued to the outsourced tailors in the range, net of the reversals
+func (dr *dashboardRepository) GetTotalLabourCost(ctx *context.Context, from, to time.Time) (float64, *errs.XError) {
+	var total float64
+	res := dr.WithDB(ctx).Model(&entities.PayoutEntry{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Where("accrued_at >= ? AND accrued_at < ?", from, to).
+		Scan(&total)
+	if res.Error != nil {
+		return 0, errs.NewXError(errs.DATABASE, "Unable to calculate labour cost", res.Error)
+	}
+	return total, nil
+}
+
+// GetStaffWorkload returns the open and overdue (as of asOf) orders of each staff who took an order,
+// along with the orders they took in the range
+func (dr *dashboardRepository) GetStaffWorkload(ctx *context.Context, from, to, asOf time.Time) ([]responseModel.StaffWorkloadStat, *errs.XError) {
+	var result []responseModel.StaffWorkloadStat
+	res := dr.WithDB(ctx).
+		Table(entities.Order{}.TableNameForQuery()).
+		Select(`U.id AS user_id,
+			CONCAT(U.first_name, ' ', U.last_name) AS name,
+			COUNT(*) FILTER (WHERE E.status NOT IN ?) AS open_orders,
+			COUNT(*) FILTER (WHERE E.status NOT IN ? AND E.expected_delivery_date < ?) AS overdue_orders,
+			COUNT(*) FILTER (WHERE E.created_at >= ? AND E.created_at < ?) AS orders_in_range,
+			COALESCE(SUM(COALESCE(OI.total, 0) + E.additional_charges)
+				FILTER (WHERE E.created_at >= ? AND E.created_at < ? AND E.status <> ?), 0) AS order_value_in_range`,
+			closedOrderStatuses, closedOrderStatuses, asOf, from, to, from, to, entities.CANCELLED).
+		Joins(`INNER JOIN "stich"."Users" U ON U.id = E.order_taken_by_id`).
+		Joins(orderItemTotals).
+		Scopes(scopes.Channel("E"), scopes.IsActive("E")).
+		Group("U.id, U.first_name, U.last_name").
+		Order("open_orders DESC, orders_in_range DESC").
+		Scan(&result)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find staff workload", res.Error)
+	}
+	return result, nil
+}
@@ -2,8 +2,10 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/imkarthi24/sf-backend/internal/entities"
+	responseModel "github.com/imkarthi24/sf-backend/internal/model/response"
 	"github.com/imkarthi24/sf-backend/internal/repository/scopes"
 	"github.com/loop-kar/pixie/db"
 	"github.com/loop-kar/pixie/errs"
@@ -14,6 +16,7 @@ type EnquiryHistoryRepository interface {
 	Get(*context.Context, uint) (*entities.EnquiryHistory, *errs.XError)
 	GetAll(*context.Context, string) ([]entities.EnquiryHistory, *errs.XError)
 	GetByEnquiryId(*context.Context, uint) ([]entities.EnquiryHistory, *errs.XError)
+	GetDueCallbacks(*context.Context, time.Time, time.Time) ([]responseModel.EnquiryCallback, *errs.XError)
 }
 
 type enquiryHistoryRepository struct {
@@ -72,3 +75,36 @@ func (ehr *enquiryHistoryRepository) GetByEnquiryId(ctx *context.Context, enquir
 	}
 	return enquiryHistories, nil
 }
+
+// GetDueCallbacks returns the open enquiries whose latest history has a call back between from and to,
+// ordered by channel and employee. Without a channel in the session (eg: the cron jobs) the enquiries of all the channels are returned.
+func (ehr *enquiryHistoryRepository) GetDueCallbacks(ctx *context.Context, from time.Time, to time.Time) ([]responseModel.EnquiryCallback, *errs.XError) {
+	var callbacks []responseModel.EnquiryCallback
+	res := ehr.WithDB(ctx).
+		Table(entities.Enquiry{}.TableNameForQuery()).
+		Select(`E.id AS enquiry_id,
+			E.channel_id,
+			E.subject,
+			COALESCE(TRIM(C.first_name || ' ' || C.last_name), '') AS customer_name,
+			COALESCE(C.phone_number, '') AS customer_phone,
+			EH.call_back_date,
+			EH.employee_comment,
+			EH.customer_comment,
+			EH.employee_id,
+			TRIM(U.first_name || ' ' || U.last_name) AS employee_name,
+			U.email AS employee_email`).
+		Joins(`JOIN (SELECT DISTINCT ON (enquiry_id) enquiry_id, call_back_date, employee_comment, customer_comment, employee_id
+			FROM "stich"."EnquiryHistories" WHERE is_active = true
+			ORDER BY enquiry_id, performed_at DESC, id DESC) EH ON EH.enquiry_id = E.id`).
+		Joins(`JOIN "stich"."Users" U ON U.id = EH.employee_id AND U.is_active = true`).
+		Joins(`LEFT JOIN "stich"."Customers" C ON C.id = E.customer_id`).
+		Scopes(scopes.Channel("E"), scopes.IsActive("E")).
+		Where("E.status NOT IN ?", []entities.EnquiryStatus{entities.EnquiryStatusAccepted, entities.EnquiryStatusBacklog}).
+		Where("EH.call_back_date >= ? AND EH.call_back_date < ?", from, to).
+		Order("E.channel_id ASC, EH.employee_id ASC, EH.call_back_date ASC").
+		Scan(&callbacks)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find enquiry callbacks", res.Error)
+	}
+	return callbacks, nil
+}
@@ -7,12 +7,14 @@ import (
 	"github.com/imkarthi24/sf-backend/internal/repository/scopes"
 	"github.com/loop-kar/pixie/db"
 	"github.com/loop-kar/pixie/errs"
+	"gorm.io/gorm"
 )
 
 type EnquiryRepository interface {
 	Create(*context.Context, *entities.Enquiry) *errs.XError
 	Update(*context.Context, *entities.Enquiry) *errs.XError
 	UpdateEnquiryAndCustomer(*context.Context, *entities.Enquiry, *entities.Customer) *errs.XError
+	ConvertToOrder(*context.Context, *entities.Enquiry, *entities.Order, *entities.OrderHistory, *entities.EnquiryHistory) *errs.XError
 	Get(*context.Context, uint) (*entities.Enquiry, *errs.XError)
 	GetAll(*context.Context, string) ([]entities.Enquiry, *errs.XError)
 	Delete(*context.Context, uint) *errs.XError
@@ -51,6 +53,64 @@ func (er *enquiryRepository) UpdateEnquiryAndCustomer(ctx *context.Context, enqu
 	return er.GormDAL.Update(ctx, *enquiry)
 }
 
+// ConvertToOrder activates the customer of the enquiry, creates the order along with its items,
+// accepts the enquiry and records the histories in a single transaction.
+// Nothing is saved if any of the steps fail.
+func (er *enquiryRepository) ConvertToOrder(ctx *context.Context, enquiry *entities.Enquiry, order *entities.Order, orderHistory *entities.OrderHistory, enquiryHistory *entities.EnquiryHistory) *errs.XError {
+	var xErr *errs.XError
+
+	err := er.WithDB(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&entities.Customer{Model: &entities.Model{ID: *enquiry.CustomerId}}).
+			Updates(map[string]interface{}{
+				"is_active":     true,
+				"updated_by_id": enquiryHistory.PerformedById,
+			})
+		if res.Error != nil {
+			xErr = errs.NewXError(errs.DATABASE, "Unable to activate customer", res.Error)
+			return res.Error
+		}
+
+		res = tx.Create(order)
+		if res.Error != nil {
+			xErr = errs.NewXError(errs.DATABASE, "Unable to save order", res.Error)
+			return res.Error
+		}
+
+		orderHistory.OrderId = order.ID
+		res = tx.Create(orderHistory)
+		if res.Error != nil {
+			xErr = errs.NewXError(errs.DATABASE, "Unable to save order history", res.Error)
+			return res.Error
+		}
+
+		res = tx.Model(&entities.Enquiry{Model: &entities.Model{ID: enquiry.ID}}).
+			Updates(map[string]interface{}{
+				"status":        entities.EnquiryStatusAccepted,
+				"updated_by_id": enquiryHistory.PerformedById,
+			})
+		if res.Error != nil {
+			xErr = errs.NewXError(errs.DATABASE, "Unable to update enquiry", res.Error)
+			return res.Error
+		}
+
+		res = tx.Create(enquiryHistory)
+		if res.Error != nil {
+			xErr = errs.NewXError(errs.DATABASE, "Unable to save enquiry history", res.Error)
+			return res.Error
+		}
+
+		return nil
+	})
+	if err != nil {
+		if xErr == nil {
+			xErr = errs.NewXError(errs.DATABASE, "Unable to convert enquiry", err)
+		}
+		return xErr
+	}
+
+	return nil
+}
+
 func (er *enquiryRepository) Get(ctx *context.Context, id uint) (*entities.Enquiry, *errs.XError) {
 	enquiry := entities.Enquiry{}
 	res := er.WithDB(ctx).Preload("Customer").Find(&enquiry, id)
@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	responseModel "github.com/imkarthi24/sf-backend/internal/model/response"
+	"github.com/imkarthi24/sf-backend/internal/repository/scopes"
+	"github.com/imkarthi24/sf-backend/internal/utils"
+	"github.com/loop-kar/pixie/db"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/loop-kar/pixie/util"
+)
+
+type InventoryRepository interface {
+	CreateMaterial(*context.Context, *entities.Material) *errs.XError
+	UpdateMaterial(*context.Context, *entities.Material) *errs.XError
+	GetMaterial(*context.Context, uint) (*entities.Material, *errs.XError)
+	GetMaterialByName(*context.Context, string) (*entities.Material, *errs.XError)
+	GetMaterials(*context.Context, string) ([]entities.Material, *errs.XError)
+	DeleteMaterial(*context.Context, uint) *errs.XError
+
+	CreateMovement(*context.Context, *entities.StockMovement) *errs.XError
+	GetMovement(*context.Context, uint) (*entities.StockMovement, *errs.XError)
+	GetMovements(*context.Context, requestModel.StockMovementFilter) ([]entities.StockMovement, *errs.XError)
+	DeleteMovement(*context.Context, uint) *errs.XError
+	DeleteMovementsByExpenseId(*context.Context, uint) *errs.XError
+
+	GetOnHand(*context.Context, uint) (float64, *errs.XError)
+	GetStockLevels(*context.Context, *uint) ([]responseModel.StockLevel, *errs.XError)
+}
+
+type inventoryRepository struct {
+	GormDAL
+}
+
+func ProvideInventoryRepository(customDB GormDAL) InventoryRepository {
+	return &inventoryRepository{GormDAL: customDB}
+}
+
+func (ir *inventoryRepository) CreateMaterial(ctx *context.Context, material *entities.Material) *errs.XError {
+	res := ir.WithDB(ctx).Create(&material)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to save material", res.Error)
+	}
+	return nil
+}
+
+func (ir *inventoryRepository) UpdateMaterial(ctx *context.Context, material *entities.Material) *errs.XError {
+	return ir.GormDAL.Update(ctx, *material)
+}
+
+func (ir *inventoryRepository) GetMaterial(ctx *context.Context, id uint) (*entities.Material, *errs.XError) {
+	material := entities.Material{}
+	res := ir.WithDB(ctx).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Find(&material, id)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find material", res.Error)
+	}
+	return &material, nil
+}
+
+// GetMaterialByName finds the active material of the channel with the name, ignoring the case
+func (ir *inventoryRepository) GetMaterialByName(ctx *context.Context, name string) (*entities.Material, *errs.XError) {
+	material := entities.Material{}
+	res := ir.WithDB(ctx).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Where("LOWER(name) = LOWER(?)", name).
+		Limit(1).
+		Find(&material)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find material by name", res.Error)
+	}
+	return &material, nil
+}
+
+func (ir *inventoryRepository) GetMaterials(ctx *context.Context, search string) ([]entities.Material, *errs.XError) {
+	var materials []entities.Material
+	query := ir.WithDB(ctx).Table(entities.Material{}.TableNameForQuery()).
+		Scopes(scopes.Channel("E"), scopes.IsActive("E"))
+
+	if !util.IsNilOrEmptyString(&search) {
+		formattedSearch := util.EncloseWithPercentageOperator(search)
+		query = query.Where(fmt.Sprintf("(E.name ILIKE %s OR E.code ILIKE %s OR E.category ILIKE %s)", formattedSearch, formattedSearch, formattedSearch))
+	}
+
+	res := query.
+		Scopes(db.Paginate(ctx)).
+		Order("E.name ASC").
+		Find(&materials)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find materials", res.Error)
+	}
+	return materials, nil
+}
+
+func (ir *inventoryRepository) DeleteMaterial(ctx *context.Context, id uint) *errs.XError {
+	material := &entities.Material{Model: &entities.Model{ID: id, IsActive: false}}
+	return ir.GormDAL.Delete(ctx, material)
+}
+
+func (ir *inventoryRepository) CreateMovement(ctx *context.Context, movement *entities.StockMovement) *errs.XError {
+	res := ir.WithDB(ctx).Create(&movement)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to save stock movement", res.Error)
+	}
+	return nil
+}
+
+func (ir *inventoryRepository) GetMovement(ctx *context.Context, id uint) (*entities.StockMovement, *errs.XError) {
+	movement := entities.StockMovement{}
+	res := ir.WithDB(ctx).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Preload("Material").
+		Preload("Expense", scopes.SelectFields("bill_number")).
+		Find(&movement, id)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find stock movement", res.Error)
+	}
+	return &movement, nil
+}
+
+// GetMovements returns the movements of the filter, latest first, paginated when the range is not given
+func (ir *inventoryRepository) GetMovements(ctx *context.Context, filter requestModel.StockMovementFilter) ([]entities.StockMovement, *errs.XError) {
+	var movements []entities.StockMovement
+	query := ir.WithDB(ctx).Table(entities.StockMovement{}.TableNameForQuery()).
+		Scopes(scopes.Channel("E"), scopes.IsActive("E"))
+
+	if filter.MaterialId != nil {
+		query = query.Where("E.material_id = ?", *filter.MaterialId)
+	}
+	if filter.ExpenseId != nil {
+		query = query.Where("E.expense_id = ?", *filter.ExpenseId)
+	}
+	if filter.OrderItemId != nil {
+		query = query.Where("E.order_item_id = ?", *filter.OrderItemId)
+	}
+	if filter.Type != "" {
+		query = query.Where("E.type = ?", filter.Type)
+	}
+	if filter.From != nil {
+		query = query.Where("E.moved_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("E.moved_at < ?", *filter.To)
+	}
+	if filter.From == nil && filter.To == nil {
+		query = query.Scopes(db.Paginate(ctx))
+	}
+
+	res := query.
+		Preload("Material").
+		Preload("Expense", scopes.SelectFields("bill_number")).
+		Order("E.moved_at DESC, E.id DESC").
+		Find(&movements)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find stock movements", res.Error)
+	}
+	return movements, nil
+}
+
+func (ir *inventoryRepository) DeleteMovement(ctx *context.Context, id uint) *errs.XError {
+	movement := &entities.StockMovement{Model: &entities.Model{ID: id, IsActive: false}}
+	return ir.GormDAL.Delete(ctx, movement)
+}
+
+// DeleteMovementsByExpenseId removes the stock bought with the expense bill
+func (ir *inventoryRepository) DeleteMovementsByExpenseId(ctx *context.Context, expenseId uint) *errs.XError {
+	res := ir.WithDB(ctx).Model(&entities.StockMovement{}).
+		Where("expense_id = ?", expenseId).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Updates(map[string]interface{}{
+			"is_active":     false,
+			"updated_by_id": utils.GetUserId(ctx),
+		})
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to delete stock movements of the expense", res.Error)
+	}
+	return nil
+}
+
+// GetOnHand returns the quantity of the material in stock
+func (ir *inventoryRepository) GetOnHand(ctx *context.Context, materialId uint) (float64, *errs.XError) {
+	var onHand float64
+	res := ir.WithDB(ctx).Model(&entities.StockMovement{}).
+		Select("COALESCE(SUM(quantity), 0)").
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Where("material_id = ?", materialId).
+		Scan(&onHand)
+	if res.Error != nil {
+		return 0, errs.NewXError(errs.DATABASE, "Unable to calculate stock on hand", res.Error)
+	}
+	return onHand, nil
+}
+
+// GetStockLevels returns the on hand quantity of every active material (or the given one) per channel.
+// Without a channel in the session (eg: the cron jobs) the materials of all the channels are returned.
+func (ir *inventoryRepository) GetStockLevels(ctx *context.Context, materialId *uint) ([]responseModel.StockLevel, *errs.XError) {
+	var result []responseModel.StockLevel
+	query := ir.WithDB(ctx).
+		Table(entities.Material{}.TableNameForQuery()).
+		Select(`E.id AS material_id,
+			E.name AS material,
+			E.code AS code,
+			E.category AS category,
+			E.unit AS unit,
+			E.channel_id AS channel_id,
+			COALESCE(SUM(S.quantity), 0) AS on_hand`).
+		Joins(`LEFT JOIN "stich"."StockMovements" S ON S.material_id = E.id AND S.is_active = true`).
+		Scopes(scopes.Channel("E"), scopes.IsActive("E"))
+
+	if materialId != nil {
+		query = query.Where("E.id = ?", *materialId)
+	}
+
+	res := query.
+		Group("E.id, E.name, E.code, E.category, E.unit, E.channel_id").
+		Order("E.channel_id ASC, E.name ASC").
+		Scan(&result)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find stock levels", res.Error)
+	}
+	return result, nil
+}
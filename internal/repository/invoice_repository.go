@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/repository/scopes"
+	"github.com/imkarthi24/sf-backend/internal/utils"
+	"github.com/loop-kar/pixie/errs"
+)
+
+type InvoiceRepository interface {
+	Create(*context.Context, *entities.Invoice) *errs.XError
+	GetByOrderId(*context.Context, uint) (*entities.Invoice, *errs.XError)
+	GetNextSequence(*context.Context) (int, *errs.XError)
+}
+
+type invoiceRepository struct {
+	GormDAL
+}
+
+func ProvideInvoiceRepository(customDB GormDAL) InvoiceRepository {
+	return &invoiceRepository{GormDAL: customDB}
+}
+
+func (ir *invoiceRepository) Create(ctx *context.Context, invoice *entities.Invoice) *errs.XError {
+	res := ir.WithDB(ctx).Create(&invoice)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to save invoice", res.Error)
+	}
+	return nil
+}
+
+// GetByOrderId returns the invoice already issued for the order, nil if none exists
+func (ir *invoiceRepository) GetByOrderId(ctx *context.Context, orderId uint) (*entities.Invoice, *errs.XError) {
+	var invoices []entities.Invoice
+	res := ir.WithDB(ctx).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Where("order_id = ?", orderId).
+		Limit(1).
+		Find(&invoices)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find invoice", res.Error)
+	}
+	if len(invoices) == 0 {
+		return nil, nil
+	}
+	return &invoices[0], nil
+}
+
+// GetNextSequence allocates the next invoice sequence for the channel in session.
+// The counter row of the channel stays locked until the transaction ends, so that two invoices never get the same sequence
+func (ir *invoiceRepository) GetNextSequence(ctx *context.Context) (int, *errs.XError) {
+	var sequence int
+	res := ir.WithDB(ctx).Raw(`INSERT INTO "stich"."InvoiceSequences" (channel_id, last_sequence, is_active, created_at, updated_at)
+		VALUES (?, 1, true, NOW(), NOW())
+		ON CONFLICT (channel_id) DO UPDATE SET last_sequence = "InvoiceSequences".last_sequence + 1, updated_at = NOW()
+		RETURNING last_sequence`, utils.GetChannelId(ctx)).
+		Scan(&sequence)
+	if res.Error != nil {
+		return 0, errs.NewXError(errs.DATABASE, "Unable to generate invoice number", res.Error)
+	}
+	return sequence, nil
+}
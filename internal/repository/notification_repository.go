@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/imkarthi24/sf-backend/internal/entities"
 	"github.com/imkarthi24/sf-backend/internal/repository/scopes"
@@ -12,7 +13,9 @@ type NotificationRepository interface {
 	CreateNotification(ctx *context.Context, notif entities.Notification) *errs.XError
 	GetPendingNotifications(ctx *context.Context) ([]entities.Notification, *errs.XError)
 	UpdateEmailNotificationStatus(ctx *context.Context, id uint, status entities.NotificationStatus) *errs.XError
+	UpdateWhatsappNotificationStatus(ctx *context.Context, id uint, status entities.NotificationStatus) *errs.XError
 	UpdateNotificationStatus(ctx *context.Context, id uint, status entities.NotificationStatus) *errs.XError
+	UpdateNotificationAttempt(ctx *context.Context, id uint, status entities.NotificationStatus, attemptCount int, nextAttemptAt *time.Time) *errs.XError
 }
 
 type notificationRepository struct {
@@ -32,6 +35,7 @@ func (repo *notificationRepository) CreateNotification(ctx *context.Context, not
 	return nil
 }
 
+// GetPendingNotifications returns the PENDING and PARTIAL notifications whose next attempt is due
 func (repo *notificationRepository) GetPendingNotifications(ctx *context.Context) ([]entities.Notification, *errs.XError) {
 	pendingNotifications := make([]entities.Notification, 0)
 
@@ -39,7 +43,9 @@ func (repo *notificationRepository) GetPendingNotifications(ctx *context.Context
 		Scopes(scopes.IsActive()).
 		Preload("EmailNotifications").
 		Preload("WhatsappNotifications").
-		Where("status = ?", entities.NOTIF_PENDING).
+		Where("status IN ?", []entities.NotificationStatus{entities.NOTIF_PENDING, entities.NOTIF_PARTIAL}).
+		Where("next_attempt_at IS NULL OR next_attempt_at <= ?", time.Now()).
+		Order("id ASC").
 		Find(&pendingNotifications)
 
 	if res.Error != nil {
@@ -61,6 +67,18 @@ func (repo *notificationRepository) UpdateEmailNotificationStatus(ctx *context.C
 	return nil
 }
 
+func (repo *notificationRepository) UpdateWhatsappNotificationStatus(ctx *context.Context, id uint, status entities.NotificationStatus) *errs.XError {
+	notif := entities.WhatsappNotification{
+		Model:  &entities.Model{ID: id},
+		Status: string(status),
+	}
+	res := repo.WithDB(ctx).Updates(notif)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to update Notification", res.Error)
+	}
+	return nil
+}
+
 func (repo *notificationRepository) UpdateNotificationStatus(ctx *context.Context, id uint, status entities.NotificationStatus) *errs.XError {
 	notif := entities.Notification{
 		Model:  &entities.Model{ID: id},
@@ -72,3 +90,18 @@ func (repo *notificationRepository) UpdateNotificationStatus(ctx *context.Contex
 	}
 	return nil
 }
+
+func (repo *notificationRepository) UpdateNotificationAttempt(ctx *context.Context, id uint, status entities.NotificationStatus, attemptCount int, nextAttemptAt *time.Time) *errs.XError {
+	res := repo.WithDB(ctx).
+		Model(&entities.Notification{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":          status,
+			"attempt_count":   attemptCount,
+			"next_attempt_at": nextAttemptAt,
+		})
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to update Notification", res.Error)
+	}
+	return nil
+}
@@ -64,7 +64,7 @@ func (ohr *orderHistoryRepository) GetByOrderId(ctx *context.Context, orderId ui
 		Where("order_id = ?", orderId).
 		Scopes(scopes.IsActive()).
 		Preload("PerformedBy", scopes.SelectFields("first_name", "last_name")).
-		Order("performed_at DESC").
+		Order("performed_at DESC, id DESC").
 		Find(&orderHistories)
 	if res.Error != nil {
 		return nil, errs.NewXError(errs.DATABASE, "Unable to find order histories by order id", res.Error)
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	"github.com/imkarthi24/sf-backend/internal/repository/scopes"
+	"github.com/loop-kar/pixie/db"
+	"github.com/loop-kar/pixie/errs"
+)
+
+type OrderItemAssignmentRepository interface {
+	Create(*context.Context, *entities.OrderItemAssignment) *errs.XError
+	Update(*context.Context, *entities.OrderItemAssignment) *errs.XError
+	UpdateStatus(*context.Context, uint, entities.AssignmentStatus, *time.Time, *time.Time, string) *errs.XError
+	Get(*context.Context, uint) (*entities.OrderItemAssignment, *errs.XError)
+	GetAll(*context.Context, requestModel.OrderItemAssignmentFilter) ([]entities.OrderItemAssignment, *errs.XError)
+	GetByOrderItemId(*context.Context, uint) ([]entities.OrderItemAssignment, *errs.XError)
+	GetByOrderId(*context.Context, uint) ([]entities.OrderItemAssignment, *errs.XError)
+	Delete(*context.Context, uint) *errs.XError
+}
+
+type orderItemAssignmentRepository struct {
+	GormDAL
+}
+
+func ProvideOrderItemAssignmentRepository(customDB GormDAL) OrderItemAssignmentRepository {
+	return &orderItemAssignmentRepository{GormDAL: customDB}
+}
+
+func (oar *orderItemAssignmentRepository) Create(ctx *context.Context, assignment *entities.OrderItemAssignment) *errs.XError {
+	res := oar.WithDB(ctx).Create(&assignment)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to save assignment", res.Error)
+	}
+	return nil
+}
+
+func (oar *orderItemAssignmentRepository) Update(ctx *context.Context, assignment *entities.OrderItemAssignment) *errs.XError {
+	return oar.GormDAL.Update(ctx, *assignment)
+}
+
+// UpdateStatus updates only the status, its timestamps and the notes of an assignment
+func (oar *orderItemAssignmentRepository) UpdateStatus(ctx *context.Context, id uint, status entities.AssignmentStatus, startedAt *time.Time, completedAt *time.Time, notes string) *errs.XError {
+	res := oar.WithDB(ctx).Model(&entities.OrderItemAssignment{Model: &entities.Model{ID: id}}).
+		Updates(map[string]interface{}{
+			"status":       status,
+			"started_at":   startedAt,
+			"completed_at": completedAt,
+			"notes":        notes,
+		})
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to update assignment status", res.Error)
+	}
+	return nil
+}
+
+func (oar *orderItemAssignmentRepository) Get(ctx *context.Context, id uint) (*entities.OrderItemAssignment, *errs.XError) {
+	assignment := entities.OrderItemAssignment{}
+	res := oar.WithDB(ctx).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Preload("OrderItem").
+		Preload("OrderItem.Order", scopes.SelectFields("status", "expected_delivery_date", "customer_id")).
+		Preload("AssignedTo", scopes.SelectFields("first_name", "last_name", "role")).
+		Find(&assignment, id)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find assignment", res.Error)
+	}
+	return &assignment, nil
+}
+
+func (oar *orderItemAssignmentRepository) GetAll(ctx *context.Context, filter requestModel.OrderItemAssignmentFilter) ([]entities.OrderItemAssignment, *errs.XError) {
+	var assignments []entities.OrderItemAssignment
+	query := oar.WithDB(ctx).Table(entities.OrderItemAssignment{}.TableNameForQuery()).
+		Scopes(scopes.Channel("E"), scopes.IsActive("E"))
+
+	if filter.OrderId != nil {
+		query = query.Where(`E.order_item_id IN (SELECT id FROM "stich"."OrderItems" WHERE order_id = ?)`, *filter.OrderId)
+	}
+	if filter.OrderItemId != nil {
+		query = query.Where("E.order_item_id = ?", *filter.OrderItemId)
+	}
+	if filter.AssignedToId != nil {
+		query = query.Where("E.assigned_to_id = ?", *filter.AssignedToId)
+	}
+	if filter.Stage != "" {
+		query = query.Where("E.stage = ?", filter.Stage)
+	}
+	if filter.Status != "" {
+		query = query.Where("E.status = ?", filter.Status)
+	} else if filter.OpenOnly {
+		query = query.Where("E.status IN ?", []entities.AssignmentStatus{entities.AssignmentStatusPending, entities.AssignmentStatusInProgress})
+	}
+
+	res := query.
+		Scopes(db.Paginate(ctx)).
+		Preload("OrderItem").
+		Preload("OrderItem.Order", scopes.SelectFields("status", "expected_delivery_date", "customer_id")).
+		Preload("OrderItem.Measurement", scopes.SelectFields("person_id", "dress_type_id")).
+		Preload("OrderItem.Measurement.Person", scopes.SelectFields("first_name", "last_name")).
+		Preload("OrderItem.Measurement.DressType", scopes.SelectFields("name")).
+		Preload("AssignedTo", scopes.SelectFields("first_name", "last_name")).
+		Order("E.due_date ASC NULLS LAST, E.id ASC").
+		Find(&assignments)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find assignments", res.Error)
+	}
+	return assignments, nil
+}
+
+func (oar *orderItemAssignmentRepository) GetByOrderItemId(ctx *context.Context, orderItemId uint) ([]entities.OrderItemAssignment, *errs.XError) {
+	var assignments []entities.OrderItemAssignment
+	res := oar.WithDB(ctx).
+		Where("order_item_id = ?", orderItemId).
+		Scopes(scopes.IsActive()).
+		Find(&assignments)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find assignments by order item id", res.Error)
+	}
+	return assignments, nil
+}
+
+// GetByOrderId returns the active assignments of the active items of an order
+func (oar *orderItemAssignmentRepository) GetByOrderId(ctx *context.Context, orderId uint) ([]entities.OrderItemAssignment, *errs.XError) {
+	var assignments []entities.OrderItemAssignment
+	res := oar.WithDB(ctx).
+		Where(`order_item_id IN (SELECT id FROM "stich"."OrderItems" WHERE order_id = ? AND is_active = true)`, orderId).
+		Scopes(scopes.IsActive()).
+		Find(&assignments)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find assignments by order id", res.Error)
+	}
+	return assignments, nil
+}
+
+func (oar *orderItemAssignmentRepository) Delete(ctx *context.Context, id uint) *errs.XError {
+	assignment := &entities.OrderItemAssignment{Model: &entities.Model{ID: id, IsActive: false}}
+	err := oar.GormDAL.Delete(ctx, assignment)
+	if err != nil {
+		return err
+	}
+	return nil
+}
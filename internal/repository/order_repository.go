@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/imkarthi24/sf-backend/internal/entities"
 	"github.com/imkarthi24/sf-backend/internal/repository/scopes"
@@ -14,6 +15,7 @@ import (
 type OrderRepository interface {
 	Create(*context.Context, *entities.Order) *errs.XError
 	Update(*context.Context, *entities.Order) *errs.XError
+	UpdateStatus(*context.Context, uint, entities.OrderStatus, *time.Time) *errs.XError
 	Get(*context.Context, uint) (*entities.Order, *errs.XError)
 	GetAll(*context.Context, string) ([]entities.Order, *errs.XError)
 	Delete(*context.Context, uint) *errs.XError
@@ -39,6 +41,19 @@ func (or *orderRepository) Update(ctx *context.Context, order *entities.Order) *
 	return or.GormDAL.Update(ctx, *order)
 }
 
+// UpdateStatus updates only the status and delivered date of an order
+func (or *orderRepository) UpdateStatus(ctx *context.Context, id uint, status entities.OrderStatus, deliveredDate *time.Time) *errs.XError {
+	res := or.WithDB(ctx).Model(&entities.Order{Model: &entities.Model{ID: id}}).
+		Updates(map[string]interface{}{
+			"status":         status,
+			"delivered_date": deliveredDate,
+		})
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to update order status", res.Error)
+	}
+	return nil
+}
+
 func (or *orderRepository) Get(ctx *context.Context, id uint) (*entities.Order, *errs.XError) {
 	order := entities.Order{}
 	res := or.WithDB(ctx).Model(&entities.Order{}).
@@ -46,12 +61,17 @@ func (or *orderRepository) Get(ctx *context.Context, id uint) (*entities.Order,
 			(SELECT COALESCE(SUM(quantity), 0) FROM "stich"."OrderItems"
 			 WHERE "stich"."OrderItems".order_id = "stich"."Orders".id) as order_quantity,
 			(SELECT COALESCE(SUM(total), 0) FROM "stich"."OrderItems"
-			 WHERE "stich"."OrderItems".order_id = "stich"."Orders".id) as order_value`).
+			 WHERE "stich"."OrderItems".order_id = "stich"."Orders".id) as order_value,
+			(SELECT COALESCE(SUM(amount), 0) FROM "stich"."Payments"
+			 WHERE "stich"."Payments".order_id = "stich"."Orders".id AND "stich"."Payments".is_active = true) as paid_amount`).
 		Preload("Customer").
 		Preload("OrderTakenBy", scopes.SelectFields("first_name", "last_name")).
 		Preload("OrderItems.Measurement", scopes.SelectFields("person_id", "dress_type_id")).
 		Preload("OrderItems.Measurement.Person", scopes.SelectFields("first_name", "last_name")).
 		Preload("OrderItems.Measurement.DressType", scopes.SelectFields("name")).
+		Preload("OrderItems.Assignments", scopes.IsActive()).
+		Preload("OrderItems.Assignments.AssignedTo", scopes.SelectFields("first_name", "last_name")).
+		Preload("Payments", scopes.IsActive()).
 		Find(&order, id)
 	if res.Error != nil {
 		return nil, errs.NewXError(errs.DATABASE, "Unable to find order", res.Error)
@@ -73,7 +93,9 @@ func (or *orderRepository) GetAll(ctx *context.Context, search string) ([]entiti
 			(SELECT COALESCE(SUM(quantity), 0) FROM "stich"."OrderItems" 
 			 WHERE "stich"."OrderItems".order_id = "stich"."Orders".id) as order_quantity,
 			(SELECT COALESCE(SUM(total), 0) FROM "stich"."OrderItems" 
-			 WHERE "stich"."OrderItems".order_id = "stich"."Orders".id) as order_value`).
+			 WHERE "stich"."OrderItems".order_id = "stich"."Orders".id) as order_value,
+			(SELECT COALESCE(SUM(amount), 0) FROM "stich"."Payments"
+			 WHERE "stich"."Payments".order_id = "stich"."Orders".id AND "stich"."Payments".is_active = true) as paid_amount`).
 		Scopes(scopes.Channel(), scopes.IsActive()).
 		Scopes(scopes.GetOrders_Search(search)).
 		Scopes(scopes.GetOrders_Filter(filter)).
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/repository/scopes"
+	"github.com/loop-kar/pixie/constants"
+	"github.com/loop-kar/pixie/db"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/loop-kar/pixie/util"
+)
+
+type PaymentRepository interface {
+	Create(*context.Context, *entities.Payment) *errs.XError
+	Update(*context.Context, *entities.Payment) *errs.XError
+	Get(*context.Context, uint) (*entities.Payment, *errs.XError)
+	GetAll(*context.Context, string) ([]entities.Payment, *errs.XError)
+	GetByOrderId(*context.Context, uint) ([]entities.Payment, *errs.XError)
+	GetPaidAmount(*context.Context, uint) (float64, *errs.XError)
+	Delete(*context.Context, uint) *errs.XError
+}
+
+type paymentRepository struct {
+	GormDAL
+}
+
+func ProvidePaymentRepository(customDB GormDAL) PaymentRepository {
+	return &paymentRepository{GormDAL: customDB}
+}
+
+func (pr *paymentRepository) Create(ctx *context.Context, payment *entities.Payment) *errs.XError {
+	res := pr.WithDB(ctx).Create(&payment)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to save payment", res.Error)
+	}
+	return nil
+}
+
+func (pr *paymentRepository) Update(ctx *context.Context, payment *entities.Payment) *errs.XError {
+	return pr.GormDAL.Update(ctx, *payment)
+}
+
+func (pr *paymentRepository) Get(ctx *context.Context, id uint) (*entities.Payment, *errs.XError) {
+	payment := entities.Payment{}
+	res := pr.WithDB(ctx).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Preload("ReceivedBy", scopes.SelectFields("first_name", "last_name")).
+		Find(&payment, id)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find payment", res.Error)
+	}
+	return &payment, nil
+}
+
+func (pr *paymentRepository) GetAll(ctx *context.Context, search string) ([]entities.Payment, *errs.XError) {
+	var payments []entities.Payment
+
+	filterValue := util.ReadValueFromContext(ctx, constants.FILTER_KEY)
+	var filter string
+	if filterValue != nil {
+		filter = filterValue.(string)
+	}
+
+	res := pr.WithDB(ctx).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Scopes(scopes.GetPayments_Search(search)).
+		Scopes(scopes.GetPayments_Filter(filter)).
+		Scopes(db.Paginate(ctx)).
+		Preload("ReceivedBy", scopes.SelectFields("first_name", "last_name")).
+		Order("payment_date DESC").
+		Find(&payments)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find payments", res.Error)
+	}
+	return payments, nil
+}
+
+func (pr *paymentRepository) GetByOrderId(ctx *context.Context, orderId uint) ([]entities.Payment, *errs.XError) {
+	var payments []entities.Payment
+	res := pr.WithDB(ctx).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Where("order_id = ?", orderId).
+		Preload("ReceivedBy", scopes.SelectFields("first_name", "last_name")).
+		Order("payment_date ASC").
+		Find(&payments)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find payments for order", res.Error)
+	}
+	return payments, nil
+}
+
+// GetPaidAmount returns the sum of all active payments recorded against an order
+func (pr *paymentRepository) GetPaidAmount(ctx *context.Context, orderId uint) (float64, *errs.XError) {
+	var paidAmount float64
+	res := pr.WithDB(ctx).Model(&entities.Payment{}).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Where("order_id = ?", orderId).
+		Select("COALESCE(SUM(amount), 0)").
+		Scan(&paidAmount)
+	if res.Error != nil {
+		return 0, errs.NewXError(errs.DATABASE, "Unable to calculate paid amount", res.Error)
+	}
+	return paidAmount, nil
+}
+
+func (pr *paymentRepository) Delete(ctx *context.Context, id uint) *errs.XError {
+	payment := &entities.Payment{Model: &entities.Model{ID: id, IsActive: false}}
+	err := pr.GormDAL.Delete(ctx, payment)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	responseModel "github.com/imkarthi24/sf-backend/internal/model/response"
+	"github.com/imkarthi24/sf-backend/internal/repository/scopes"
+	"github.com/imkarthi24/sf-backend/internal/utils"
+	"github.com/loop-kar/pixie/db"
+	"github.com/loop-kar/pixie/errs"
+)
+
+type PayoutRepository interface {
+	CreateEntry(*context.Context, *entities.PayoutEntry) *errs.XError
+	GetEntries(*context.Context, requestModel.PayoutFilter) ([]entities.PayoutEntry, *errs.XError)
+	GetEntriesByAssignmentId(*context.Context, uint) ([]entities.PayoutEntry, *errs.XError)
+	GetOutstandingEntries(*context.Context, uint, time.Time) ([]entities.PayoutEntry, *errs.XError)
+	DeleteEntries(*context.Context, []uint) *errs.XError
+	GetOutstanding(*context.Context) ([]responseModel.PayoutOutstanding, *errs.XError)
+	GetBalance(*context.Context, uint, time.Time) (float64, *errs.XError)
+
+	CreateSettlement(*context.Context, *entities.PayoutSettlement) *errs.XError
+	MarkSettled(*context.Context, []uint, uint) *errs.XError
+	GetSettlement(*context.Context, uint) (*entities.PayoutSettlement, *errs.XError)
+	GetSettlements(*context.Context, requestModel.PayoutFilter) ([]entities.PayoutSettlement, *errs.XError)
+}
+
+type payoutRepository struct {
+	GormDAL
+}
+
+func ProvidePayoutRepository(customDB GormDAL) PayoutRepository {
+	return &payoutRepository{GormDAL: customDB}
+}
+
+func (pr *payoutRepository) CreateEntry(ctx *context.Context, entry *entities.PayoutEntry) *errs.XError {
+	res := pr.WithDB(ctx).Create(&entry)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to save payout entry", res.Error)
+	}
+	return nil
+}
+
+// GetEntries returns the entries of the filter, paginated when the range is not given
+func (pr *payoutRepository) GetEntries(ctx *context.Context, filter requestModel.PayoutFilter) ([]entities.PayoutEntry, *errs.XError) {
+	var entries []entities.PayoutEntry
+	query := pr.WithDB(ctx).Table(entities.PayoutEntry{}.TableNameForQuery()).
+		Scopes(scopes.Channel("E"), scopes.IsActive("E"))
+
+	if filter.TailorId != nil {
+		query = query.Where("E.tailor_id = ?", *filter.TailorId)
+	}
+	if filter.Unsettled {
+		query = query.Where("E.settlement_id IS NULL")
+	}
+	if filter.From != nil {
+		query = query.Where("E.accrued_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("E.accrued_at < ?", *filter.To)
+	}
+	if filter.From == nil && filter.To == nil {
+		query = query.Scopes(db.Paginate(ctx))
+	}
+
+	res := query.
+		Preload("Tailor", scopes.SelectFields("first_name", "last_name")).
+		Order("E.accrued_at ASC, E.id ASC").
+		Find(&entries)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find payout entries", res.Error)
+	}
+	return entries, nil
+}
+
+func (pr *payoutRepository) GetEntriesByAssignmentId(ctx *context.Context, assignmentId uint) ([]entities.PayoutEntry, *errs.XError) {
+	var entries []entities.PayoutEntry
+	res := pr.WithDB(ctx).
+		Where("assignment_id = ?", assignmentId).
+		Scopes(scopes.IsActive()).
+		Find(&entries)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find payout entries by assignment id", res.Error)
+	}
+	return entries, nil
+}
+
+// GetOutstandingEntries returns the entries of the tailor accrued before the time which are yet to be settled
+func (pr *payoutRepository) GetOutstandingEntries(ctx *context.Context, tailorId uint, before time.Time) ([]entities.PayoutEntry, *errs.XError) {
+	var entries []entities.PayoutEntry
+	res := pr.WithDB(ctx).
+		Where("tailor_id = ? AND settlement_id IS NULL AND accrued_at < ?", tailorId, before).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Order("accrued_at ASC, id ASC").
+		Find(&entries)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find outstanding payout entries", res.Error)
+	}
+	return entries, nil
+}
+
+func (pr *payoutRepository) DeleteEntries(ctx *context.Context, ids []uint) *errs.XError {
+	res := pr.WithDB(ctx).Model(&entities.PayoutEntry{}).
+		Where("id IN ?", ids).
+		Updates(map[string]interface{}{
+			"is_active":     false,
+			"updated_by_id": utils.GetUserId(ctx),
+		})
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to delete payout entries", res.Error)
+	}
+	return nil
+}
+
+// GetOutstanding returns the amount yet to be settled to each tailor, the tailors owed the most first
+func (pr *payoutRepository) GetOutstanding(ctx *context.Context) ([]responseModel.PayoutOutstanding, *errs.XError) {
+	var result []responseModel.PayoutOutstanding
+	res := pr.WithDB(ctx).
+		Table(entities.PayoutEntry{}.TableNameForQuery()).
+		Select(`U.id AS tailor_id,
+			CONCAT(U.first_name, ' ', U.last_name) AS tailor,
+			SUM(E.amount) AS amount,
+			COUNT(*) AS entry_count,
+			MIN(E.accrued_at) AS oldest_accrued_at`).
+		Joins(`INNER JOIN "stich"."Users" U ON U.id = E.tailor_id`).
+		Scopes(scopes.Channel("E"), scopes.IsActive("E")).
+		Where("E.settlement_id IS NULL").
+		Group("U.id, U.first_name, U.last_name").
+		Order("amount DESC").
+		Scan(&result)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find outstanding payouts", res.Error)
+	}
+	return result, nil
+}
+
+// GetBalance returns the amount owed to the tailor before the time, the work done less the settlements made
+func (pr *payoutRepository) GetBalance(ctx *context.Context, tailorId uint, before time.Time) (float64, *errs.XError) {
+	var accrued float64
+	res := pr.WithDB(ctx).Model(&entities.PayoutEntry{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Where("tailor_id = ? AND accrued_at < ?", tailorId, before).
+		Scan(&accrued)
+	if res.Error != nil {
+		return 0, errs.NewXError(errs.DATABASE, "Unable to calculate payout balance", res.Error)
+	}
+
+	var settled float64
+	res = pr.WithDB(ctx).Model(&entities.PayoutSettlement{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Where("tailor_id = ? AND settled_at < ?", tailorId, before).
+		Scan(&settled)
+	if res.Error != nil {
+		return 0, errs.NewXError(errs.DATABASE, "Unable to calculate payout balance", res.Error)
+	}
+
+	return accrued - settled, nil
+}
+
+func (pr *payoutRepository) CreateSettlement(ctx *context.Context, settlement *entities.PayoutSettlement) *errs.XError {
+	res := pr.WithDB(ctx).Create(&settlement)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to save payout settlement", res.Error)
+	}
+	return nil
+}
+
+// MarkSettled links the entries to the settlement they are paid with
+func (pr *payoutRepository) MarkSettled(ctx *context.Context, entryIds []uint, settlementId uint) *errs.XError {
+	res := pr.WithDB(ctx).Model(&entities.PayoutEntry{}).
+		Where("id IN ? AND settlement_id IS NULL", entryIds).
+		Update("settlement_id", settlementId)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to settle payout entries", res.Error)
+	}
+	if res.RowsAffected != int64(len(entryIds)) {
+		return errs.NewXError(errs.VALIDATION, "Some of the payout entries were settled meanwhile, please retry", nil)
+	}
+	return nil
+}
+
+func (pr *payoutRepository) GetSettlement(ctx *context.Context, id uint) (*entities.PayoutSettlement, *errs.XError) {
+	settlement := entities.PayoutSettlement{}
+	res := pr.WithDB(ctx).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Preload("Tailor", scopes.SelectFields("first_name", "last_name")).
+		Preload("SettledBy", scopes.SelectFields("first_name", "last_name")).
+		Preload("Entries", scopes.IsActive()).
+		Find(&settlement, id)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find payout settlement", res.Error)
+	}
+	return &settlement, nil
+}
+
+// GetSettlements returns the settlements of the filter, paginated when the range is not given
+func (pr *payoutRepository) GetSettlements(ctx *context.Context, filter requestModel.PayoutFilter) ([]entities.PayoutSettlement, *errs.XError) {
+	var settlements []entities.PayoutSettlement
+	query := pr.WithDB(ctx).Table(entities.PayoutSettlement{}.TableNameForQuery()).
+		Scopes(scopes.Channel("E"), scopes.IsActive("E"))
+
+	if filter.TailorId != nil {
+		query = query.Where("E.tailor_id = ?", *filter.TailorId)
+	}
+	if filter.From != nil {
+		query = query.Where("E.settled_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("E.settled_at < ?", *filter.To)
+	}
+	if filter.From == nil && filter.To == nil {
+		query = query.Scopes(db.Paginate(ctx))
+	}
+
+	res := query.
+		Preload("Tailor", scopes.SelectFields("first_name", "last_name")).
+		Preload("SettledBy", scopes.SelectFields("first_name", "last_name")).
+		Order("E.settled_at DESC, E.id DESC").
+		Find(&settlements)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find payout settlements", res.Error)
+	}
+	return settlements, nil
+}
@@ -2,6 +2,7 @@ package scopes
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/iancoleman/strcase"
@@ -9,6 +10,23 @@ import (
 	"gorm.io/gorm"
 )
 
+// orderBalanceAmount is the outstanding amount of an order:
+// sum of item totals + order additional charges - sum of active payments
+const orderBalanceAmount = `((SELECT COALESCE(SUM(total), 0) FROM "stich"."OrderItems"
+	 WHERE "stich"."OrderItems".order_id = "stich"."Orders".id) +
+	COALESCE("stich"."Orders".additional_charges, 0) -
+	(SELECT COALESCE(SUM(amount), 0) FROM "stich"."Payments"
+	 WHERE "stich"."Payments".order_id = "stich"."Orders".id AND "stich"."Payments".is_active = true))`
+
+var balanceOperators = map[string]string{
+	"eq":  "=",
+	"neq": "<>",
+	"gt":  ">",
+	"gte": ">=",
+	"lt":  "<",
+	"lte": "<=",
+}
+
 func GetOrders_Search(search string) func(db *gorm.DB) *gorm.DB {
 	defaultReturn := func(db *gorm.DB) *gorm.DB { return db }
 
@@ -51,6 +69,7 @@ func GetOrders_Filter(filters string) func(db *gorm.DB) *gorm.DB {
 		// Format examples:
 		// - "CustomerId in 1,2,3; OrderTakenById in 5,6"
 		// - "Status eq CONFIRMED, ExpectedDeliveryDate btwn '2024-01-01' AND '2024-12-31'"
+		// - "BalanceAmount gt 0"
 		var filterArray []string
 		if strings.Contains(filters, ";") {
 			filterArray = strings.Split(filters, ";")
@@ -60,6 +79,8 @@ func GetOrders_Filter(filters string) func(db *gorm.DB) *gorm.DB {
 
 		var regularFilters []string
 		inFilters := make(map[string][]interface{})
+		var balanceFilters []string
+		var balanceValues []interface{}
 
 		for _, filter := range filterArray {
 			filter = strings.TrimSpace(filter)
@@ -78,6 +99,18 @@ func GetOrders_Filter(filters string) func(db *gorm.DB) *gorm.DB {
 
 			// Normalize field name to CamelCase for lookup
 			fieldCamel := strcase.ToCamel(field)
+
+			// BalanceAmount is a calculated field, filter on the subquery expression
+			if fieldCamel == "BalanceAmount" {
+				sqlOperator, ok := balanceOperators[operator]
+				amount, err := strconv.ParseFloat(strings.Trim(valueStr, "'\""), 64)
+				if ok && err == nil {
+					balanceFilters = append(balanceFilters, fmt.Sprintf("%s %s ?", orderBalanceAmount, sqlOperator))
+					balanceValues = append(balanceValues, amount)
+				}
+				continue
+			}
+
 			dbField, exists := fieldMap[fieldCamel]
 			if !exists {
 				regularFilters = append(regularFilters, filter)
@@ -121,6 +154,10 @@ func GetOrders_Filter(filters string) func(db *gorm.DB) *gorm.DB {
 			}
 		}
 
+		for i, balanceFilter := range balanceFilters {
+			db = db.Where(balanceFilter, balanceValues[i])
+		}
+
 		return db
 	}
 }
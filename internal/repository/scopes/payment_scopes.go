@@ -0,0 +1,51 @@
+package scopes
+
+import (
+	"fmt"
+
+	"github.com/loop-kar/pixie/util"
+	"gorm.io/gorm"
+)
+
+func GetPayments_Search(search string) func(db *gorm.DB) *gorm.DB {
+	defaultReturn := func(db *gorm.DB) *gorm.DB { return db }
+
+	if util.IsNilOrEmptyString(&search) {
+		return defaultReturn
+	}
+
+	return func(db *gorm.DB) *gorm.DB {
+		formattedSearch := util.EncloseWithPercentageOperator(search)
+		whereClause := fmt.Sprintf(
+			`("stich"."Payments".reference_number ILIKE %s OR
+			 "stich"."Payments".notes ILIKE %s OR
+			 "stich"."Payments".order_id::text ILIKE %s)`,
+			formattedSearch, formattedSearch, formattedSearch,
+		)
+		return db.Where(whereClause)
+	}
+}
+
+func GetPayments_Filter(filters string) func(db *gorm.DB) *gorm.DB {
+	defaultReturn := func(db *gorm.DB) *gorm.DB { return db }
+
+	if util.IsNilOrEmptyString(&filters) {
+		return defaultReturn
+	}
+
+	fieldMap := map[string]string{
+		"OrderId":       "order_id",
+		"PaymentType":   "payment_type",
+		"PaymentMethod": "payment_method",
+		"PaymentDate":   "payment_date",
+		"Amount":        "amount",
+	}
+
+	return func(db *gorm.DB) *gorm.DB {
+		queryString := util.BuildQuery(filters, fieldMap)
+		if !util.IsNilOrEmptyString(&queryString) {
+			return db.Where(queryString)
+		}
+		return db
+	}
+}
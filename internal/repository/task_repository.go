@@ -17,6 +17,7 @@ type TaskRepository interface {
 	Get(*context.Context, uint) (*entities.Task, *errs.XError)
 	GetAll(*context.Context, string) ([]entities.Task, *errs.XError)
 	Delete(*context.Context, uint) *errs.XError
+	GetOpenByTitle(*context.Context, string, uint) (*entities.Task, *errs.XError)
 }
 
 type taskRepository struct {
@@ -79,3 +80,18 @@ func (tr *taskRepository) Delete(ctx *context.Context, id uint) *errs.XError {
 	}
 	return nil
 }
+
+// GetOpenByTitle finds the incomplete task of the assignee with the title, used to not repeat the reminders raised by the jobs
+func (tr *taskRepository) GetOpenByTitle(ctx *context.Context, title string, assignedToId uint) (*entities.Task, *errs.XError) {
+	task := entities.Task{}
+	res := tr.WithDB(ctx).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Where("title = ? AND assigned_to_id = ? AND is_completed = false", title, assignedToId).
+		Order("id DESC").
+		Limit(1).
+		Find(&task)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find open task", res.Error)
+	}
+	return &task, nil
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	responseModel "github.com/imkarthi24/sf-backend/internal/model/response"
+	"github.com/imkarthi24/sf-backend/internal/repository/scopes"
+	"github.com/loop-kar/pixie/db"
+	"github.com/loop-kar/pixie/errs"
+)
+
+// vendorNameKey compares the vendor names ignoring the case, spaces and punctuation, eg: "S.R. Textiles" = "sr textiles"
+const vendorNameKey = "LOWER(REGEXP_REPLACE(name, '[^[:alnum:]]', '', 'g'))"
+
+type VendorRepository interface {
+	Create(*context.Context, *entities.Vendor) *errs.XError
+	Update(*context.Context, *entities.Vendor) *errs.XError
+	Get(*context.Context, uint) (*entities.Vendor, *errs.XError)
+	GetAll(*context.Context, string) ([]entities.Vendor, *errs.XError)
+	Delete(*context.Context, uint) *errs.XError
+	AutocompleteVendor(*context.Context, string) ([]entities.Vendor, *errs.XError)
+	GetByNameKey(*context.Context, string) (*entities.Vendor, *errs.XError)
+	GetSpend(*context.Context, time.Time, time.Time) ([]responseModel.VendorSpend, *errs.XError)
+}
+
+type vendorRepository struct {
+	GormDAL
+}
+
+func ProvideVendorRepository(customDB GormDAL) VendorRepository {
+	return &vendorRepository{GormDAL: customDB}
+}
+
+func (vr *vendorRepository) Create(ctx *context.Context, vendor *entities.Vendor) *errs.XError {
+	res := vr.WithDB(ctx).Create(&vendor)
+	if res.Error != nil {
+		return errs.NewXError(errs.DATABASE, "Unable to save vendor", res.Error)
+	}
+	return nil
+}
+
+func (vr *vendorRepository) Update(ctx *context.Context, vendor *entities.Vendor) *errs.XError {
+	return vr.GormDAL.Update(ctx, *vendor)
+}
+
+func (vr *vendorRepository) Get(ctx *context.Context, id uint) (*entities.Vendor, *errs.XError) {
+	vendor := entities.Vendor{}
+	res := vr.WithDB(ctx).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Find(&vendor, id)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find vendor", res.Error)
+	}
+	return &vendor, nil
+}
+
+func (vr *vendorRepository) GetAll(ctx *context.Context, search string) ([]entities.Vendor, *errs.XError) {
+	var vendors []entities.Vendor
+	res := vr.WithDB(ctx).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Scopes(scopes.ILike(search, "name", "contact_person", "phone_number", "gstin")).
+		Scopes(db.Paginate(ctx)).
+		Order("name ASC").
+		Find(&vendors)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find vendors", res.Error)
+	}
+	return vendors, nil
+}
+
+func (vr *vendorRepository) Delete(ctx *context.Context, id uint) *errs.XError {
+	vendor := &entities.Vendor{Model: &entities.Model{ID: id, IsActive: false}}
+	return vr.GormDAL.Delete(ctx, vendor)
+}
+
+func (vr *vendorRepository) AutocompleteVendor(ctx *context.Context, search string) ([]entities.Vendor, *errs.XError) {
+	var vendors []entities.Vendor
+	res := vr.WithDB(ctx).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Scopes(scopes.ILike(search, "name", "phone_number", "gstin")).
+		Select("id", "name", "phone_number", "gstin").
+		Order("name ASC").
+		Find(&vendors)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find vendors for autocomplete", res.Error)
+	}
+	return vendors, nil
+}
+
+// GetByNameKey finds the active vendor of the channel whose name has the key, see vendorNameKey
+func (vr *vendorRepository) GetByNameKey(ctx *context.Context, key string) (*entities.Vendor, *errs.XError) {
+	vendor := entities.Vendor{}
+	res := vr.WithDB(ctx).
+		Scopes(scopes.Channel(), scopes.IsActive()).
+		Where(vendorNameKey+" = ?", key).
+		Order("id ASC").
+		Limit(1).
+		Find(&vendor)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find vendor by name", res.Error)
+	}
+	return &vendor, nil
+}
+
+// GetSpend returns the expenses billed by each vendor in the range, the highest spend first.
+// Expenses without a purchase date are taken on the day they were recorded.
+func (vr *vendorRepository) GetSpend(ctx *context.Context, from, to time.Time) ([]responseModel.VendorSpend, *errs.XError) {
+	var result []responseModel.VendorSpend
+	res := vr.WithDB(ctx).
+		Table(entities.Expense{}.TableNameForQuery()).
+		Select(`COALESCE(V.id, 0) AS vendor_id,
+			COALESCE(V.name, 'Unassigned') AS vendor,
+			COUNT(*) AS bill_count,
+			COALESCE(SUM(E.price), 0) AS total_spend,
+			MAX(COALESCE(E.purchase_date, E.created_at)) AS last_purchase_date`).
+		Joins(`LEFT JOIN "stich"."Vendors" V ON V.id = E.vendor_id`).
+		Scopes(scopes.Channel("E"), scopes.IsActive("E")).
+		Where("COALESCE(E.purchase_date, E.created_at) >= ? AND COALESCE(E.purchase_date, E.created_at) < ?", from, to).
+		Group("V.id, V.name").
+		Order("total_spend DESC").
+		Scan(&result)
+	if res.Error != nil {
+		return nil, errs.NewXError(errs.DATABASE, "Unable to find vendor spend", res.Error)
+	}
+	return result, nil
+}
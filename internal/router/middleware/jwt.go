@@ -1,13 +1,19 @@
 package router
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
-	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	appConstants "github.com/imkarthi24/sf-backend/internal/constants"
 	"github.com/imkarthi24/sf-backend/internal/entities"
 	"github.com/imkarthi24/sf-backend/internal/model/models"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	"github.com/imkarthi24/sf-backend/internal/service"
 	"github.com/loop-kar/pixie/constants"
 	"github.com/loop-kar/pixie/errs"
 	"github.com/loop-kar/pixie/util"
@@ -42,34 +48,54 @@ func VerifyJWT(secretKey string) gin.HandlerFunc {
 	}
 }
 
-func GenerateExternalSession() gin.HandlerFunc {
+// VerifySignedRequest creates the system session of the channel of the api key which signed the request,
+// the request is allowed only when the scopes of the key cover the resource and the action of the route
+func VerifySignedRequest(resource Resource, apiKeySvc service.ApiKeyService) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 
-		channelHeaders, channelExists := ctx.Request.Header["Channel"]
-		channelIdHeaders, channelIdExists := ctx.Request.Header["Channel-Id"]
+		body, err := io.ReadAll(ctx.Request.Body)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, errs.NewXError(errs.INVALID_REQUEST, errs.MALFORMED_REQUEST, err))
+			return
+		}
+		// the handler binds the body again
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		signedRequest := requestModel.SignedRequest{
+			KeyId:      ctx.GetHeader(appConstants.API_KEY_ID_HEADER),
+			Timestamp:  ctx.GetHeader(appConstants.API_KEY_TIMESTAMP_HEADER),
+			Signature:  ctx.GetHeader(appConstants.API_KEY_SIGNATURE_HEADER),
+			Method:     ctx.Request.Method,
+			RequestURI: ctx.Request.URL.RequestURI(),
+			Body:       body,
+		}
 
-		if !channelExists || len(channelHeaders) == 0 || !channelIdExists || len(channelIdHeaders) == 0 {
-			ctx.AbortWithStatusJSON(http.StatusForbidden, errs.NewXError(errs.INVALID_REQUEST, "missing required headers", nil))
+		context := util.CopyContextFromGin(ctx)
+		apiKey, errr := apiKeySvc.VerifySignature(&context, signedRequest)
+		if errr != nil {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errr)
 			return
 		}
 
-		channel := channelHeaders[0]
-		channelId, err := strconv.Atoi(channelIdHeaders[0])
-		if err != nil {
-			ctx.AbortWithStatusJSON(http.StatusForbidden, errs.NewXError(errs.INVALID_REQUEST, "invalid Channel-Id header", nil))
+		action := actionFor(ctx.Request.Method, ctx.FullPath())
+		if !HasPermission(strings.Split(apiKey.Scopes, ","), resource, action) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errs.NewXError(errs.INSUFFICIENT_ACCESS, fmt.Sprintf("api key does not have %s permission", Permission(resource, action)), nil))
 			return
 		}
 
-		if util.IsNilOrEmptyString(&channel) {
-			ctx.AbortWithStatusJSON(http.StatusForbidden, errs.NewXError(errs.INVALID_REQUEST, "invalid channel header", nil))
+		var userId uint
+		if apiKey.CreatedById != nil {
+			userId = *apiKey.CreatedById
 		}
 		sessionDetails := &models.Session{
-			Role:            entities.SUPERADMIN, //TODO: outsourced to freelance tailors
-			ChannelName:     channel,
-			ChannelId:       uint(channelId),
+			Role:            entities.SUPERADMIN,
+			UserId:          &userId,
+			ChannelName:     apiKey.Channel.Name,
+			ChannelId:       apiKey.ChannelId,
 			IsSystemSession: true,
 		}
 		ctx.Set(constants.SESSION, sessionDetails)
+		ctx.Set(apiKeyIdKey, apiKey.KeyId)
 		ctx.Next()
 	}
 }
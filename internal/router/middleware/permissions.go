@@ -0,0 +1,152 @@
+package router
+
+import (
+	"fmt"
+	"strings"
+
+	appConstants "github.com/imkarthi24/sf-backend/internal/constants"
+	"github.com/imkarthi24/sf-backend/internal/entities"
+)
+
+type Resource string
+
+type Action string
+
+const (
+	ResourceProfile            Resource = "profile" // the logged in user's own token, config and channels
+	ResourceUser               Resource = "user"
+	ResourceChannel            Resource = "channel"
+	ResourceMasterConfig       Resource = "master-config"
+	ResourceAdmin              Resource = "admin"
+	ResourceCustomer           Resource = "customer"
+	ResourceEnquiry            Resource = "enquiry"
+	ResourceOrder              Resource = "order"
+	ResourceOrderItem          Resource = "order-item"
+	ResourceMeasurement        Resource = "measurement"
+	ResourcePerson             Resource = "person"
+	ResourceDressType          Resource = "dress-type"
+	ResourceOrderHistory       Resource = "order-history"
+	ResourceMeasurementHistory Resource = "measurement-history"
+	ResourceEnquiryHistory     Resource = "enquiry-history"
+	ResourceExpense            Resource = "expense"
+	ResourcePayment            Resource = "payment"
+	ResourceTask               Resource = "task"
+	ResourceAttachment         Resource = "attachment"
+	ResourceDashboard          Resource = "dashboard"
+	ResourceAudit              Resource = "audit"
+	ResourceAssignment         Resource = "assignment" // production work of the order items
+	ResourcePayout             Resource = "payout"     // piece rate payouts of the outsourced tailors
+	ResourceInventory          Resource = "inventory"  // materials and their stock
+	ResourceVendor             Resource = "vendor"     // suppliers billed in the expenses
+	ResourceBudget             Resource = "budget"     // expense categories, recurring expenses and budgets
+	ResourceAppointment        Resource = "appointment"
+	ResourceApiKey             Resource = "api-key" // signed keys of the external integrations
+)
+
+const (
+	ActionRead   Action = "read"
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// DefaultPermissions is the permission matrix of each role.
+// A permission is "resource:action", either part can be a * wildcard, eg: "order:*" or "*".
+// The list of a role can be narrowed per channel with the Access.Permissions master config,
+// which takes the same shape as json, eg: {"STAFF":["order:*","expense:read"]}.
+// The read only roles list their resources, so that a new resource is not readable unless added here.
+var DefaultPermissions = map[entities.RoleType][]string{
+	entities.SYSTEM_ADMIN: {"*"},
+	entities.SUPERADMIN:   {"*"},
+	entities.ADMIN: {
+		"profile:*", "user:*", "channel:read", "channel:update", "master-config:*", "admin:*",
+		"customer:*", "enquiry:*", "order:*", "order-item:*", "assignment:*", "measurement:*", "person:*", "dress-type:*",
+		"order-history:*", "measurement-history:*", "enquiry-history:*", "expense:*", "payment:*", "task:*", "attachment:*",
+		"dashboard:read", "audit:read", "payout:*", "inventory:*", "vendor:*", "budget:*",
+		"appointment:*", "api-key:*",
+	},
+	entities.DEV: {
+		"profile:read", "user:read", "channel:read", "master-config:read",
+		"customer:read", "enquiry:read", "order:read", "order-item:read", "assignment:read", "measurement:read", "person:read",
+		"dress-type:read", "order-history:read", "measurement-history:read", "enquiry-history:read", "expense:read",
+		"payment:read", "task:read", "attachment:read", "dashboard:read", "audit:read", "inventory:read", "vendor:read",
+		"budget:read", "appointment:read",
+	},
+	entities.STAFF: {
+		"profile:*", "user:read", "channel:read", "master-config:read",
+		"customer:*", "enquiry:*", "person:*", "measurement:*", "task:*",
+		"order:read", "order:create", "order:update", "order-item:read", "order-item:create", "order-item:update",
+		"assignment:read", "assignment:create", "assignment:update",
+		"dress-type:read", "order-history:read", "order-history:create", "measurement-history:read",
+		"measurement-history:create", "enquiry-history:read", "enquiry-history:create",
+		"expense:read", "expense:create", "payment:read", "payment:create", "attachment:*", "payout:read",
+		"inventory:read", "inventory:create", "vendor:read", "vendor:create", "budget:read",
+		"appointment:*",
+	},
+	entities.OUTSOURCED: {
+		"profile:*", "master-config:read", "order:read", "order-item:read", "measurement:read",
+		"assignment:read", "assignment:update", "person:read", "dress-type:read", "task:read", "task:update", "attachment:read",
+		"payout:read",
+	},
+	entities.VIEWER: {
+		"profile:*", "user:read", "channel:read", "master-config:read",
+		"customer:read", "enquiry:read", "order:read", "order-item:read", "assignment:read", "measurement:read", "person:read",
+		"dress-type:read", "order-history:read", "measurement-history:read", "enquiry-history:read", "expense:read",
+		"payment:read", "task:read", "attachment:read", "dashboard:read", "inventory:read", "vendor:read",
+		"budget:read", "appointment:read",
+	},
+}
+
+// Roles whose permissions cannot be overridden per channel, so that the channel is never locked out
+var nonOverridableRoles = map[entities.RoleType]bool{
+	entities.SYSTEM_ADMIN: true,
+	entities.SUPERADMIN:   true,
+}
+
+// routeActions maps the routes which do not follow the http method convention to their action.
+// Key is "METHOD /full/route/path"
+var routeActions = map[string]Action{
+	"POST /" + appConstants.API_PREFIX_V1 + "/masterConfig/values": ActionRead,
+	"POST /" + appConstants.API_PREFIX_V1 + "/api-key/:id/rotate":  ActionUpdate,
+}
+
+func Permission(resource Resource, action Action) string {
+	return fmt.Sprintf("%s:%s", resource, action)
+}
+
+// HasPermission checks if any of the granted permissions covers the resource and action
+func HasPermission(granted []string, resource Resource, action Action) bool {
+	for _, permission := range granted {
+		if permission == "*" {
+			return true
+		}
+
+		grantedResource, grantedAction, found := strings.Cut(permission, ":")
+		if !found {
+			continue
+		}
+		if (grantedResource == "*" || grantedResource == string(resource)) &&
+			(grantedAction == "*" || grantedAction == string(action)) {
+			return true
+		}
+	}
+	return false
+}
+
+// actionFor derives the action from the route or the http method
+func actionFor(method string, fullPath string) Action {
+	if action, ok := routeActions[method+" "+fullPath]; ok {
+		return action
+	}
+
+	switch method {
+	case "POST":
+		return ActionCreate
+	case "PUT", "PATCH":
+		return ActionUpdate
+	case "DELETE":
+		return ActionDelete
+	default:
+		return ActionRead
+	}
+}
@@ -0,0 +1,89 @@
+package router
+
+import (
+	"testing"
+
+	appConstants "github.com/imkarthi24/sf-backend/internal/constants"
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_HasPermission(t *testing.T) {
+	tests := []struct {
+		granted  []string
+		resource Resource
+		action   Action
+		allowed  bool
+	}{
+		{[]string{"*"}, ResourceAdmin, ActionDelete, true},
+		{[]string{"order:*"}, ResourceOrder, ActionDelete, true},
+		{[]string{"*:read"}, ResourceCustomer, ActionRead, true},
+		{[]string{"*:read"}, ResourceCustomer, ActionCreate, false},
+		{[]string{"order:read"}, ResourceOrderItem, ActionRead, false},
+		{[]string{"order"}, ResourceOrder, ActionRead, false},
+		{nil, ResourceProfile, ActionRead, false},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.allowed, HasPermission(test.granted, test.resource, test.action), "%v %s", test.granted, Permission(test.resource, test.action))
+	}
+}
+
+func Test_DefaultPermissions(t *testing.T) {
+	tests := []struct {
+		role     entities.RoleType
+		resource Resource
+		action   Action
+		allowed  bool
+	}{
+		{entities.SYSTEM_ADMIN, ResourceAdmin, ActionUpdate, true},
+		{entities.SUPERADMIN, ResourceApiKey, ActionCreate, true},
+
+		{entities.ADMIN, ResourcePayout, ActionCreate, true},
+		{entities.ADMIN, ResourceApiKey, ActionUpdate, true},
+		{entities.ADMIN, ResourceChannel, ActionCreate, false},
+		{entities.ADMIN, ResourceDashboard, ActionUpdate, false},
+
+		{entities.DEV, ResourceAudit, ActionRead, true},
+		{entities.DEV, ResourceOrder, ActionCreate, false},
+		{entities.DEV, ResourcePayout, ActionRead, false},
+		{entities.DEV, ResourceApiKey, ActionRead, false},
+		{entities.DEV, ResourceAdmin, ActionRead, false},
+
+		{entities.STAFF, ResourceOrder, ActionUpdate, true},
+		{entities.STAFF, ResourceOrder, ActionDelete, false},
+		{entities.STAFF, ResourcePayment, ActionCreate, true},
+		{entities.STAFF, ResourcePayment, ActionDelete, false},
+		{entities.STAFF, ResourceAudit, ActionRead, false},
+
+		{entities.OUTSOURCED, ResourceAssignment, ActionUpdate, true},
+		{entities.OUTSOURCED, ResourceCustomer, ActionRead, false},
+		{entities.OUTSOURCED, ResourcePayout, ActionRead, true},
+
+		{entities.VIEWER, ResourceProfile, ActionUpdate, true},
+		{entities.VIEWER, ResourceOrder, ActionRead, true},
+		{entities.VIEWER, ResourceOrder, ActionUpdate, false},
+		{entities.VIEWER, ResourceAudit, ActionRead, false},
+		{entities.VIEWER, ResourcePayout, ActionRead, false},
+		{entities.VIEWER, ResourceApiKey, ActionRead, false},
+
+		// a role without a matrix has no access
+		{entities.RoleType("GUEST"), ResourceProfile, ActionRead, false},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.allowed, HasPermission(DefaultPermissions[test.role], test.resource, test.action), "%s %s", test.role, Permission(test.resource, test.action))
+	}
+}
+
+func Test_actionFor(t *testing.T) {
+	require.Equal(t, ActionRead, actionFor("GET", "/"+appConstants.API_PREFIX_V1+"/order"))
+	require.Equal(t, ActionCreate, actionFor("POST", "/"+appConstants.API_PREFIX_V1+"/order"))
+	require.Equal(t, ActionUpdate, actionFor("PUT", "/"+appConstants.API_PREFIX_V1+"/order/:id"))
+	require.Equal(t, ActionDelete, actionFor("DELETE", "/"+appConstants.API_PREFIX_V1+"/order/:id"))
+	require.Equal(t, ActionUpdate, actionFor("PATCH", "/"+appConstants.API_PREFIX_V1+"/api-key/:id/revoke"))
+
+	// the routes which do not follow the method convention
+	require.Equal(t, ActionUpdate, actionFor("POST", "/"+appConstants.API_PREFIX_V1+"/api-key/:id/rotate"))
+	require.Equal(t, ActionRead, actionFor("POST", "/"+appConstants.API_PREFIX_V1+"/masterConfig/values"))
+}
@@ -0,0 +1,79 @@
+package router
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	appConstants "github.com/imkarthi24/sf-backend/internal/constants"
+	"github.com/loop-kar/pixie/errs"
+)
+
+// apiKeyIdKey holds the key id of the verified signed request in the gin context
+const apiKeyIdKey = "apiKeyId"
+
+type clientWindow struct {
+	start time.Time
+	count int
+}
+
+// clientRateLimiter counts the requests of each client in a fixed window
+type clientRateLimiter struct {
+	mu        sync.Mutex
+	limit     int
+	window    time.Duration
+	windows   map[string]*clientWindow
+	lastPrune time.Time
+}
+
+func (l *clientRateLimiter) allow(client string, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// drop the expired windows so that the map does not grow with every client
+	if now.Sub(l.lastPrune) > l.window {
+		for key, w := range l.windows {
+			if now.Sub(w.start) > l.window {
+				delete(l.windows, key)
+			}
+		}
+		l.lastPrune = now
+	}
+
+	w, ok := l.windows[client]
+	if !ok || now.Sub(w.start) > l.window {
+		l.windows[client] = &clientWindow{start: now, count: 1}
+		return true
+	}
+	if w.count >= l.limit {
+		return false
+	}
+	w.count++
+	return true
+}
+
+// RateLimitByClient allows at most limit requests per window for each client of the api key, after VerifySignedRequest.
+// The forms post through their backend, so the client is the visitor ip forwarded by the backend, else the ip of the request.
+// The counts are kept in memory, so the limit applies per instance of the server
+func RateLimitByClient(limit int, window time.Duration) gin.HandlerFunc {
+	limiter := &clientRateLimiter{
+		limit:   limit,
+		window:  window,
+		windows: make(map[string]*clientWindow),
+	}
+
+	return func(ctx *gin.Context) {
+		clientIp := strings.TrimSpace(ctx.GetHeader(appConstants.API_KEY_CLIENT_IP_HEADER))
+		if clientIp == "" {
+			clientIp = ctx.ClientIP()
+		}
+
+		if !limiter.allow(ctx.GetString(apiKeyIdKey)+"|"+clientIp, time.Now()) {
+			ctx.AbortWithStatusJSON(http.StatusTooManyRequests, errs.NewXError(errs.INVALID_REQUEST, "too many requests, try again later", nil))
+			return
+		}
+		ctx.Next()
+	}
+}
@@ -1,14 +1,24 @@
 package router
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	appConstants "github.com/imkarthi24/sf-backend/internal/constants"
 	"github.com/imkarthi24/sf-backend/internal/model/models"
+	"github.com/imkarthi24/sf-backend/internal/service"
 	"github.com/loop-kar/pixie/constants"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/loop-kar/pixie/util"
 )
 
-func RoleBasedAccessControl() gin.HandlerFunc {
+// RoleBasedAccessControl allows the request only when the role of the session
+// has the permission for the resource and the action of the route.
+// It has to be mounted after VerifyJWT
+func RoleBasedAccessControl(resource Resource, masterConfigSvc service.MasterConfigService) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		sessionValue := ctx.Value(constants.SESSION)
 		if sessionValue == nil {
@@ -28,8 +38,8 @@ func RoleBasedAccessControl() gin.HandlerFunc {
 			return
 		}
 
-		if err := checkResourceAccess(ctx, session); err != nil {
-			ctx.AbortWithError(http.StatusForbidden, err)
+		if err := checkResourceAccess(ctx, session, resource, masterConfigSvc); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, err)
 			return
 		}
 
@@ -38,9 +48,58 @@ func RoleBasedAccessControl() gin.HandlerFunc {
 }
 
 // checkResourceAccess validates if the user has access to the requested resource
-func checkResourceAccess(ctx *gin.Context, session *models.Session) error {
-	// path := ctx.Request.URL.Path
-	// method := ctx.Request.Method
+func checkResourceAccess(ctx *gin.Context, session *models.Session, resource Resource, masterConfigSvc service.MasterConfigService) *errs.XError {
+	action := actionFor(ctx.Request.Method, ctx.FullPath())
 
-	return nil
+	// the channel's override can only take away the permissions of the role, never add to them
+	if HasPermission(DefaultPermissions[session.Role], resource, action) &&
+		HasPermission(getPermissions(ctx, session, masterConfigSvc), resource, action) {
+		return nil
+	}
+
+	return errs.NewXError(errs.INSUFFICIENT_ACCESS, fmt.Sprintf("%s role does not have %s permission", session.Role, Permission(resource, action)), nil).
+		SetCode(http.StatusForbidden)
+}
+
+// getPermissions returns the channel's permissions of the role from master config,
+// falls back to the default permissions when not configured or invalid
+func getPermissions(ctx *gin.Context, session *models.Session, masterConfigSvc service.MasterConfigService) []string {
+	defaultPermissions := DefaultPermissions[session.Role]
+	if nonOverridableRoles[session.Role] || masterConfigSvc == nil {
+		return defaultPermissions
+	}
+
+	context := util.CopyContextFromGin(ctx)
+	value, err := masterConfigSvc.GetByName(&context, appConstants.CONFIG_ACCESS_PERMISSIONS)
+	if err != nil || util.IsNilOrEmptyString(&value) {
+		return defaultPermissions
+	}
+
+	permissions := make(map[string][]string)
+	if jsonErr := json.Unmarshal([]byte(value), &permissions); jsonErr != nil {
+		return defaultPermissions
+	}
+
+	if rolePermissions, ok := permissions[string(session.Role)]; ok {
+		return rolePermissions
+	}
+
+	return defaultPermissions
+}
+
+// CanGrant reports whether the user of the session holds the scope in the "resource:action" form,
+// a wildcard scope is granted only by the same or a wider wildcard
+func CanGrant(ctx *gin.Context, masterConfigSvc service.MasterConfigService, scope string) bool {
+	session, ok := ctx.Value(constants.SESSION).(*models.Session)
+	if !ok || session == nil {
+		return false
+	}
+
+	resource, action, found := strings.Cut(scope, ":")
+	if !found {
+		return false
+	}
+
+	return HasPermission(DefaultPermissions[session.Role], Resource(resource), Action(action)) &&
+		HasPermission(getPermissions(ctx, session, masterConfigSvc), Resource(resource), Action(action))
 }
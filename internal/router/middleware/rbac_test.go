@@ -0,0 +1,104 @@
+package router
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	appConstants "github.com/imkarthi24/sf-backend/internal/constants"
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/model/models"
+	"github.com/imkarthi24/sf-backend/internal/service"
+	"github.com/loop-kar/pixie/constants"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/stretchr/testify/require"
+)
+
+// masterConfigStub returns the Access.Permissions override of the channel
+type masterConfigStub struct {
+	service.MasterConfigService
+	permissions string
+}
+
+func (m masterConfigStub) GetByName(ctx *context.Context, name string) (string, *errs.XError) {
+	if name != appConstants.CONFIG_ACCESS_PERMISSIONS {
+		return "", nil
+	}
+	return m.permissions, nil
+}
+
+func newSessionContext(role entities.RoleType, method string) *gin.Context {
+	gin.SetMode(gin.TestMode)
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(method, "/", nil)
+	if role != "" {
+		userId := uint(1)
+		ctx.Set(constants.SESSION, &models.Session{Role: role, UserId: &userId, ChannelId: 1})
+	}
+	return ctx
+}
+
+func Test_checkResourceAccess(t *testing.T) {
+	tests := []struct {
+		name     string
+		role     entities.RoleType
+		override string
+		method   string
+		resource Resource
+		allowed  bool
+	}{
+		{"default permissions", entities.STAFF, "", http.MethodPost, ResourceOrder, true},
+		{"default permissions deny", entities.STAFF, "", http.MethodDelete, ResourceOrder, false},
+		{"override narrows the role", entities.STAFF, `{"STAFF":["order:read"]}`, http.MethodPost, ResourceOrder, false},
+		{"override keeps the listed permission", entities.STAFF, `{"STAFF":["order:read"]}`, http.MethodGet, ResourceOrder, true},
+		{"override of another role", entities.STAFF, `{"VIEWER":["order:read"]}`, http.MethodPost, ResourceOrder, true},
+		{"override cannot widen the role", entities.VIEWER, `{"VIEWER":["*"]}`, http.MethodDelete, ResourceOrder, false},
+		{"override cannot add a resource", entities.DEV, `{"DEV":["payout:read","order:read"]}`, http.MethodGet, ResourcePayout, false},
+		{"invalid override falls back to the defaults", entities.STAFF, `{"STAFF":`, http.MethodPost, ResourceOrder, true},
+		{"super admin is not overridable", entities.SUPERADMIN, `{"SUPER ADMIN":["order:read"]}`, http.MethodDelete, ResourceOrder, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := newSessionContext(test.role, test.method)
+			session := ctx.Value(constants.SESSION).(*models.Session)
+
+			err := checkResourceAccess(ctx, session, test.resource, masterConfigStub{permissions: test.override})
+			if test.allowed {
+				require.Nil(t, err)
+			} else {
+				require.NotNil(t, err)
+			}
+		})
+	}
+}
+
+func Test_CanGrant(t *testing.T) {
+	tests := []struct {
+		name     string
+		role     entities.RoleType
+		override string
+		scope    string
+		allowed  bool
+	}{
+		{"scope held by the role", entities.ADMIN, "", "enquiry:create", true},
+		{"wildcard action held by the role", entities.ADMIN, "", "enquiry:*", true},
+		{"resource wildcard not held by the role", entities.ADMIN, "", "*:read", false},
+		{"full wildcard not held by the role", entities.ADMIN, "", "*:*", false},
+		{"resource outside the role", entities.ADMIN, "", "channel:create", false},
+		{"super admin grants any scope", entities.SUPERADMIN, "", "*:*", true},
+		{"scope taken away by the override", entities.ADMIN, `{"ADMIN":["master-config:read"]}`, "enquiry:create", false},
+		{"override cannot widen the grant", entities.VIEWER, `{"VIEWER":["*"]}`, "enquiry:create", false},
+		{"malformed scope", entities.ADMIN, "", "enquiry", false},
+		{"no session", "", "", "enquiry:create", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := newSessionContext(test.role, http.MethodPost)
+			require.Equal(t, test.allowed, CanGrant(ctx, masterConfigStub{permissions: test.override}, test.scope))
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package router
 
 import (
+	"log"
+	"time"
+
 	"github.com/gin-contrib/gzip"
 	"github.com/gin-gonic/gin"
 	"github.com/imkarthi24/sf-backend/internal/config"
@@ -8,18 +11,25 @@ import (
 	baseHandler "github.com/imkarthi24/sf-backend/internal/handler/base"
 	"github.com/imkarthi24/sf-backend/internal/log/newreliclog"
 	router "github.com/imkarthi24/sf-backend/internal/router/middleware"
+	"github.com/imkarthi24/sf-backend/internal/service"
 	"github.com/loop-kar/pixie/middleware"
+	"github.com/loop-kar/pixie/util"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 
 	docs "github.com/imkarthi24/sf-backend/docs"
 )
 
-func InitRouter(handler baseHandler.BaseHandler, srvConfig config.ServerConfig) *gin.Engine {
+func InitRouter(handler baseHandler.BaseHandler, srvConfig config.ServerConfig, masterConfigSvc service.MasterConfigService, apiKeySvc service.ApiKeyService) *gin.Engine {
 
 	g := gin.Default()
 	g.Use(gin.Recovery())
 
+	// gin trusts the forwarded headers of every request by default, which lets a client choose its own ip
+	if err := g.SetTrustedProxies(util.SplitNonEmpty(srvConfig.TrustedProxies, ",")); err != nil {
+		log.Fatalf("invalid trusted proxies: %v", err)
+	}
+
 	// Middlewares
 	g.Use(middleware.NewRelicMiddleWare(newreliclog.Get()))
 	g.Use(middleware.LogMiddleware())
@@ -49,31 +59,42 @@ func InitRouter(handler baseHandler.BaseHandler, srvConfig config.ServerConfig)
 		}
 
 		// External Endpoints
-		// Used with channel-id and channel-name header
-		externalEndpoints := appRouter.Group("external", router.GenerateExternalSession())
+		// Used with the requests signed by an api key of the channel, the scopes of the key authorize the route
+		externalEndpoints := appRouter.Group("external")
 		{
-			configEndpoint := externalEndpoints.Group("masterConfig")
+			configEndpoint := externalEndpoints.Group("masterConfig", router.VerifySignedRequest(router.ResourceMasterConfig, apiKeySvc))
 			{
 				configEndpoint.GET("value", handler.MasterConfigHandler.GetValue)
 			}
+
+			// Leads of the website and social lead forms, posted through the backend of the form
+			externalEndpoints.POST("enquiry",
+				router.VerifySignedRequest(router.ResourceEnquiry, apiKeySvc),
+				router.RateLimitByClient(srvConfig.ExternalRateLimit, time.Minute),
+				handler.EnquiryHandler.SaveExternalEnquiry)
 		}
 
-		//**************JWT ENDPOINTS**************************//
+		// Signed download urls of the locally stored attachments
+		appRouter.GET(constants.ATTACHMENT_FILE, handler.AttachmentHandler.DownloadFile)
 
-		userEndpoints := appRouter.Group("user", router.VerifyJWT(srvConfig.JwtSecretKey))
-		{
-			userEndpoints.POST("", handler.UserHandler.SaveUser)
-			userEndpoints.PUT(":id", handler.UserHandler.UpdateUser)
+		//**************JWT ENDPOINTS**************************//
 
-			userEndpoints.GET(":id", handler.UserHandler.Get)
-			userEndpoints.GET("autocomplete", handler.UserHandler.GetUsersForAutoComplete)
-			userEndpoints.GET("", handler.UserHandler.GetAllUsers)
-			userEndpoints.GET("refresh-token", handler.UserHandler.RefreshToken)
-			userEndpoints.GET("switch-channel/:id", handler.UserHandler.SwitchChannel)
+		// authorize verifies the JWT and the role's permission on the resource
+		authorize := func(resource router.Resource) []gin.HandlerFunc {
+			return []gin.HandlerFunc{
+				router.VerifyJWT(srvConfig.JwtSecretKey),
+				router.RoleBasedAccessControl(resource, masterConfigSvc),
+			}
+		}
 
-			userEndpoints.DELETE(":id", handler.UserHandler.Delete)
+		// Endpoints of the logged in user's own session, config and channels
+		profileEndpoints := appRouter.Group("user", authorize(router.ResourceProfile)...)
+		{
+			profileEndpoints.GET("refresh-token", handler.UserHandler.RefreshToken)
+			profileEndpoints.GET("switch-channel/:id", handler.UserHandler.SwitchChannel)
+			profileEndpoints.GET("channel/accessible", handler.UserHandler.GetUserAccessibleChannels)
 
-			configEndpoints := userEndpoints.Group("config", router.VerifyJWT(srvConfig.JwtSecretKey))
+			configEndpoints := profileEndpoints.Group("config")
 			{
 				configEndpoints.POST("", handler.UserHandler.SaveUserConfig)
 
@@ -81,17 +102,28 @@ func InitRouter(handler baseHandler.BaseHandler, srvConfig config.ServerConfig)
 
 				configEndpoints.GET("", handler.UserHandler.GetUserConfig)
 			}
+		}
+
+		userEndpoints := appRouter.Group("user", authorize(router.ResourceUser)...)
+		{
+			userEndpoints.POST("", handler.UserHandler.SaveUser)
+			userEndpoints.PUT(":id", handler.UserHandler.UpdateUser)
 
-			channelEndpoints := userEndpoints.Group("channel", router.VerifyJWT(srvConfig.JwtSecretKey))
+			userEndpoints.GET(":id", handler.UserHandler.Get)
+			userEndpoints.GET("autocomplete", handler.UserHandler.GetUsersForAutoComplete)
+			userEndpoints.GET("", handler.UserHandler.GetAllUsers)
+
+			userEndpoints.DELETE(":id", handler.UserHandler.Delete)
+
+			channelEndpoints := userEndpoints.Group("channel")
 			{
 				channelEndpoints.POST("", handler.UserHandler.SaveUserChannelDetail)
 				channelEndpoints.PUT(":id", handler.UserHandler.UpdateUserChannelDetail)
 				channelEndpoints.DELETE(":id", handler.UserHandler.DeleteUserChannelDetail)
-				channelEndpoints.GET("accessible", handler.UserHandler.GetUserAccessibleChannels)
 			}
 		}
 
-		channelEndpoints := appRouter.Group("channel", router.VerifyJWT(srvConfig.JwtSecretKey))
+		channelEndpoints := appRouter.Group("channel", authorize(router.ResourceChannel)...)
 		{
 			channelEndpoints.POST("", handler.ChannelHandler.SaveChannel)
 
@@ -105,7 +137,7 @@ func InitRouter(handler baseHandler.BaseHandler, srvConfig config.ServerConfig)
 			channelEndpoints.DELETE(":id", handler.ChannelHandler.Delete)
 		}
 
-		masterConfigEndpoints := appRouter.Group("masterConfig", router.VerifyJWT(srvConfig.JwtSecretKey))
+		masterConfigEndpoints := appRouter.Group("masterConfig", authorize(router.ResourceMasterConfig)...)
 		{
 			masterConfigEndpoints.POST("", handler.MasterConfigHandler.Create)
 			masterConfigEndpoints.POST("values", handler.MasterConfigHandler.GetMultipleValues)
@@ -117,12 +149,21 @@ func InitRouter(handler baseHandler.BaseHandler, srvConfig config.ServerConfig)
 			masterConfigEndpoints.GET("value", handler.MasterConfigHandler.GetValue)
 		}
 
-		adminEndpoints := appRouter.Group("admin", router.VerifyJWT(srvConfig.JwtSecretKey))
+		apiKeyEndpoints := appRouter.Group("api-key", authorize(router.ResourceApiKey)...)
+		{
+			apiKeyEndpoints.POST("", handler.ApiKeyHandler.Issue)
+			apiKeyEndpoints.POST(":id/rotate", handler.ApiKeyHandler.Rotate)
+			apiKeyEndpoints.PATCH(":id/revoke", handler.ApiKeyHandler.Revoke)
+			apiKeyEndpoints.GET(":id", handler.ApiKeyHandler.Get)
+			apiKeyEndpoints.GET("", handler.ApiKeyHandler.GetAll)
+		}
+
+		adminEndpoints := appRouter.Group("admin", authorize(router.ResourceAdmin)...)
 		{
 			adminEndpoints.POST("switch-branch", handler.AdminHandler.SwitchBranch)
 		}
 
-		customerEndpoints := appRouter.Group("customer", router.VerifyJWT(srvConfig.JwtSecretKey))
+		customerEndpoints := appRouter.Group("customer", authorize(router.ResourceCustomer)...)
 		{
 			customerEndpoints.POST("", handler.CustomerHandler.SaveCustomer)
 			customerEndpoints.PUT(":id", handler.CustomerHandler.UpdateCustomer)
@@ -132,9 +173,10 @@ func InitRouter(handler baseHandler.BaseHandler, srvConfig config.ServerConfig)
 			customerEndpoints.DELETE(":id", handler.CustomerHandler.Delete)
 		}
 
-		enquiryEndpoints := appRouter.Group("enquiry", router.VerifyJWT(srvConfig.JwtSecretKey))
+		enquiryEndpoints := appRouter.Group("enquiry", authorize(router.ResourceEnquiry)...)
 		{
 			enquiryEndpoints.POST("", handler.EnquiryHandler.SaveEnquiry)
+			enquiryEndpoints.POST(":id/convert", handler.EnquiryHandler.ConvertToOrder)
 			enquiryEndpoints.PUT(":id/customer", handler.EnquiryHandler.UpdateEnquiryAndCustomer)
 			enquiryEndpoints.PUT(":id", handler.EnquiryHandler.UpdateEnquiry)
 			enquiryEndpoints.GET(":id", handler.EnquiryHandler.Get)
@@ -142,16 +184,18 @@ func InitRouter(handler baseHandler.BaseHandler, srvConfig config.ServerConfig)
 			enquiryEndpoints.DELETE(":id", handler.EnquiryHandler.Delete)
 		}
 
-		orderEndpoints := appRouter.Group("order", router.VerifyJWT(srvConfig.JwtSecretKey))
+		orderEndpoints := appRouter.Group("order", authorize(router.ResourceOrder)...)
 		{
 			orderEndpoints.POST("", handler.OrderHandler.SaveOrder)
 			orderEndpoints.PUT(":id", handler.OrderHandler.UpdateOrder)
+			orderEndpoints.PATCH(":id/status", handler.OrderHandler.UpdateOrderStatus)
 			orderEndpoints.GET(":id", handler.OrderHandler.Get)
+			orderEndpoints.GET(":id/invoice", handler.OrderHandler.GetInvoice)
 			orderEndpoints.GET("", handler.OrderHandler.GetAllOrders)
 			orderEndpoints.DELETE(":id", handler.OrderHandler.Delete)
 		}
 
-		orderItemEndpoints := appRouter.Group("order-item", router.VerifyJWT(srvConfig.JwtSecretKey))
+		orderItemEndpoints := appRouter.Group("order-item", authorize(router.ResourceOrderItem)...)
 		{
 			orderItemEndpoints.POST("", handler.OrderItemHandler.SaveOrderItem)
 			orderItemEndpoints.PUT(":id", handler.OrderItemHandler.UpdateOrderItem)
@@ -160,7 +204,80 @@ func InitRouter(handler baseHandler.BaseHandler, srvConfig config.ServerConfig)
 			orderItemEndpoints.DELETE(":id", handler.OrderItemHandler.Delete)
 		}
 
-		measurementEndpoints := appRouter.Group("measurement", router.VerifyJWT(srvConfig.JwtSecretKey))
+		orderItemAssignmentEndpoints := appRouter.Group("order-item-assignment", authorize(router.ResourceAssignment)...)
+		{
+			orderItemAssignmentEndpoints.POST("", handler.OrderItemAssignmentHandler.SaveAssignment)
+			orderItemAssignmentEndpoints.PUT(":id", handler.OrderItemAssignmentHandler.UpdateAssignment)
+			orderItemAssignmentEndpoints.PATCH(":id/status", handler.OrderItemAssignmentHandler.UpdateAssignmentStatus)
+			orderItemAssignmentEndpoints.GET("my-queue", handler.OrderItemAssignmentHandler.GetWorkQueue)
+			orderItemAssignmentEndpoints.GET(":id", handler.OrderItemAssignmentHandler.Get)
+			orderItemAssignmentEndpoints.GET("", handler.OrderItemAssignmentHandler.GetAllAssignments)
+			orderItemAssignmentEndpoints.DELETE(":id", handler.OrderItemAssignmentHandler.Delete)
+		}
+
+		payoutEndpoints := appRouter.Group("payout", authorize(router.ResourcePayout)...)
+		{
+			payoutEndpoints.POST("settlement", handler.PayoutHandler.SaveSettlement)
+			payoutEndpoints.GET("settlement/:id", handler.PayoutHandler.GetSettlement)
+			payoutEndpoints.GET("settlement", handler.PayoutHandler.GetAllSettlements)
+			payoutEndpoints.GET("entry", handler.PayoutHandler.GetAllEntries)
+			payoutEndpoints.GET("outstanding", handler.PayoutHandler.GetOutstanding)
+			payoutEndpoints.GET("statement", handler.PayoutHandler.GetStatement)
+		}
+
+		inventoryEndpoints := appRouter.Group("inventory", authorize(router.ResourceInventory)...)
+		{
+			inventoryEndpoints.POST("material", handler.InventoryHandler.SaveMaterial)
+			inventoryEndpoints.PUT("material/:id", handler.InventoryHandler.UpdateMaterial)
+			inventoryEndpoints.GET("material/:id", handler.InventoryHandler.GetMaterial)
+			inventoryEndpoints.GET("material", handler.InventoryHandler.GetAllMaterials)
+			inventoryEndpoints.DELETE("material/:id", handler.InventoryHandler.DeleteMaterial)
+			inventoryEndpoints.POST("movement", handler.InventoryHandler.SaveMovement)
+			inventoryEndpoints.GET("movement", handler.InventoryHandler.GetAllMovements)
+			inventoryEndpoints.DELETE("movement/:id", handler.InventoryHandler.DeleteMovement)
+			inventoryEndpoints.GET("stock", handler.InventoryHandler.GetStockLevels)
+		}
+
+		vendorEndpoints := appRouter.Group("vendor", authorize(router.ResourceVendor)...)
+		{
+			vendorEndpoints.POST("", handler.VendorHandler.SaveVendor)
+			vendorEndpoints.PUT(":id", handler.VendorHandler.UpdateVendor)
+			vendorEndpoints.GET("autocomplete", handler.VendorHandler.AutocompleteVendor)
+			vendorEndpoints.GET("spend", handler.VendorHandler.GetSpend)
+			vendorEndpoints.GET(":id", handler.VendorHandler.Get)
+			vendorEndpoints.GET("", handler.VendorHandler.GetAllVendors)
+			vendorEndpoints.DELETE(":id", handler.VendorHandler.Delete)
+		}
+
+		budgetEndpoints := appRouter.Group("budget", authorize(router.ResourceBudget)...)
+		{
+			budgetEndpoints.POST("category", handler.BudgetHandler.SaveCategory)
+			budgetEndpoints.PUT("category/:id", handler.BudgetHandler.UpdateCategory)
+			budgetEndpoints.GET("category/:id", handler.BudgetHandler.GetCategory)
+			budgetEndpoints.GET("category", handler.BudgetHandler.GetAllCategories)
+			budgetEndpoints.DELETE("category/:id", handler.BudgetHandler.DeleteCategory)
+			budgetEndpoints.POST("recurring", handler.BudgetHandler.SaveRecurringExpense)
+			budgetEndpoints.PUT("recurring/:id", handler.BudgetHandler.UpdateRecurringExpense)
+			budgetEndpoints.GET("recurring/:id", handler.BudgetHandler.GetRecurringExpense)
+			budgetEndpoints.GET("recurring", handler.BudgetHandler.GetAllRecurringExpenses)
+			budgetEndpoints.DELETE("recurring/:id", handler.BudgetHandler.DeleteRecurringExpense)
+			budgetEndpoints.GET("report", handler.BudgetHandler.GetBudgetReport)
+			budgetEndpoints.POST("", handler.BudgetHandler.SaveBudget)
+			budgetEndpoints.GET("", handler.BudgetHandler.GetAllBudgets)
+			budgetEndpoints.DELETE(":id", handler.BudgetHandler.DeleteBudget)
+		}
+
+		appointmentEndpoints := appRouter.Group("appointment", authorize(router.ResourceAppointment)...)
+		{
+			appointmentEndpoints.POST("", handler.AppointmentHandler.SaveAppointment)
+			appointmentEndpoints.PUT(":id", handler.AppointmentHandler.UpdateAppointment)
+			appointmentEndpoints.PATCH(":id/status", handler.AppointmentHandler.UpdateAppointmentStatus)
+			appointmentEndpoints.GET("calendar", handler.AppointmentHandler.GetCalendar)
+			appointmentEndpoints.GET(":id", handler.AppointmentHandler.Get)
+			appointmentEndpoints.DELETE(":id", handler.AppointmentHandler.Delete)
+		}
+
+		measurementEndpoints := appRouter.Group("measurement", authorize(router.ResourceMeasurement)...)
 		{
 			measurementEndpoints.POST("", handler.MeasurementHandler.SaveMeasurement)
 			measurementEndpoints.POST("bulk", handler.MeasurementHandler.SaveBulkMeasurements)
@@ -169,9 +286,10 @@ func InitRouter(handler baseHandler.BaseHandler, srvConfig config.ServerConfig)
 			measurementEndpoints.GET(":id", handler.MeasurementHandler.Get)
 			measurementEndpoints.GET("", handler.MeasurementHandler.GetAllMeasurements)
 			measurementEndpoints.DELETE(":id", handler.MeasurementHandler.Delete)
+			measurementEndpoints.POST(":id/restore/:historyId", handler.MeasurementHandler.Restore)
 		}
 
-		personEndpoints := appRouter.Group("person", router.VerifyJWT(srvConfig.JwtSecretKey))
+		personEndpoints := appRouter.Group("person", authorize(router.ResourcePerson)...)
 		{
 			personEndpoints.POST("", handler.PersonHandler.SavePerson)
 			personEndpoints.PUT(":id", handler.PersonHandler.UpdatePerson)
@@ -181,7 +299,7 @@ func InitRouter(handler baseHandler.BaseHandler, srvConfig config.ServerConfig)
 			personEndpoints.DELETE(":id", handler.PersonHandler.Delete)
 		}
 
-		dressTypeEndpoints := appRouter.Group("dress-type", router.VerifyJWT(srvConfig.JwtSecretKey))
+		dressTypeEndpoints := appRouter.Group("dress-type", authorize(router.ResourceDressType)...)
 		{
 			dressTypeEndpoints.POST("", handler.DressTypeHandler.SaveDressType)
 			dressTypeEndpoints.PUT(":id", handler.DressTypeHandler.UpdateDressType)
@@ -190,7 +308,7 @@ func InitRouter(handler baseHandler.BaseHandler, srvConfig config.ServerConfig)
 			dressTypeEndpoints.DELETE(":id", handler.DressTypeHandler.Delete)
 		}
 
-		orderHistoryEndpoints := appRouter.Group("order-history", router.VerifyJWT(srvConfig.JwtSecretKey))
+		orderHistoryEndpoints := appRouter.Group("order-history", authorize(router.ResourceOrderHistory)...)
 		{
 			orderHistoryEndpoints.POST("", handler.OrderHistoryHandler.SaveOrderHistory)
 			orderHistoryEndpoints.GET(":id", handler.OrderHistoryHandler.Get)
@@ -198,15 +316,16 @@ func InitRouter(handler baseHandler.BaseHandler, srvConfig config.ServerConfig)
 			orderHistoryEndpoints.GET("order/:orderId", handler.OrderHistoryHandler.GetByOrderId)
 		}
 
-		measurementHistoryEndpoints := appRouter.Group("measurement-history", router.VerifyJWT(srvConfig.JwtSecretKey))
+		measurementHistoryEndpoints := appRouter.Group("measurement-history", authorize(router.ResourceMeasurementHistory)...)
 		{
 			measurementHistoryEndpoints.POST("", handler.MeasurementHistoryHandler.SaveMeasurementHistory)
 			measurementHistoryEndpoints.GET(":id", handler.MeasurementHistoryHandler.Get)
+			measurementHistoryEndpoints.GET(":id/diff", handler.MeasurementHistoryHandler.Diff)
 			measurementHistoryEndpoints.GET("", handler.MeasurementHistoryHandler.GetAllMeasurementHistories)
 			measurementHistoryEndpoints.GET("measurement/:measurementId", handler.MeasurementHistoryHandler.GetByMeasurementId)
 		}
 
-		enquiryHistoryEndpoints := appRouter.Group("enquiry-history", router.VerifyJWT(srvConfig.JwtSecretKey))
+		enquiryHistoryEndpoints := appRouter.Group("enquiry-history", authorize(router.ResourceEnquiryHistory)...)
 		{
 			enquiryHistoryEndpoints.POST("", handler.EnquiryHistoryHandler.SaveEnquiryHistory)
 			enquiryHistoryEndpoints.GET(":id", handler.EnquiryHistoryHandler.Get)
@@ -214,7 +333,7 @@ func InitRouter(handler baseHandler.BaseHandler, srvConfig config.ServerConfig)
 			enquiryHistoryEndpoints.GET("enquiry/:enquiryId", handler.EnquiryHistoryHandler.GetByEnquiryId)
 		}
 
-		expenseTrackerEndpoints := appRouter.Group("expense-tracker", router.VerifyJWT(srvConfig.JwtSecretKey))
+		expenseTrackerEndpoints := appRouter.Group("expense-tracker", authorize(router.ResourceExpense)...)
 		{
 			expenseTrackerEndpoints.POST("", handler.ExpenseTrackerHandler.SaveExpenseTracker)
 			expenseTrackerEndpoints.PUT(":id", handler.ExpenseTrackerHandler.UpdateExpenseTracker)
@@ -223,7 +342,35 @@ func InitRouter(handler baseHandler.BaseHandler, srvConfig config.ServerConfig)
 			expenseTrackerEndpoints.DELETE(":id", handler.ExpenseTrackerHandler.Delete)
 		}
 
-		taskEndpoints := appRouter.Group("task", router.VerifyJWT(srvConfig.JwtSecretKey))
+		paymentEndpoints := appRouter.Group("payment", authorize(router.ResourcePayment)...)
+		{
+			paymentEndpoints.POST("", handler.PaymentHandler.SavePayment)
+			paymentEndpoints.PUT(":id", handler.PaymentHandler.UpdatePayment)
+			paymentEndpoints.GET(":id", handler.PaymentHandler.Get)
+			paymentEndpoints.GET("", handler.PaymentHandler.GetAllPayments)
+			paymentEndpoints.GET("order/:orderId", handler.PaymentHandler.GetByOrderId)
+			paymentEndpoints.DELETE(":id", handler.PaymentHandler.Delete)
+		}
+
+		attachmentEndpoints := appRouter.Group("attachment", authorize(router.ResourceAttachment)...)
+		{
+			attachmentEndpoints.POST("", handler.AttachmentHandler.Upload)
+			attachmentEndpoints.GET("entity/:entityType/:entityId", handler.AttachmentHandler.GetByEntity)
+			attachmentEndpoints.GET(":id/download", handler.AttachmentHandler.GetDownloadURL)
+			attachmentEndpoints.DELETE(":id", handler.AttachmentHandler.Delete)
+		}
+
+		dashboardEndpoints := appRouter.Group("dashboard", authorize(router.ResourceDashboard)...)
+		{
+			dashboardEndpoints.GET("", handler.DashboardHandler.GetDashboardStats)
+		}
+
+		auditEndpoints := appRouter.Group("audit", authorize(router.ResourceAudit)...)
+		{
+			auditEndpoints.GET("", handler.AuditLogHandler.GetAll)
+		}
+
+		taskEndpoints := appRouter.Group("task", authorize(router.ResourceTask)...)
 		{
 			taskEndpoints.POST("", handler.TaskHandler.SaveTask)
 			taskEndpoints.PUT(":id", handler.TaskHandler.UpdateTask)
@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/imkarthi24/sf-backend/internal/config"
+	"github.com/imkarthi24/sf-backend/internal/constants"
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/mapper"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	responseModel "github.com/imkarthi24/sf-backend/internal/model/response"
+	"github.com/imkarthi24/sf-backend/internal/repository"
+	"github.com/imkarthi24/sf-backend/internal/utils"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/loop-kar/pixie/util"
+)
+
+// signed requests older or newer than this are rejected, so that a captured request cannot be replayed later
+const apiKeySignatureMaxSkew = 5 * time.Minute
+
+type ApiKeyService interface {
+	Issue(*context.Context, requestModel.ApiKey) (*responseModel.ApiKeySecret, *errs.XError)
+	Rotate(*context.Context, uint) (*responseModel.ApiKeySecret, *errs.XError)
+	Revoke(*context.Context, uint) *errs.XError
+	Get(*context.Context, uint) (*responseModel.ApiKey, *errs.XError)
+	GetAll(*context.Context) ([]responseModel.ApiKey, *errs.XError)
+	VerifySignature(*context.Context, requestModel.SignedRequest) (*entities.ApiKey, *errs.XError)
+}
+
+type apiKeyService struct {
+	apiKeyRepo repository.ApiKeyRepository
+	config     config.AppConfig
+	mapper     mapper.Mapper
+	respMapper mapper.ResponseMapper
+}
+
+func ProvideApiKeyService(repo repository.ApiKeyRepository, config config.AppConfig, mapper mapper.Mapper, respMapper mapper.ResponseMapper) ApiKeyService {
+	return apiKeyService{
+		apiKeyRepo: repo,
+		config:     config,
+		mapper:     mapper,
+		respMapper: respMapper,
+	}
+}
+
+func (svc apiKeyService) Issue(ctx *context.Context, apiKey requestModel.ApiKey) (*responseModel.ApiKeySecret, *errs.XError) {
+	dbApiKey, err := svc.mapper.ApiKey(apiKey)
+	if err != nil {
+		return nil, errs.NewXError(errs.INVALID_REQUEST, "Unable to save api key", err)
+	}
+
+	errr := validateApiKey(dbApiKey)
+	if errr != nil {
+		return nil, errr
+	}
+
+	dbApiKey.KeyId, err = utils.GenerateApiKey(constants.API_KEY_ID_PREFIX)
+	if err != nil {
+		return nil, errs.NewXError(errs.INTERNAL, "Unable to generate api key", err)
+	}
+
+	secret, errr := svc.newSecret(dbApiKey)
+	if errr != nil {
+		return nil, errr
+	}
+
+	errr = svc.apiKeyRepo.Create(ctx, dbApiKey)
+	if errr != nil {
+		return nil, errr
+	}
+
+	return svc.apiKeySecret(dbApiKey, secret)
+}
+
+// Rotate replaces the secret of the key, the requests signed with the previous secret are rejected
+func (svc apiKeyService) Rotate(ctx *context.Context, id uint) (*responseModel.ApiKeySecret, *errs.XError) {
+	apiKey, errr := svc.getUsableKey(ctx, id)
+	if errr != nil {
+		return nil, errr
+	}
+
+	secret, errr := svc.newSecret(apiKey)
+	if errr != nil {
+		return nil, errr
+	}
+
+	errr = svc.apiKeyRepo.UpdateSecret(ctx, id, apiKey.Salt, apiKey.SecretHash)
+	if errr != nil {
+		return nil, errr
+	}
+
+	return svc.apiKeySecret(apiKey, secret)
+}
+
+func (svc apiKeyService) Revoke(ctx *context.Context, id uint) *errs.XError {
+	apiKey, errr := svc.apiKeyRepo.Get(ctx, id)
+	if errr != nil {
+		return errr
+	}
+	if apiKey.Model == nil {
+		return errs.NewXError(errs.NOT_EXIST, "Api key not found", nil)
+	}
+	if apiKey.RevokedAt != nil {
+		return errs.NewXError(errs.VALIDATION, "Api key is already revoked", nil)
+	}
+
+	return svc.apiKeyRepo.Revoke(ctx, id, util.GetLocalTime())
+}
+
+func (svc apiKeyService) Get(ctx *context.Context, id uint) (*responseModel.ApiKey, *errs.XError) {
+	apiKey, errr := svc.apiKeyRepo.Get(ctx, id)
+	if errr != nil {
+		return nil, errr
+	}
+	if apiKey.Model == nil {
+		return nil, errs.NewXError(errs.NOT_EXIST, "Api key not found", nil)
+	}
+
+	mapped, err := svc.respMapper.ApiKey(apiKey)
+	if err != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map ApiKey data", err)
+	}
+	return mapped, nil
+}
+
+func (svc apiKeyService) GetAll(ctx *context.Context) ([]responseModel.ApiKey, *errs.XError) {
+	apiKeys, errr := svc.apiKeyRepo.GetAll(ctx)
+	if errr != nil {
+		return nil, errr
+	}
+
+	mapped, err := svc.respMapper.ApiKeys(apiKeys)
+	if err != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map ApiKey data", err)
+	}
+	return mapped, nil
+}
+
+// VerifySignature returns the key of a request whose signature matches the secret of the key.
+// The key has to be active, of an active channel and the timestamp of the request within the allowed skew
+func (svc apiKeyService) VerifySignature(ctx *context.Context, request requestModel.SignedRequest) (*entities.ApiKey, *errs.XError) {
+	unauthorized := func(message string) *errs.XError {
+		return errs.NewXError(errs.INSUFFICIENT_ACCESS, message, nil).SetCode(http.StatusUnauthorized)
+	}
+
+	if request.KeyId == "" || request.Timestamp == "" || request.Signature == "" {
+		return nil, unauthorized("missing api key signature headers")
+	}
+
+	unixSeconds, err := strconv.ParseInt(request.Timestamp, 10, 64)
+	if err != nil {
+		return nil, unauthorized("invalid signature timestamp")
+	}
+	now := time.Now()
+	signedAt := time.Unix(unixSeconds, 0)
+	if signedAt.Before(now.Add(-apiKeySignatureMaxSkew)) || signedAt.After(now.Add(apiKeySignatureMaxSkew)) {
+		return nil, unauthorized("signature timestamp is outside the allowed window")
+	}
+
+	apiKey, errr := svc.apiKeyRepo.GetByKeyId(ctx, request.KeyId)
+	if errr != nil {
+		return nil, errr
+	}
+	if apiKey.Model == nil {
+		return nil, unauthorized("invalid api key")
+	}
+	if status := apiKey.Status(now); status != entities.ApiKeyStatusActive {
+		return nil, unauthorized(fmt.Sprintf("api key is %s", strings.ToLower(string(status))))
+	}
+	if apiKey.Channel == nil || apiKey.Channel.Model == nil || !apiKey.Channel.IsActive || string(apiKey.Channel.Status) != string(entities.CHANNEL_ACTIVE) {
+		return nil, unauthorized("channel of the api key is not active")
+	}
+
+	secret := utils.DeriveApiSecret(svc.config.Server.SecretKey, apiKey.KeyId, apiKey.Salt)
+	if utils.HashApiKey(secret) != apiKey.SecretHash {
+		// the server secret key has changed since the key was issued, the key has to be rotated
+		return nil, unauthorized("api key has to be rotated")
+	}
+
+	expected := utils.SignRequest(secret, request.Timestamp, request.Method, request.RequestURI, request.Body)
+	if !hmac.Equal([]byte(expected), []byte(strings.ToLower(request.Signature))) {
+		return nil, unauthorized("invalid signature")
+	}
+
+	errr = svc.apiKeyRepo.SetLastUsed(ctx, apiKey.ID, util.GetLocalTime())
+	if errr != nil {
+		return nil, errr
+	}
+
+	return apiKey, nil
+}
+
+func (svc apiKeyService) getUsableKey(ctx *context.Context, id uint) (*entities.ApiKey, *errs.XError) {
+	apiKey, errr := svc.apiKeyRepo.Get(ctx, id)
+	if errr != nil {
+		return nil, errr
+	}
+	if apiKey.Model == nil {
+		return nil, errs.NewXError(errs.NOT_EXIST, "Api key not found", nil)
+	}
+	if status := apiKey.Status(time.Now()); status != entities.ApiKeyStatusActive {
+		return nil, errs.NewXError(errs.VALIDATION, fmt.Sprintf("Api key is %s, issue a new key instead", strings.ToLower(string(status))), nil)
+	}
+	return apiKey, nil
+}
+
+// newSecret sets a new salt and secret hash on the key and returns the secret to be shown once
+func (svc apiKeyService) newSecret(apiKey *entities.ApiKey) (string, *errs.XError) {
+	salt, err := utils.GenerateApiKey("")
+	if err != nil {
+		return "", errs.NewXError(errs.INTERNAL, "Unable to generate api key secret", err)
+	}
+
+	secret := utils.DeriveApiSecret(svc.config.Server.SecretKey, apiKey.KeyId, salt)
+	apiKey.Salt = salt
+	apiKey.SecretHash = utils.HashApiKey(secret)
+	return secret, nil
+}
+
+func (svc apiKeyService) apiKeySecret(apiKey *entities.ApiKey, secret string) (*responseModel.ApiKeySecret, *errs.XError) {
+	mapped, err := svc.respMapper.ApiKey(apiKey)
+	if err != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map ApiKey data", err)
+	}
+	return &responseModel.ApiKeySecret{ApiKey: *mapped, Secret: secret}, nil
+}
+
+func validateApiKey(apiKey *entities.ApiKey) *errs.XError {
+	if apiKey.Name == "" {
+		return errs.NewXError(errs.VALIDATION, "Name is required", nil)
+	}
+	if apiKey.Scopes == "" {
+		return errs.NewXError(errs.VALIDATION, "At least one scope is required", nil)
+	}
+	for _, scope := range strings.Split(apiKey.Scopes, ",") {
+		resource, action, found := strings.Cut(scope, ":")
+		if !found || resource == "" || action == "" {
+			return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Invalid scope %s, expected resource:action", scope), nil)
+		}
+	}
+	if apiKey.ExpiresAt != nil && !apiKey.ExpiresAt.After(time.Now()) {
+		return errs.NewXError(errs.VALIDATION, "Expiry should be in the future", nil)
+	}
+	return nil
+}
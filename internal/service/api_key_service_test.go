@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/imkarthi24/sf-backend/internal/config"
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/mapper"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	"github.com/imkarthi24/sf-backend/internal/repository"
+	"github.com/imkarthi24/sf-backend/internal/utils"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/stretchr/testify/require"
+)
+
+// apiKeyRepositoryStub keeps the keys in memory, the keys belong to an active channel
+type apiKeyRepositoryStub struct {
+	repository.ApiKeyRepository
+	keys map[uint]*entities.ApiKey
+}
+
+func (r *apiKeyRepositoryStub) Create(ctx *context.Context, apiKey *entities.ApiKey) *errs.XError {
+	apiKey.ID = uint(len(r.keys) + 1)
+	apiKey.ChannelId = 1
+	r.keys[apiKey.ID] = apiKey
+	return nil
+}
+
+func (r *apiKeyRepositoryStub) Get(ctx *context.Context, id uint) (*entities.ApiKey, *errs.XError) {
+	if apiKey, ok := r.keys[id]; ok {
+		copied := *apiKey
+		return &copied, nil
+	}
+	return &entities.ApiKey{}, nil
+}
+
+func (r *apiKeyRepositoryStub) GetByKeyId(ctx *context.Context, keyId string) (*entities.ApiKey, *errs.XError) {
+	for _, apiKey := range r.keys {
+		if apiKey.KeyId == keyId {
+			copied := *apiKey
+			copied.Channel = &entities.Channel{Model: &entities.Model{ID: 1, IsActive: true}, Name: "Main", Status: entities.ChannelStatus(entities.CHANNEL_ACTIVE)}
+			return &copied, nil
+		}
+	}
+	return &entities.ApiKey{}, nil
+}
+
+func (r *apiKeyRepositoryStub) UpdateSecret(ctx *context.Context, id uint, salt string, secretHash string) *errs.XError {
+	r.keys[id].Salt = salt
+	r.keys[id].SecretHash = secretHash
+	return nil
+}
+
+func (r *apiKeyRepositoryStub) SetLastUsed(ctx *context.Context, id uint, usedAt time.Time) *errs.XError {
+	r.keys[id].LastUsedAt = &usedAt
+	return nil
+}
+
+func (r *apiKeyRepositoryStub) Revoke(ctx *context.Context, id uint, revokedAt time.Time) *errs.XError {
+	r.keys[id].RevokedAt = &revokedAt
+	r.keys[id].IsActive = false
+	return nil
+}
+
+func newApiKeyService(serverSecretKey string, repo *apiKeyRepositoryStub) ApiKeyService {
+	appConfig := config.AppConfig{Server: config.ServerConfig{SecretKey: serverSecretKey}}
+	return ProvideApiKeyService(repo, appConfig, mapper.ProvideMapper(), mapper.ProvideResponseMapper())
+}
+
+func issueApiKey(t *testing.T, svc ApiKeyService, name string) (uint, string, string) {
+	ctx := context.Background()
+	issued, errr := svc.Issue(&ctx, requestModel.ApiKey{Name: name, Scopes: []string{"enquiry:create"}})
+	require.Nil(t, errr)
+	return issued.ID, issued.KeyId, issued.Secret
+}
+
+func signedRequest(keyId string, secret string, signedAt time.Time, method string, requestURI string, body string) requestModel.SignedRequest {
+	timestamp := strconv.FormatInt(signedAt.Unix(), 10)
+	return requestModel.SignedRequest{
+		KeyId:      keyId,
+		Timestamp:  timestamp,
+		Signature:  utils.SignRequest(secret, timestamp, method, requestURI, []byte(body)),
+		Method:     method,
+		RequestURI: requestURI,
+		Body:       []byte(body),
+	}
+}
+
+func Test_VerifySignature(t *testing.T) {
+	ctx := context.Background()
+	repo := &apiKeyRepositoryStub{keys: make(map[uint]*entities.ApiKey)}
+	svc := newApiKeyService("server-secret", repo)
+
+	_, keyId, secret := issueApiKey(t, svc, "website")
+
+	revokedId, revokedKeyId, revokedSecret := issueApiKey(t, svc, "revoked")
+	require.Nil(t, svc.Revoke(&ctx, revokedId))
+
+	expiredId, expiredKeyId, expiredSecret := issueApiKey(t, svc, "expired")
+	expiredAt := time.Now().Add(-time.Minute)
+	repo.keys[expiredId].ExpiresAt = &expiredAt
+
+	rotatedId, rotatedKeyId, previousSecret := issueApiKey(t, svc, "rotated")
+	rotated, errr := svc.Rotate(&ctx, rotatedId)
+	require.Nil(t, errr)
+	require.NotEqual(t, previousSecret, rotated.Secret)
+
+	now := time.Now()
+	body := `{"phoneNumber":"9876543210"}`
+	valid := signedRequest(keyId, secret, now, http.MethodPost, "/external/enquiry", body)
+
+	tests := []struct {
+		name    string
+		request requestModel.SignedRequest
+		valid   bool
+	}{
+		{"valid signature", valid, true},
+		{"signature in upper case", func() requestModel.SignedRequest {
+			r := valid
+			r.Signature = strings.ToUpper(r.Signature)
+			return r
+		}(), true},
+		{"tampered body", func() requestModel.SignedRequest {
+			r := valid
+			r.Body = []byte(`{"phoneNumber":"9999999999"}`)
+			return r
+		}(), false},
+		{"tampered method", func() requestModel.SignedRequest {
+			r := valid
+			r.Method = http.MethodPut
+			return r
+		}(), false},
+		{"tampered uri", func() requestModel.SignedRequest {
+			r := valid
+			r.RequestURI = "/external/enquiry?source=instagram"
+			return r
+		}(), false},
+		{"tampered timestamp", func() requestModel.SignedRequest {
+			r := valid
+			r.Timestamp = strconv.FormatInt(now.Add(time.Minute).Unix(), 10)
+			return r
+		}(), false},
+		{"within the allowed skew", signedRequest(keyId, secret, now.Add(-4*time.Minute), http.MethodPost, "/external/enquiry", body), true},
+		{"timestamp older than 5 minutes", signedRequest(keyId, secret, now.Add(-6*time.Minute), http.MethodPost, "/external/enquiry", body), false},
+		{"timestamp ahead by more than 5 minutes", signedRequest(keyId, secret, now.Add(6*time.Minute), http.MethodPost, "/external/enquiry", body), false},
+		{"invalid timestamp", func() requestModel.SignedRequest {
+			r := valid
+			r.Timestamp = "yesterday"
+			return r
+		}(), false},
+		{"missing signature", func() requestModel.SignedRequest {
+			r := valid
+			r.Signature = ""
+			return r
+		}(), false},
+		{"unknown key", signedRequest("sfk_unknown", secret, now, http.MethodPost, "/external/enquiry", body), false},
+		{"signed with the secret of another key", signedRequest(keyId, rotated.Secret, now, http.MethodPost, "/external/enquiry", body), false},
+		{"revoked key", signedRequest(revokedKeyId, revokedSecret, now, http.MethodPost, "/external/enquiry", body), false},
+		{"expired key", signedRequest(expiredKeyId, expiredSecret, now, http.MethodPost, "/external/enquiry", body), false},
+		{"secret before the rotation", signedRequest(rotatedKeyId, previousSecret, now, http.MethodPost, "/external/enquiry", body), false},
+		{"secret after the rotation", signedRequest(rotatedKeyId, rotated.Secret, now, http.MethodPost, "/external/enquiry", body), true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			apiKey, errr := svc.VerifySignature(&ctx, test.request)
+			if test.valid {
+				require.Nil(t, errr)
+				require.Equal(t, test.request.KeyId, apiKey.KeyId)
+			} else {
+				require.NotNil(t, errr)
+				require.Nil(t, apiKey)
+			}
+		})
+	}
+}
+
+// The secret is derived from the server secret key, a key issued before the server secret key changed cannot sign
+func Test_VerifySignature_ServerSecretKeyChanged(t *testing.T) {
+	ctx := context.Background()
+	repo := &apiKeyRepositoryStub{keys: make(map[uint]*entities.ApiKey)}
+
+	_, keyId, secret := issueApiKey(t, newApiKeyService("server-secret", repo), "website")
+
+	apiKey, errr := newApiKeyService("new-server-secret", repo).VerifySignature(&ctx, signedRequest(keyId, secret, time.Now(), http.MethodPost, "/external/enquiry", ""))
+	require.NotNil(t, errr)
+	require.Nil(t, apiKey)
+}
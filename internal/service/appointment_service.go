@@ -0,0 +1,370 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/imkarthi24/sf-backend/internal/constants"
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/mapper"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	responseModel "github.com/imkarthi24/sf-backend/internal/model/response"
+	"github.com/imkarthi24/sf-backend/internal/repository"
+	"github.com/imkarthi24/sf-backend/internal/utils"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/loop-kar/pixie/util"
+)
+
+const (
+	AppointmentViewDay  = "day"
+	AppointmentViewWeek = "week"
+
+	appointmentDateLayout = "2006-01-02"
+
+	// reminders are queued at most a week before the appointment
+	defaultAppointmentReminderHours = 24
+	maxAppointmentReminderHours     = 7 * 24
+)
+
+type AppointmentService interface {
+	SaveAppointment(*context.Context, requestModel.Appointment) *errs.XError
+	UpdateAppointment(*context.Context, requestModel.Appointment, uint) *errs.XError
+	UpdateAppointmentStatus(*context.Context, requestModel.Status, uint) *errs.XError
+	Get(*context.Context, uint) (*responseModel.Appointment, *errs.XError)
+	GetCalendar(*context.Context, *time.Time, string, *uint) (*responseModel.AppointmentCalendar, *errs.XError)
+	Delete(*context.Context, uint) *errs.XError
+	QueueReminders(*context.Context) *errs.XError
+}
+
+type appointmentService struct {
+	appointmentRepo repository.AppointmentRepository
+	customerRepo    repository.CustomerRepository
+	orderRepo       repository.OrderRepository
+	userRepo        repository.UserRepository
+	channelRepo     repository.ChannelRepository
+	masterConfigSvc MasterConfigService
+	notificationSvc NotificationService
+	mapper          mapper.Mapper
+	respMapper      mapper.ResponseMapper
+}
+
+func ProvideAppointmentService(repo repository.AppointmentRepository, customerRepo repository.CustomerRepository, orderRepo repository.OrderRepository, userRepo repository.UserRepository, channelRepo repository.ChannelRepository, masterConfigSvc MasterConfigService, notificationSvc NotificationService, mapper mapper.Mapper, respMapper mapper.ResponseMapper) AppointmentService {
+	return appointmentService{
+		appointmentRepo: repo,
+		customerRepo:    customerRepo,
+		orderRepo:       orderRepo,
+		userRepo:        userRepo,
+		channelRepo:     channelRepo,
+		masterConfigSvc: masterConfigSvc,
+		notificationSvc: notificationSvc,
+		mapper:          mapper,
+		respMapper:      respMapper,
+	}
+}
+
+// Roles that appointments can be booked with
+var appointmentStaffRoles = map[entities.RoleType]bool{
+	entities.SUPERADMIN: true,
+	entities.ADMIN:      true,
+	entities.STAFF:      true,
+}
+
+func (svc appointmentService) SaveAppointment(ctx *context.Context, appointment requestModel.Appointment) *errs.XError {
+	dbAppointment, err := svc.mapper.Appointment(appointment)
+	if err != nil {
+		return errs.NewXError(errs.INVALID_REQUEST, "Unable to save appointment", err)
+	}
+
+	if dbAppointment.Status == "" {
+		dbAppointment.Status = entities.AppointmentStatusScheduled
+	}
+	if _, ok := entities.AppointmentStatusTransitions[dbAppointment.Status]; !ok {
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Invalid appointment status %s", dbAppointment.Status), nil)
+	}
+
+	errr := svc.validateAppointment(ctx, dbAppointment)
+	if errr != nil {
+		return errr
+	}
+
+	return svc.appointmentRepo.Create(ctx, dbAppointment)
+}
+
+func (svc appointmentService) UpdateAppointment(ctx *context.Context, appointment requestModel.Appointment, id uint) *errs.XError {
+	oldAppointment, err := svc.appointmentRepo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if oldAppointment.Model == nil {
+		return errs.NewXError(errs.NOT_EXIST, "Appointment not found", nil)
+	}
+
+	dbAppointment, mapErr := svc.mapper.Appointment(appointment)
+	if mapErr != nil {
+		return errs.NewXError(errs.INVALID_REQUEST, "Unable to update appointment", mapErr)
+	}
+
+	// Use the status endpoint to change the status, a rescheduled appointment is reminded again
+	dbAppointment.ID = id
+	dbAppointment.Status = oldAppointment.Status
+	dbAppointment.ReminderQueuedAt = oldAppointment.ReminderQueuedAt
+	if !dbAppointment.StartTime.Equal(oldAppointment.StartTime) {
+		dbAppointment.ReminderQueuedAt = nil
+	}
+
+	err = svc.validateAppointment(ctx, dbAppointment)
+	if err != nil {
+		return err
+	}
+
+	return svc.appointmentRepo.Update(ctx, dbAppointment)
+}
+
+func (svc appointmentService) UpdateAppointmentStatus(ctx *context.Context, status requestModel.Status, id uint) *errs.XError {
+	appointment, err := svc.appointmentRepo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if appointment.Model == nil {
+		return errs.NewXError(errs.NOT_EXIST, "Appointment not found", nil)
+	}
+
+	newStatus := entities.AppointmentStatus(status.Status)
+	if appointment.Status == newStatus {
+		return nil
+	}
+
+	if _, ok := entities.AppointmentStatusTransitions[newStatus]; !ok {
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Invalid appointment status %s", newStatus), nil)
+	}
+	allowed := false
+	for _, target := range entities.AppointmentStatusTransitions[appointment.Status] {
+		if target == newStatus {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Appointment status cannot be changed from %s to %s", appointment.Status, newStatus), nil)
+	}
+
+	// The reason of the change (eg: why the appointment is cancelled) is kept as the notes
+	notes := appointment.Notes
+	if !util.IsNilOrEmptyString(&status.StatusReason) {
+		notes = status.StatusReason
+	}
+
+	return svc.appointmentRepo.UpdateStatus(ctx, id, newStatus, notes)
+}
+
+func (svc appointmentService) Get(ctx *context.Context, id uint) (*responseModel.Appointment, *errs.XError) {
+	appointment, err := svc.appointmentRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if appointment.Model == nil {
+		return nil, errs.NewXError(errs.NOT_EXIST, "Appointment not found", nil)
+	}
+
+	mappedAppointment, mapErr := svc.respMapper.Appointment(appointment)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map Appointment data", mapErr)
+	}
+
+	return mappedAppointment, nil
+}
+
+// GetCalendar returns the appointments of the day or the week (Monday to Sunday) of the date, optionally of a staff.
+// The date defaults to today.
+func (svc appointmentService) GetCalendar(ctx *context.Context, date *time.Time, view string, staffId *uint) (*responseModel.AppointmentCalendar, *errs.XError) {
+	now := util.GetLocalTime()
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if date != nil {
+		day = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, now.Location())
+	}
+
+	if view == "" {
+		view = AppointmentViewDay
+	}
+	days := 1
+	switch view {
+	case AppointmentViewDay:
+	case AppointmentViewWeek:
+		day = day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+		days = 7
+	default:
+		return nil, errs.NewXError(errs.INVALID_REQUEST, fmt.Sprintf("Invalid calendar view %s, expected day or week", view), nil)
+	}
+	rangeEnd := day.AddDate(0, 0, days)
+
+	appointments, err := svc.appointmentRepo.GetInRange(ctx, day, rangeEnd, staffId)
+	if err != nil {
+		return nil, err
+	}
+
+	mappedAppointments, mapErr := svc.respMapper.Appointments(appointments)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map Appointment data", mapErr)
+	}
+
+	calendar := &responseModel.AppointmentCalendar{
+		View: view,
+		From: day.Format(appointmentDateLayout),
+		To:   rangeEnd.AddDate(0, 0, -1).Format(appointmentDateLayout),
+		Days: make([]responseModel.AppointmentCalendarDay, days),
+	}
+	dayIndex := make(map[string]int)
+	for i := range calendar.Days {
+		date := day.AddDate(0, 0, i).Format(appointmentDateLayout)
+		dayIndex[date] = i
+		calendar.Days[i] = responseModel.AppointmentCalendarDay{
+			Date:         date,
+			Appointments: make([]responseModel.Appointment, 0),
+		}
+	}
+	for _, appointment := range mappedAppointments {
+		index, ok := dayIndex[appointment.StartTime.In(now.Location()).Format(appointmentDateLayout)]
+		if !ok {
+			continue
+		}
+		calendar.Days[index].Appointments = append(calendar.Days[index].Appointments, appointment)
+	}
+
+	return calendar, nil
+}
+
+func (svc appointmentService) Delete(ctx *context.Context, id uint) *errs.XError {
+	return svc.appointmentRepo.Delete(ctx, id)
+}
+
+// QueueReminders queues the reminders of the scheduled appointments starting within the reminder hours of their channel
+// (Appointment.ReminderHours master config, 24 hours by default). Every appointment is reminded once per slot.
+func (svc appointmentService) QueueReminders(ctx *context.Context) *errs.XError {
+	now := util.GetLocalTime()
+
+	appointments, err := svc.appointmentRepo.GetUpcomingForReminder(ctx, now, now.Add(maxAppointmentReminderHours*time.Hour))
+	if err != nil {
+		return err
+	}
+
+	// the reminders are queued on behalf of the owner of the channel
+	owners := make(map[uint]uint)
+	reminderHours := make(map[uint]int)
+	var lastErr *errs.XError
+	for _, appointment := range appointments {
+		ownerId, ok := owners[appointment.ChannelId]
+		if !ok {
+			channel, err := svc.channelRepo.Get(ctx, appointment.ChannelId)
+			if err != nil {
+				lastErr = err
+				continue
+			}
+			if channel.Model != nil {
+				ownerId = channel.OwnerUserID
+			}
+			owners[appointment.ChannelId] = ownerId
+		}
+		if ownerId == 0 {
+			continue
+		}
+
+		channelCtx := utils.NewSystemContext(appointment.ChannelId, ownerId)
+		hours, ok := reminderHours[appointment.ChannelId]
+		if !ok {
+			hours = svc.getReminderHours(&channelCtx)
+			reminderHours[appointment.ChannelId] = hours
+		}
+		if appointment.StartTime.After(now.Add(time.Duration(hours) * time.Hour)) {
+			continue
+		}
+
+		err = svc.notificationSvc.NotifyAppointmentReminder(&channelCtx, appointment)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		err = svc.appointmentRepo.SetReminderQueued(&channelCtx, appointment.ID, now)
+		if err != nil {
+			lastErr = err
+		}
+	}
+
+	return lastErr
+}
+
+func (svc appointmentService) getReminderHours(ctx *context.Context) int {
+	value, err := svc.masterConfigSvc.GetByName(ctx, constants.CONFIG_APPOINTMENT_REMINDER_HOURS)
+	if err != nil || util.IsNilOrEmptyString(&value) {
+		return defaultAppointmentReminderHours
+	}
+
+	hours, convErr := strconv.Atoi(value)
+	if convErr != nil || hours <= 0 {
+		return defaultAppointmentReminderHours
+	}
+	return min(hours, maxAppointmentReminderHours)
+}
+
+func (svc appointmentService) validateAppointment(ctx *context.Context, appointment *entities.Appointment) *errs.XError {
+	if !entities.AppointmentTypes[appointment.Type] {
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Invalid appointment type %s", appointment.Type), nil)
+	}
+	if !appointment.EndTime.After(appointment.StartTime) {
+		return errs.NewXError(errs.VALIDATION, "End time should be after the start time", nil)
+	}
+
+	customer, err := svc.customerRepo.Get(ctx, appointment.CustomerId)
+	if err != nil {
+		return err
+	}
+	if customer.Model == nil || !customer.IsActive {
+		return errs.NewXError(errs.NOT_EXIST, "Customer not found", nil)
+	}
+
+	if appointment.OrderId != nil {
+		order, err := svc.orderRepo.Get(ctx, *appointment.OrderId)
+		if err != nil {
+			return err
+		}
+		if order.Model == nil || !order.IsActive {
+			return errs.NewXError(errs.NOT_EXIST, "Order not found", nil)
+		}
+		if order.CustomerId == nil || *order.CustomerId != appointment.CustomerId {
+			return errs.NewXError(errs.VALIDATION, "Order does not belong to the customer", nil)
+		}
+	}
+
+	staff, err := svc.userRepo.Get(ctx, appointment.StaffId)
+	if err != nil {
+		return err
+	}
+	if staff.Model == nil || !staff.IsActive {
+		return errs.NewXError(errs.NOT_EXIST, "Staff not found", nil)
+	}
+	if !appointmentStaffRoles[staff.Role] {
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Appointments cannot be booked with a %s user", staff.Role), nil)
+	}
+
+	if !appointment.BlocksSlot() {
+		return nil
+	}
+
+	var appointmentId uint
+	if appointment.Model != nil {
+		appointmentId = appointment.ID
+	}
+	conflicts, err := svc.appointmentRepo.GetStaffConflicts(ctx, appointment.StaffId, appointment.StartTime, appointment.EndTime, appointmentId)
+	if err != nil {
+		return err
+	}
+	if len(conflicts) > 0 {
+		conflict := conflicts[0]
+		location := util.GetLocalTime().Location()
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("%s %s is already booked from %s to %s", staff.FirstName, staff.LastName,
+			conflict.StartTime.In(location).Format("02 Jan 2006 03:04 PM"), conflict.EndTime.In(location).Format("03:04 PM")), nil)
+	}
+
+	return nil
+}
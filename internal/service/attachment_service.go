@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/imkarthi24/sf-backend/internal/config"
+	"github.com/imkarthi24/sf-backend/internal/constants"
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/mapper"
+	"github.com/imkarthi24/sf-backend/internal/model/models"
+	responseModel "github.com/imkarthi24/sf-backend/internal/model/response"
+	"github.com/imkarthi24/sf-backend/internal/repository"
+	"github.com/imkarthi24/sf-backend/internal/utils"
+	"github.com/imkarthi24/sf-backend/internal/utils/storage"
+	"github.com/loop-kar/pixie/errs"
+)
+
+type AttachmentService interface {
+	Upload(*context.Context, models.FileUpload) (*responseModel.Attachment, *errs.XError)
+	GetByEntity(*context.Context, string, uint) ([]responseModel.Attachment, *errs.XError)
+	GetDownloadURL(*context.Context, uint) (*responseModel.FileResponse, *errs.XError)
+	DownloadFile(*context.Context, string, string, string) (io.ReadCloser, string, *errs.XError)
+	Delete(*context.Context, uint) *errs.XError
+}
+
+type attachmentService struct {
+	attachmentRepo repository.AttachmentRepository
+	storage        storage.Storage
+	storageConfig  config.StorageConfig
+	respMapper     mapper.ResponseMapper
+}
+
+func ProvideAttachmentService(repo repository.AttachmentRepository, storage storage.Storage, storageConfig config.StorageConfig, respMapper mapper.ResponseMapper) AttachmentService {
+	return attachmentService{
+		attachmentRepo: repo,
+		storage:        storage,
+		storageConfig:  storageConfig,
+		respMapper:     respMapper,
+	}
+}
+
+// Upload stores the file and records it against the entity
+func (svc attachmentService) Upload(ctx *context.Context, file models.FileUpload) (*responseModel.Attachment, *errs.XError) {
+	if !file.HasContent() {
+		return nil, errs.NewXError(errs.INVALID_REQUEST, "File is required", nil)
+	}
+	defer file.Content.Close()
+
+	entityType := entities.ToEntityName(file.EntityType)
+	err := svc.validateEntity(ctx, entityType, file.EntityId)
+	if err != nil {
+		return nil, err
+	}
+
+	if file.Metadata.Size > constants.ATTACHMENT_MAX_SIZE {
+		return nil, errs.NewXError(errs.VALIDATION, fmt.Sprintf("File size should not exceed %d MB", constants.ATTACHMENT_MAX_SIZE>>20), nil)
+	}
+
+	contentType := ""
+	if values := file.Metadata.Header["Content-Type"]; len(values) > 0 {
+		contentType = values[0]
+	}
+	if contentType == "" || contentType == "application/octet-stream" {
+		contentType = mime.TypeByExtension(strings.ToLower(filepath.Ext(file.Metadata.Filename)))
+	}
+	if !isAllowedContentType(contentType) {
+		return nil, errs.NewXError(errs.VALIDATION, "Only images and PDF files can be attached", nil)
+	}
+
+	key := fmt.Sprintf("channel-%d/%s/%d/%s%s", utils.GetChannelId(ctx), entityType, file.EntityId,
+		uuid.NewString(), strings.ToLower(filepath.Ext(file.Metadata.Filename)))
+
+	uploadErr := svc.storage.Upload(*ctx, key, file.Content, file.Metadata.Size, contentType)
+	if uploadErr != nil {
+		return nil, errs.NewXError(errs.STORAGE, "Unable to upload file", uploadErr)
+	}
+
+	userId := utils.GetUserId(ctx)
+	attachment := &entities.Attachment{
+		Model:        &entities.Model{IsActive: true},
+		EntityType:   entityType,
+		EntityId:     file.EntityId,
+		Kind:         file.Kind,
+		FileName:     filepath.Base(file.Metadata.Filename),
+		ContentType:  contentType,
+		Size:         file.Metadata.Size,
+		StorageKey:   key,
+		UploadedById: &userId,
+	}
+
+	err = svc.attachmentRepo.Create(ctx, attachment)
+	if err != nil {
+		// the file is of no use without the record
+		svc.storage.Delete(*ctx, key)
+		return nil, err
+	}
+
+	mappedAttachment, mapErr := svc.respMapper.Attachment(attachment)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map Attachment data", mapErr)
+	}
+
+	return mappedAttachment, nil
+}
+
+func (svc attachmentService) GetByEntity(ctx *context.Context, entityType string, entityId uint) ([]responseModel.Attachment, *errs.XError) {
+	attachments, err := svc.attachmentRepo.GetByEntity(ctx, entities.ToEntityName(entityType), entityId)
+	if err != nil {
+		return nil, err
+	}
+
+	mappedAttachments, mapErr := svc.respMapper.Attachments(attachments)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map Attachment data", mapErr)
+	}
+
+	return mappedAttachments, nil
+}
+
+// GetDownloadURL returns a short lived url to download the file
+func (svc attachmentService) GetDownloadURL(ctx *context.Context, id uint) (*responseModel.FileResponse, *errs.XError) {
+	attachment, err := svc.attachmentRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if attachment.Model == nil {
+		return nil, errs.NewXError(errs.NOT_EXIST, "Attachment not found", nil)
+	}
+
+	expiry := time.Duration(svc.storageConfig.URLExpiryMinutes) * time.Minute
+	url, urlErr := svc.storage.PresignedURL(*ctx, attachment.StorageKey, attachment.FileName, expiry)
+	if urlErr != nil {
+		return nil, errs.NewXError(errs.STORAGE, "Unable to generate download url", urlErr)
+	}
+
+	return &responseModel.FileResponse{
+		FileUrl:  url,
+		FileName: attachment.FileName,
+	}, nil
+}
+
+// DownloadFile serves the signed download urls of the local storage and returns the content along with the content type
+func (svc attachmentService) DownloadFile(ctx *context.Context, key string, expires string, signature string) (io.ReadCloser, string, *errs.XError) {
+	localStorage, ok := svc.storage.(*storage.LocalStorage)
+	if !ok {
+		return nil, "", errs.NewXError(errs.INVALID_REQUEST, "Files are not served by the application", nil)
+	}
+
+	err := localStorage.VerifySignature(key, expires, signature)
+	if err != nil {
+		return nil, "", errs.NewXError(errs.INSUFFICIENT_ACCESS, "Download link is invalid or has expired", err)
+	}
+
+	content, err := localStorage.Download(*ctx, key)
+	if err != nil {
+		return nil, "", errs.NewXError(errs.NOT_EXIST, "File not found", err)
+	}
+
+	return content, mime.TypeByExtension(filepath.Ext(key)), nil
+}
+
+func (svc attachmentService) Delete(ctx *context.Context, id uint) *errs.XError {
+	attachment, err := svc.attachmentRepo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if attachment.Model == nil {
+		return errs.NewXError(errs.NOT_EXIST, "Attachment not found", nil)
+	}
+
+	err = svc.attachmentRepo.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	deleteErr := svc.storage.Delete(*ctx, attachment.StorageKey)
+	if deleteErr != nil {
+		return errs.NewXError(errs.STORAGE, "Unable to delete file", deleteErr)
+	}
+
+	return nil
+}
+
+func (svc attachmentService) validateEntity(ctx *context.Context, entityType entities.EntityName, entityId uint) *errs.XError {
+	if !entities.AttachableEntities[entityType] {
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Files cannot be attached to %s", entityType), nil)
+	}
+
+	exists, err := svc.attachmentRepo.EntityExists(ctx, entityType, entityId)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errs.NewXError(errs.NOT_EXIST, fmt.Sprintf("%s not found", entityType), nil)
+	}
+
+	return nil
+}
+
+func isAllowedContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return strings.HasPrefix(mediaType, "image/") || mediaType == "application/pdf"
+}
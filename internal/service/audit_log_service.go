@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+
+	"github.com/imkarthi24/sf-backend/internal/mapper"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	responseModel "github.com/imkarthi24/sf-backend/internal/model/response"
+	"github.com/imkarthi24/sf-backend/internal/repository"
+	"github.com/loop-kar/pixie/errs"
+)
+
+type AuditLogService interface {
+	GetAll(*context.Context, requestModel.AuditLogFilter) ([]responseModel.AuditLog, *errs.XError)
+}
+
+type auditLogService struct {
+	auditLogRepo repository.AuditLogRepository
+	respMapper   mapper.ResponseMapper
+}
+
+func ProvideAuditLogService(repo repository.AuditLogRepository, respMapper mapper.ResponseMapper) AuditLogService {
+	return auditLogService{
+		auditLogRepo: repo,
+		respMapper:   respMapper,
+	}
+}
+
+func (svc auditLogService) GetAll(ctx *context.Context, filter requestModel.AuditLogFilter) ([]responseModel.AuditLog, *errs.XError) {
+	if filter.From != nil && filter.To != nil && filter.To.Before(*filter.From) {
+		return nil, errs.NewXError(errs.INVALID_REQUEST, "From date should not be after the to date", nil)
+	}
+
+	// the whole of the to date is included
+	if filter.To != nil {
+		end := filter.To.AddDate(0, 0, 1)
+		filter.To = &end
+	}
+
+	auditLogs, err := svc.auditLogRepo.GetAll(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	mappedAuditLogs, mapErr := svc.respMapper.AuditLogs(auditLogs)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map AuditLog data", mapErr)
+	}
+
+	return mappedAuditLogs, nil
+}
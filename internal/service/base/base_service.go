@@ -18,6 +18,10 @@ type BaseService struct {
 	MeasurementHistoryService service.MeasurementHistoryService
 	ExpenseTrackerService     service.ExpenseTrackerService
 	TaskService               service.TaskService
+	PaymentService            service.PaymentService
+	InventoryService          service.InventoryService
+	BudgetService             service.BudgetService
+	AppointmentService        service.AppointmentService
 }
 
 func ProvideBaseService(
@@ -36,6 +40,10 @@ func ProvideBaseService(
 	measurementHistoryService service.MeasurementHistoryService,
 	expenseTrackerService service.ExpenseTrackerService,
 	taskService service.TaskService,
+	paymentService service.PaymentService,
+	inventoryService service.InventoryService,
+	budgetService service.BudgetService,
+	appointmentService service.AppointmentService,
 ) BaseService {
 	return BaseService{
 		UserService:               user,
@@ -53,5 +61,9 @@ func ProvideBaseService(
 		MeasurementHistoryService: measurementHistoryService,
 		ExpenseTrackerService:     expenseTrackerService,
 		TaskService:               taskService,
+		PaymentService:            paymentService,
+		InventoryService:          inventoryService,
+		BudgetService:             budgetService,
+		AppointmentService:        appointmentService,
 	}
 }
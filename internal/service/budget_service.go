@@ -0,0 +1,556 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/mapper"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	responseModel "github.com/imkarthi24/sf-backend/internal/model/response"
+	"github.com/imkarthi24/sf-backend/internal/repository"
+	"github.com/imkarthi24/sf-backend/internal/utils"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/loop-kar/pixie/util"
+)
+
+// recurringFrequencyMonths is the number of months between the due dates of each frequency
+var recurringFrequencyMonths = map[entities.RecurringFrequency]int{
+	entities.RecurringFrequencyMonthly:   1,
+	entities.RecurringFrequencyQuarterly: 3,
+	entities.RecurringFrequencyYearly:    12,
+}
+
+type BudgetService interface {
+	SaveCategory(*context.Context, requestModel.ExpenseCategory) *errs.XError
+	UpdateCategory(*context.Context, requestModel.ExpenseCategory, uint) *errs.XError
+	GetCategory(*context.Context, uint) (*responseModel.ExpenseCategory, *errs.XError)
+	GetCategories(*context.Context, string) ([]responseModel.ExpenseCategory, *errs.XError)
+	DeleteCategory(*context.Context, uint) *errs.XError
+
+	SaveRecurringExpense(*context.Context, requestModel.RecurringExpense) *errs.XError
+	UpdateRecurringExpense(*context.Context, requestModel.RecurringExpense, uint) *errs.XError
+	GetRecurringExpense(*context.Context, uint) (*responseModel.RecurringExpense, *errs.XError)
+	GetRecurringExpenses(*context.Context) ([]responseModel.RecurringExpense, *errs.XError)
+	DeleteRecurringExpense(*context.Context, uint) *errs.XError
+	RecordRecurringExpenses(*context.Context) *errs.XError
+
+	SaveBudget(*context.Context, requestModel.ExpenseBudget) *errs.XError
+	GetBudgets(*context.Context, *time.Time, *time.Time) ([]responseModel.ExpenseBudget, *errs.XError)
+	DeleteBudget(*context.Context, uint) *errs.XError
+	GetBudgetReport(*context.Context, *time.Time, *time.Time) (*responseModel.BudgetReport, *errs.XError)
+}
+
+type budgetService struct {
+	budgetRepo         repository.BudgetRepository
+	expenseTrackerRepo repository.ExpenseTrackerRepository
+	vendorRepo         repository.VendorRepository
+	channelRepo        repository.ChannelRepository
+	mapper             mapper.Mapper
+	respMapper         mapper.ResponseMapper
+}
+
+func ProvideBudgetService(repo repository.BudgetRepository, expenseTrackerRepo repository.ExpenseTrackerRepository, vendorRepo repository.VendorRepository,
+	channelRepo repository.ChannelRepository, mapper mapper.Mapper, respMapper mapper.ResponseMapper) BudgetService {
+	return budgetService{
+		budgetRepo:         repo,
+		expenseTrackerRepo: expenseTrackerRepo,
+		vendorRepo:         vendorRepo,
+		channelRepo:        channelRepo,
+		mapper:             mapper,
+		respMapper:         respMapper,
+	}
+}
+
+func (svc budgetService) SaveCategory(ctx *context.Context, category requestModel.ExpenseCategory) *errs.XError {
+	dbCategory, err := svc.mapper.ExpenseCategory(category)
+	if err != nil {
+		return errs.NewXError(errs.INVALID_REQUEST, "Unable to save expense category", err)
+	}
+
+	errr := svc.prepareCategory(ctx, dbCategory, 0)
+	if errr != nil {
+		return errr
+	}
+
+	return svc.budgetRepo.CreateCategory(ctx, dbCategory)
+}
+
+func (svc budgetService) UpdateCategory(ctx *context.Context, category requestModel.ExpenseCategory, id uint) *errs.XError {
+	oldCategory, err := svc.budgetRepo.GetCategory(ctx, id)
+	if err != nil {
+		return err
+	}
+	if oldCategory.Model == nil {
+		return errs.NewXError(errs.NOT_EXIST, "Expense category not found", nil)
+	}
+
+	dbCategory, mapErr := svc.mapper.ExpenseCategory(category)
+	if mapErr != nil {
+		return errs.NewXError(errs.INVALID_REQUEST, "Unable to update expense category", mapErr)
+	}
+
+	dbCategory.ID = id
+	err = svc.prepareCategory(ctx, dbCategory, id)
+	if err != nil {
+		return err
+	}
+
+	return svc.budgetRepo.UpdateCategory(ctx, dbCategory)
+}
+
+func (svc budgetService) GetCategory(ctx *context.Context, id uint) (*responseModel.ExpenseCategory, *errs.XError) {
+	category, err := svc.budgetRepo.GetCategory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if category.Model == nil {
+		return nil, errs.NewXError(errs.NOT_EXIST, "Expense category not found", nil)
+	}
+
+	mappedCategory, mapErr := svc.respMapper.ExpenseCategory(category)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map ExpenseCategory data", mapErr)
+	}
+
+	return mappedCategory, nil
+}
+
+func (svc budgetService) GetCategories(ctx *context.Context, search string) ([]responseModel.ExpenseCategory, *errs.XError) {
+	categories, err := svc.budgetRepo.GetCategories(ctx, search)
+	if err != nil {
+		return nil, err
+	}
+
+	mappedCategories, mapErr := svc.respMapper.ExpenseCategories(categories)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map ExpenseCategory data", mapErr)
+	}
+
+	return mappedCategories, nil
+}
+
+// DeleteCategory removes the category, its expenses and budgets are still reported under its name
+func (svc budgetService) DeleteCategory(ctx *context.Context, id uint) *errs.XError {
+	return svc.budgetRepo.DeleteCategory(ctx, id)
+}
+
+func (svc budgetService) SaveRecurringExpense(ctx *context.Context, recurringExpense requestModel.RecurringExpense) *errs.XError {
+	dbRecurringExpense, err := svc.mapper.RecurringExpense(recurringExpense)
+	if err != nil {
+		return errs.NewXError(errs.INVALID_REQUEST, "Unable to save recurring expense", err)
+	}
+
+	errr := svc.prepareRecurringExpense(ctx, dbRecurringExpense)
+	if errr != nil {
+		return errr
+	}
+
+	dbRecurringExpense.NextDueDate = dbRecurringExpense.StartDate
+	return svc.budgetRepo.CreateRecurringExpense(ctx, dbRecurringExpense)
+}
+
+// UpdateRecurringExpense keeps the expenses already recorded, a changed schedule takes effect from the next due date
+func (svc budgetService) UpdateRecurringExpense(ctx *context.Context, recurringExpense requestModel.RecurringExpense, id uint) *errs.XError {
+	oldRecurringExpense, err := svc.budgetRepo.GetRecurringExpense(ctx, id)
+	if err != nil {
+		return err
+	}
+	if oldRecurringExpense.Model == nil {
+		return errs.NewXError(errs.NOT_EXIST, "Recurring expense not found", nil)
+	}
+
+	dbRecurringExpense, mapErr := svc.mapper.RecurringExpense(recurringExpense)
+	if mapErr != nil {
+		return errs.NewXError(errs.INVALID_REQUEST, "Unable to update recurring expense", mapErr)
+	}
+
+	dbRecurringExpense.ID = id
+	err = svc.prepareRecurringExpense(ctx, dbRecurringExpense)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case oldRecurringExpense.NextDueDate.Equal(oldRecurringExpense.StartDate):
+		// nothing is recorded yet
+		dbRecurringExpense.NextDueDate = dbRecurringExpense.StartDate
+	case oldRecurringExpense.StartDate.Equal(dbRecurringExpense.StartDate) && oldRecurringExpense.Frequency == dbRecurringExpense.Frequency:
+		dbRecurringExpense.NextDueDate = oldRecurringExpense.NextDueDate
+	default:
+		dbRecurringExpense.NextDueDate = firstDueDateFrom(dbRecurringExpense, oldRecurringExpense.NextDueDate)
+	}
+
+	return svc.budgetRepo.UpdateRecurringExpense(ctx, dbRecurringExpense)
+}
+
+func (svc budgetService) GetRecurringExpense(ctx *context.Context, id uint) (*responseModel.RecurringExpense, *errs.XError) {
+	recurringExpense, err := svc.budgetRepo.GetRecurringExpense(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if recurringExpense.Model == nil {
+		return nil, errs.NewXError(errs.NOT_EXIST, "Recurring expense not found", nil)
+	}
+
+	mappedRecurringExpense, mapErr := svc.respMapper.RecurringExpense(recurringExpense)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map RecurringExpense data", mapErr)
+	}
+
+	return mappedRecurringExpense, nil
+}
+
+func (svc budgetService) GetRecurringExpenses(ctx *context.Context) ([]responseModel.RecurringExpense, *errs.XError) {
+	recurringExpenses, err := svc.budgetRepo.GetRecurringExpenses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	mappedRecurringExpenses, mapErr := svc.respMapper.RecurringExpenses(recurringExpenses)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map RecurringExpense data", mapErr)
+	}
+
+	return mappedRecurringExpenses, nil
+}
+
+// DeleteRecurringExpense stops the recurring expense, the expenses already recorded are kept
+func (svc budgetService) DeleteRecurringExpense(ctx *context.Context, id uint) *errs.XError {
+	return svc.budgetRepo.DeleteRecurringExpense(ctx, id)
+}
+
+// RecordRecurringExpenses records an expense for every due date of the recurring expenses till today.
+// The due dates missed while the scheduler was down are caught up, a due date is never recorded twice.
+func (svc budgetService) RecordRecurringExpenses(ctx *context.Context) *errs.XError {
+	now := util.GetLocalTime()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	recurringExpenses, err := svc.budgetRepo.GetDueRecurringExpenses(ctx, today)
+	if err != nil {
+		return err
+	}
+
+	// the expenses are recorded on behalf of the owner of the channel
+	owners := make(map[uint]uint)
+	var lastErr *errs.XError
+	for _, recurringExpense := range recurringExpenses {
+		ownerId, ok := owners[recurringExpense.ChannelId]
+		if !ok {
+			channel, err := svc.channelRepo.Get(ctx, recurringExpense.ChannelId)
+			if err != nil {
+				lastErr = err
+				continue
+			}
+			if channel.Model != nil {
+				ownerId = channel.OwnerUserID
+			}
+			owners[recurringExpense.ChannelId] = ownerId
+		}
+		if ownerId == 0 {
+			continue
+		}
+
+		channelCtx := utils.NewSystemContext(recurringExpense.ChannelId, ownerId)
+		err = svc.recordRecurringExpense(&channelCtx, recurringExpense, today)
+		if err != nil {
+			lastErr = err
+		}
+	}
+
+	return lastErr
+}
+
+func (svc budgetService) recordRecurringExpense(ctx *context.Context, recurringExpense entities.RecurringExpense, today time.Time) *errs.XError {
+	months := recurringFrequencyMonths[recurringExpense.Frequency]
+	if months == 0 {
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Invalid frequency %s of the recurring expense %s", recurringExpense.Frequency, recurringExpense.Name), nil)
+	}
+
+	startDay := recurringExpense.StartDate.In(today.Location()).Day()
+	dueDate := recurringExpense.NextDueDate.In(today.Location())
+	for !dueDate.After(today) && (recurringExpense.EndDate == nil || !dueDate.After(*recurringExpense.EndDate)) {
+		recorded, err := svc.budgetRepo.IsRecurringExpenseRecorded(ctx, recurringExpense.ID, dueDate)
+		if err != nil {
+			return err
+		}
+
+		if !recorded {
+			purchaseDate := dueDate
+			material := recurringExpense.Material
+			if material == "" {
+				material = recurringExpense.Name
+			}
+
+			expense := &entities.Expense{
+				Model:              &entities.Model{IsActive: true},
+				PurchaseDate:       &purchaseDate,
+				CompanyName:        recurringExpense.CompanyName,
+				Material:           material,
+				Price:              recurringExpense.Price,
+				Location:           recurringExpense.Location,
+				Notes:              recurringExpense.Notes,
+				CategoryId:         recurringExpense.CategoryId,
+				VendorId:           recurringExpense.VendorId,
+				RecurringExpenseId: &recurringExpense.ID,
+			}
+			err = linkExpenseVendor(ctx, svc.vendorRepo, expense)
+			if err != nil {
+				return err
+			}
+
+			err = svc.expenseTrackerRepo.Create(ctx, expense)
+			if err != nil {
+				return err
+			}
+		}
+
+		dueDate = nextDueDate(dueDate, startDay, months)
+		err = svc.budgetRepo.SetNextDueDate(ctx, recurringExpense.ID, dueDate)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SaveBudget sets the budget of the category for the month, replacing the budget saved earlier for the month
+func (svc budgetService) SaveBudget(ctx *context.Context, budget requestModel.ExpenseBudget) *errs.XError {
+	dbBudget, err := svc.mapper.ExpenseBudget(budget)
+	if err != nil {
+		return errs.NewXError(errs.INVALID_REQUEST, "Invalid budget month, expected YYYY-MM", err)
+	}
+	if dbBudget.Amount < 0 {
+		return errs.NewXError(errs.VALIDATION, "Budget amount should not be negative", nil)
+	}
+
+	errr := checkExpenseCategory(ctx, svc.budgetRepo, &dbBudget.CategoryId)
+	if errr != nil {
+		return errr
+	}
+
+	existing, errr := svc.budgetRepo.GetBudget(ctx, dbBudget.CategoryId, dbBudget.Month)
+	if errr != nil {
+		return errr
+	}
+	if existing.Model == nil {
+		return svc.budgetRepo.CreateBudget(ctx, dbBudget)
+	}
+
+	existing.Amount = dbBudget.Amount
+	existing.Notes = dbBudget.Notes
+	return svc.budgetRepo.UpdateBudget(ctx, existing)
+}
+
+// GetBudgets returns the budgets between the from and to months (both inclusive)
+func (svc budgetService) GetBudgets(ctx *context.Context, from *time.Time, to *time.Time) ([]responseModel.ExpenseBudget, *errs.XError) {
+	rangeStart, rangeEnd, err := budgetMonthRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	budgets, err := svc.budgetRepo.GetBudgets(ctx, rangeStart, rangeEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	mappedBudgets, mapErr := svc.respMapper.ExpenseBudgets(budgets)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map ExpenseBudget data", mapErr)
+	}
+
+	return mappedBudgets, nil
+}
+
+func (svc budgetService) DeleteBudget(ctx *context.Context, id uint) *errs.XError {
+	return svc.budgetRepo.DeleteBudget(ctx, id)
+}
+
+// GetBudgetReport compares the expenses of each category between the from and to months (both inclusive) with its budget
+func (svc budgetService) GetBudgetReport(ctx *context.Context, from *time.Time, to *time.Time) (*responseModel.BudgetReport, *errs.XError) {
+	rangeStart, rangeEnd, err := budgetMonthRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	budgets, err := svc.budgetRepo.GetBudgetTotals(ctx, rangeStart, rangeEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	actuals, err := svc.budgetRepo.GetActualTotals(ctx, rangeStart, rangeEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make(map[uint]*responseModel.BudgetReportLine)
+	for i := range budgets {
+		lines[budgets[i].CategoryId] = &budgets[i]
+	}
+	for _, actual := range actuals {
+		line, ok := lines[actual.CategoryId]
+		if !ok {
+			line = &responseModel.BudgetReportLine{CategoryId: actual.CategoryId, Category: actual.Category}
+			lines[actual.CategoryId] = line
+		}
+		line.Actual = actual.Actual
+	}
+
+	report := &responseModel.BudgetReport{
+		From:       rangeStart.Format(entities.BudgetMonthLayout),
+		To:         rangeEnd.AddDate(0, -1, 0).Format(entities.BudgetMonthLayout),
+		Categories: make([]responseModel.BudgetReportLine, 0, len(lines)),
+	}
+	for _, line := range lines {
+		line.Budget = roundAmount(line.Budget)
+		line.Actual = roundAmount(line.Actual)
+		line.Variance = roundAmount(line.Budget - line.Actual)
+		line.IsOverBudget = line.Actual > line.Budget
+		if line.Budget > 0 {
+			utilization := roundAmount(line.Actual / line.Budget * 100)
+			line.UtilizationPercent = &utilization
+		}
+
+		report.TotalBudget += line.Budget
+		report.TotalActual += line.Actual
+		report.Categories = append(report.Categories, *line)
+	}
+	report.TotalBudget = roundAmount(report.TotalBudget)
+	report.TotalActual = roundAmount(report.TotalActual)
+	report.TotalVariance = roundAmount(report.TotalBudget - report.TotalActual)
+
+	// the uncategorized expenses go last
+	sort.Slice(report.Categories, func(i, j int) bool {
+		if (report.Categories[i].CategoryId == 0) != (report.Categories[j].CategoryId == 0) {
+			return report.Categories[j].CategoryId == 0
+		}
+		return report.Categories[i].Category < report.Categories[j].Category
+	})
+
+	return report, nil
+}
+
+func (svc budgetService) prepareCategory(ctx *context.Context, category *entities.ExpenseCategory, id uint) *errs.XError {
+	category.Name = strings.TrimSpace(category.Name)
+	if category.Name == "" {
+		return errs.NewXError(errs.VALIDATION, "Name of the expense category is required", nil)
+	}
+
+	existing, err := svc.budgetRepo.GetCategoryByName(ctx, category.Name)
+	if err != nil {
+		return err
+	}
+	if existing.Model != nil && existing.ID != id {
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Expense category %s already exists", category.Name), nil)
+	}
+	return nil
+}
+
+func (svc budgetService) prepareRecurringExpense(ctx *context.Context, recurringExpense *entities.RecurringExpense) *errs.XError {
+	recurringExpense.Name = strings.TrimSpace(recurringExpense.Name)
+	if recurringExpense.Name == "" {
+		return errs.NewXError(errs.VALIDATION, "Name of the recurring expense is required", nil)
+	}
+	if _, ok := recurringFrequencyMonths[recurringExpense.Frequency]; !ok {
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Invalid frequency %s, expected MONTHLY, QUARTERLY or YEARLY", recurringExpense.Frequency), nil)
+	}
+	if recurringExpense.Price <= 0 {
+		return errs.NewXError(errs.VALIDATION, "Price of the recurring expense should be more than zero", nil)
+	}
+
+	location := util.GetLocalTime().Location()
+	start := recurringExpense.StartDate
+	recurringExpense.StartDate = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, location)
+	if recurringExpense.EndDate != nil {
+		end := time.Date(recurringExpense.EndDate.Year(), recurringExpense.EndDate.Month(), recurringExpense.EndDate.Day(), 0, 0, 0, 0, location)
+		if end.Before(recurringExpense.StartDate) {
+			return errs.NewXError(errs.VALIDATION, "End date should not be before the start date", nil)
+		}
+		recurringExpense.EndDate = &end
+	}
+
+	err := checkExpenseCategory(ctx, svc.budgetRepo, recurringExpense.CategoryId)
+	if err != nil {
+		return err
+	}
+
+	if recurringExpense.VendorId != nil {
+		vendor, err := svc.vendorRepo.Get(ctx, *recurringExpense.VendorId)
+		if err != nil {
+			return err
+		}
+		if vendor.Model == nil {
+			return errs.NewXError(errs.NOT_EXIST, "Vendor not found", nil)
+		}
+		recurringExpense.CompanyName = vendor.Name
+	}
+
+	return nil
+}
+
+// checkExpenseCategory validates the category of an expense, the category is optional
+func checkExpenseCategory(ctx *context.Context, budgetRepo repository.BudgetRepository, categoryId *uint) *errs.XError {
+	if categoryId == nil {
+		return nil
+	}
+
+	category, err := budgetRepo.GetCategory(ctx, *categoryId)
+	if err != nil {
+		return err
+	}
+	if category.Model == nil {
+		return errs.NewXError(errs.NOT_EXIST, "Expense category not found", nil)
+	}
+	return nil
+}
+
+// budgetMonthRange returns the first day of the from month and of the month after the to month.
+// Either month defaults to the other one, or both to the current month.
+func budgetMonthRange(from *time.Time, to *time.Time) (time.Time, time.Time, *errs.XError) {
+	now := util.GetLocalTime()
+
+	rangeStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	if from != nil {
+		rangeStart = time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, now.Location())
+	}
+	rangeEnd := rangeStart
+	if to != nil {
+		rangeEnd = time.Date(to.Year(), to.Month(), 1, 0, 0, 0, 0, now.Location())
+		if from == nil {
+			rangeStart = rangeEnd
+		}
+	}
+	if rangeEnd.Before(rangeStart) {
+		return rangeStart, rangeEnd, errs.NewXError(errs.INVALID_REQUEST, "From month should not be after the to month", nil)
+	}
+
+	return rangeStart, rangeEnd.AddDate(0, 1, 0), nil
+}
+
+// nextDueDate returns the due date the given months after the due date, on the day of the month of the start date.
+// Shorter months fall on their last day, eg: Jan 31, Feb 28, Mar 31.
+func nextDueDate(dueDate time.Time, startDay int, months int) time.Time {
+	month := time.Date(dueDate.Year(), dueDate.Month()+time.Month(months), 1, 0, 0, 0, 0, dueDate.Location())
+
+	day := month.AddDate(0, 1, -1).Day()
+	if startDay < day {
+		day = startDay
+	}
+	return time.Date(month.Year(), month.Month(), day, 0, 0, 0, 0, dueDate.Location())
+}
+
+// firstDueDateFrom returns the first due date of the schedule of the recurring expense on or after the date
+func firstDueDateFrom(recurringExpense *entities.RecurringExpense, date time.Time) time.Time {
+	months := recurringFrequencyMonths[recurringExpense.Frequency]
+	startDay := recurringExpense.StartDate.Day()
+
+	dueDate := recurringExpense.StartDate
+	for dueDate.Before(date) {
+		dueDate = nextDueDate(dueDate, startDay, months)
+	}
+	return dueDate
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	responseModel "github.com/imkarthi24/sf-backend/internal/model/response"
+	"github.com/imkarthi24/sf-backend/internal/repository"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/loop-kar/pixie/util"
+)
+
+const (
+	dashboardOverdueOrdersLimit = 20
+	dashboardTopReferrersLimit  = 10
+)
+
+type DashboardService interface {
+	GetDashboardStats(ctx *context.Context, from *time.Time, to *time.Time) (*responseModel.DashboardStats, *errs.XError)
+}
+
+type dashboardService struct {
+	dashboardRepo repository.DashboardRepository
+}
+
+func ProvideDashboardService(repo repository.DashboardRepository) DashboardService {
+	return dashboardService{
+		dashboardRepo: repo,
+	}
+}
+
+// GetDashboardStats returns the stats of the channel between the from and to dates (both inclusive).
+// The range defaults to the start of the current month till today.
+func (svc dashboardService) GetDashboardStats(ctx *context.Context, from *time.Time, to *time.Time) (*responseModel.DashboardStats, *errs.XError) {
+	now := util.GetLocalTime()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	rangeStart, rangeEnd, err := reportDateRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// the queries take the end of the range as exclusive
+	end := rangeEnd.AddDate(0, 0, 1)
+
+	stats := responseModel.DashboardStats{
+		From: rangeStart,
+		To:   rangeEnd,
+	}
+
+	stats.OrderStats, err = svc.dashboardRepo.GetOrderStatusCounts(ctx, rangeStart, end)
+	if err != nil {
+		return nil, err
+	}
+
+	stats.RevenueStats, err = svc.getRevenueStats(ctx, rangeStart, end)
+	if err != nil {
+		return nil, err
+	}
+
+	overdueOrders, overdueCount, err := svc.dashboardRepo.GetOverdueOrders(ctx, today, dashboardOverdueOrdersLimit)
+	if err != nil {
+		return nil, err
+	}
+	stats.OverdueDeliveries = &responseModel.OverdueDeliveryStats{
+		Count:  overdueCount,
+		Orders: overdueOrders,
+	}
+
+	stats.EnquiryStats, err = svc.getEnquiryStats(ctx, rangeStart, end)
+	if err != nil {
+		return nil, err
+	}
+
+	stats.ReferrerStats, err = svc.dashboardRepo.GetTopReferrers(ctx, rangeStart, end, dashboardTopReferrersLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	totalExpense, err := svc.dashboardRepo.GetTotalExpense(ctx, rangeStart, end)
+	if err != nil {
+		return nil, err
+	}
+	labourCost, err := svc.dashboardRepo.GetTotalLabourCost(ctx, rangeStart, end)
+	if err != nil {
+		return nil, err
+	}
+	stats.ExpenseStats = &responseModel.ExpenseStat{
+		TotalExpense:    totalExpense,
+		LabourCost:      labourCost,
+		TotalCost:       totalExpense + labourCost,
+		CollectedAmount: stats.RevenueStats.CollectedAmount,
+		NetAmount:       stats.RevenueStats.CollectedAmount - (totalExpense + labourCost),
+	}
+
+	stats.StaffWorkload, err = svc.dashboardRepo.GetStaffWorkload(ctx, rangeStart, end, today)
+	if err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+func (svc dashboardService) getRevenueStats(ctx *context.Context, from, to time.Time) (*responseModel.RevenueStat, *errs.XError) {
+	bookedValue, err := svc.dashboardRepo.GetBookedOrderValue(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	dailyCollection, err := svc.dashboardRepo.GetDailyCollection(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	revenue := responseModel.RevenueStat{
+		BookedValue:     bookedValue,
+		DailyCollection: dailyCollection,
+	}
+	for _, day := range dailyCollection {
+		revenue.CollectedAmount += day.Amount
+	}
+
+	return &revenue, nil
+}
+
+// getEnquiryStats returns the enquiry counts by status and source,
+// an enquiry is taken as converted once it is accepted
+func (svc dashboardService) getEnquiryStats(ctx *context.Context, from, to time.Time) (*responseModel.EnquiryConversionStat, *errs.XError) {
+	statusStats, err := svc.dashboardRepo.GetEnquiryStatusCounts(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceStats, err := svc.dashboardRepo.GetEnquirySourceCounts(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	enquiryStats := responseModel.EnquiryConversionStat{
+		StatusStats: statusStats,
+		SourceStats: sourceStats,
+	}
+	for _, stat := range statusStats {
+		enquiryStats.TotalCount += stat.Count
+		if stat.Status == string(entities.EnquiryStatusAccepted) {
+			enquiryStats.AcceptedCount = stat.Count
+		}
+	}
+	enquiryStats.ConversionRate = conversionRate(enquiryStats.AcceptedCount, enquiryStats.TotalCount)
+
+	for i := range enquiryStats.SourceStats {
+		source := &enquiryStats.SourceStats[i]
+		source.ConversionRate = conversionRate(source.AcceptedCount, source.Count)
+	}
+
+	return &enquiryStats, nil
+}
+
+// conversionRate returns the percentage of converted over total rounded to two decimals
+func conversionRate(converted int, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(converted*10000/total) / 100
+}
+
+// reportDateRange returns the start and end days of a report between the from and to dates (both inclusive).
+// The range defaults to the start of the current month till today.
+func reportDateRange(from *time.Time, to *time.Time) (time.Time, time.Time, *errs.XError) {
+	now := util.GetLocalTime()
+
+	rangeStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	if from != nil {
+		rangeStart = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, now.Location())
+	}
+	rangeEnd := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if to != nil {
+		rangeEnd = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, now.Location())
+	}
+	if rangeEnd.Before(rangeStart) {
+		return rangeStart, rangeEnd, errs.NewXError(errs.INVALID_REQUEST, "From date should not be after the to date", nil)
+	}
+
+	return rangeStart, rangeEnd, nil
+}
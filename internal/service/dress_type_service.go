@@ -2,11 +2,14 @@ package service
 
 import (
 	"context"
+	"strings"
 
+	"github.com/imkarthi24/sf-backend/internal/entities"
 	"github.com/imkarthi24/sf-backend/internal/mapper"
 	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
 	responseModel "github.com/imkarthi24/sf-backend/internal/model/response"
 	"github.com/imkarthi24/sf-backend/internal/repository"
+	"github.com/imkarthi24/sf-backend/internal/utils/validator"
 	"github.com/loop-kar/pixie/errs"
 )
 
@@ -38,7 +41,12 @@ func (svc dressTypeService) SaveDressType(ctx *context.Context, dressType reques
 		return errs.NewXError(errs.INVALID_REQUEST, "Unable to save dress type", err)
 	}
 
-	errr := svc.dressTypeRepo.Create(ctx, dbDressType)
+	errr := prepareMeasurementFields(dbDressType)
+	if errr != nil {
+		return errr
+	}
+
+	errr = svc.dressTypeRepo.Create(ctx, dbDressType)
 	if errr != nil {
 		return errr
 	}
@@ -52,8 +60,13 @@ func (svc dressTypeService) UpdateDressType(ctx *context.Context, dressType requ
 		return errs.NewXError(errs.INVALID_REQUEST, "Unable to update dress type", err)
 	}
 
+	errr := prepareMeasurementFields(dbDressType)
+	if errr != nil {
+		return errr
+	}
+
 	dbDressType.ID = id
-	errr := svc.dressTypeRepo.Update(ctx, dbDressType)
+	errr = svc.dressTypeRepo.Update(ctx, dbDressType)
 	if errr != nil {
 		return errr
 	}
@@ -95,3 +108,25 @@ func (svc dressTypeService) Delete(ctx *context.Context, id uint) *errs.XError {
 	}
 	return nil
 }
+
+// prepareMeasurementFields validates the measurement definitions of the dress type
+// and keeps the Measurements CSV in sync with the names of the definitions
+func prepareMeasurementFields(dressType *entities.DressType) *errs.XError {
+	if len(dressType.MeasurementFields) == 0 {
+		return nil
+	}
+
+	errr := validator.ValidateMeasurementFields(dressType.MeasurementFields).ToXError("Invalid measurement fields")
+	if errr != nil {
+		return errr
+	}
+
+	names := make([]string, 0, len(dressType.MeasurementFields))
+	for i := range dressType.MeasurementFields {
+		dressType.MeasurementFields[i].Name = strings.TrimSpace(dressType.MeasurementFields[i].Name)
+		names = append(names, dressType.MeasurementFields[i].Name)
+	}
+	dressType.Measurements = strings.Join(names, ", ")
+
+	return nil
+}
@@ -2,37 +2,56 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"html"
+	"strings"
+	"time"
 
+	"github.com/imkarthi24/sf-backend/internal/constants"
 	"github.com/imkarthi24/sf-backend/internal/entities"
 	"github.com/imkarthi24/sf-backend/internal/mapper"
 	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
 	responseModel "github.com/imkarthi24/sf-backend/internal/model/response"
 	"github.com/imkarthi24/sf-backend/internal/repository"
+	"github.com/imkarthi24/sf-backend/internal/utils"
 	"github.com/loop-kar/pixie/errs"
+	"github.com/loop-kar/pixie/service/email"
+	"github.com/loop-kar/pixie/util"
 )
 
 type EnquiryService interface {
 	SaveEnquiry(*context.Context, requestModel.Enquiry) *errs.XError
+	SaveExternalEnquiry(*context.Context, requestModel.ExternalEnquiry) *errs.XError
 	UpdateEnquiry(*context.Context, requestModel.Enquiry, uint) *errs.XError
 	UpdateEnquiryAndCustomer(*context.Context, requestModel.UpdateEnquiryAndCustomer, uint) *errs.XError
+	ConvertToOrder(*context.Context, requestModel.ConvertEnquiry, uint) (*responseModel.ConvertedEnquiry, *errs.XError)
 	Get(*context.Context, uint) (*responseModel.Enquiry, *errs.XError)
 	GetAll(*context.Context, string) ([]responseModel.Enquiry, *errs.XError)
 	Delete(*context.Context, uint) *errs.XError
+	RemindCallbacks(*context.Context) *errs.XError
 }
 
 type enquiryService struct {
-	enquiryRepo  repository.EnquiryRepository
-	customerRepo repository.CustomerRepository
-	mapper       mapper.Mapper
-	respMapper   mapper.ResponseMapper
+	enquiryRepo        repository.EnquiryRepository
+	customerRepo       repository.CustomerRepository
+	enquiryHistoryRepo repository.EnquiryHistoryRepository
+	taskRepo           repository.TaskRepository
+	channelRepo        repository.ChannelRepository
+	notificationSvc    NotificationService
+	mapper             mapper.Mapper
+	respMapper         mapper.ResponseMapper
 }
 
-func ProvideEnquiryService(repo repository.EnquiryRepository, customerRepo repository.CustomerRepository, mapper mapper.Mapper, respMapper mapper.ResponseMapper) EnquiryService {
+func ProvideEnquiryService(repo repository.EnquiryRepository, customerRepo repository.CustomerRepository, enquiryHistoryRepo repository.EnquiryHistoryRepository, taskRepo repository.TaskRepository, channelRepo repository.ChannelRepository, notificationSvc NotificationService, mapper mapper.Mapper, respMapper mapper.ResponseMapper) EnquiryService {
 	return enquiryService{
-		enquiryRepo:  repo,
-		customerRepo: customerRepo,
-		mapper:       mapper,
-		respMapper:   respMapper,
+		enquiryRepo:        repo,
+		customerRepo:       customerRepo,
+		enquiryHistoryRepo: enquiryHistoryRepo,
+		taskRepo:           taskRepo,
+		channelRepo:        channelRepo,
+		notificationSvc:    notificationSvc,
+		mapper:             mapper,
+		respMapper:         respMapper,
 	}
 }
 
@@ -84,6 +103,13 @@ func (svc enquiryService) SaveEnquiry(ctx *context.Context, enquiry requestModel
 	}
 	dbEnquiry.CustomerId = customerId
 
+	if dbEnquiry.Status == "" {
+		dbEnquiry.Status = entities.EnquiryStatusNew
+	}
+	if _, ok := entities.EnquiryStatusTransitions[dbEnquiry.Status]; !ok {
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Invalid enquiry status %s", dbEnquiry.Status), nil)
+	}
+
 	errr := svc.enquiryRepo.Create(ctx, dbEnquiry)
 	if errr != nil {
 		return errr
@@ -92,18 +118,70 @@ func (svc enquiryService) SaveEnquiry(ctx *context.Context, enquiry requestModel
 	return nil
 }
 
+// SaveExternalEnquiry saves the lead of a website or social lead form as a new enquiry,
+// the leads filling the honeypot are dropped without an error so that the bots do not retry
+func (svc enquiryService) SaveExternalEnquiry(ctx *context.Context, lead requestModel.ExternalEnquiry) *errs.XError {
+	if strings.TrimSpace(lead.Website) != "" {
+		return nil
+	}
+
+	phoneNumber := strings.TrimSpace(lead.PhoneNumber)
+	if phoneNumber == "" {
+		return errs.NewXError(errs.VALIDATION, "Phone number is required", nil)
+	}
+
+	source := strings.ToLower(strings.TrimSpace(lead.Platform))
+	if !entities.EnquiryLeadSources[source] {
+		source = entities.EnquirySourceWebsite
+	}
+
+	subject := strings.TrimSpace(lead.Subject)
+	if subject == "" {
+		subject = fmt.Sprintf("Enquiry from %s", source)
+	}
+
+	enquiry := requestModel.Enquiry{
+		IsActive:       true,
+		Subject:        subject,
+		Notes:          strings.TrimSpace(lead.Notes),
+		Status:         string(entities.EnquiryStatusNew),
+		FirstName:      strings.TrimSpace(lead.FirstName),
+		LastName:       strings.TrimSpace(lead.LastName),
+		Email:          strings.TrimSpace(lead.Email),
+		PhoneNumber:    phoneNumber,
+		WhatsappNumber: strings.TrimSpace(lead.WhatsappNumber),
+		Source:         source,
+	}
+
+	return svc.SaveEnquiry(ctx, enquiry)
+}
+
 func (svc enquiryService) UpdateEnquiry(ctx *context.Context, enquiry requestModel.Enquiry, id uint) *errs.XError {
+	oldEnquiry, errr := svc.enquiryRepo.Get(ctx, id)
+	if errr != nil {
+		return errr
+	}
+	if oldEnquiry.Model == nil {
+		return errs.NewXError(errs.NOT_EXIST, "Enquiry not found", nil)
+	}
+
 	dbEnquiry, err := svc.mapper.Enquiry(enquiry)
 	if err != nil {
 		return errs.NewXError(errs.INVALID_REQUEST, "Unable to update enquiry", err)
 	}
 
 	dbEnquiry.ID = id
-	errr := svc.enquiryRepo.Update(ctx, dbEnquiry)
+	errr = prepareEnquiryStatus(oldEnquiry.Status, dbEnquiry)
 	if errr != nil {
 		return errr
 	}
-	return nil
+
+	errr = svc.enquiryRepo.Update(ctx, dbEnquiry)
+	if errr != nil {
+		return errr
+	}
+
+	return svc.recordEnquiryHistory(ctx, oldEnquiry, dbEnquiry, enquiryChangedFields(oldEnquiry, dbEnquiry, nil))
 }
 
 func (svc enquiryService) UpdateEnquiryAndCustomer(ctx *context.Context, request requestModel.UpdateEnquiryAndCustomer, enquiryId uint) *errs.XError {
@@ -111,6 +189,9 @@ func (svc enquiryService) UpdateEnquiryAndCustomer(ctx *context.Context, request
 	if err != nil {
 		return err
 	}
+	if existingEnquiry.Model == nil {
+		return errs.NewXError(errs.NOT_EXIST, "Enquiry not found", nil)
+	}
 
 	enquiryRequest := requestModel.Enquiry{
 		ID:                  request.ID,
@@ -132,6 +213,10 @@ func (svc enquiryService) UpdateEnquiryAndCustomer(ctx *context.Context, request
 	if dbEnquiry.CustomerId == nil {
 		dbEnquiry.CustomerId = existingEnquiry.CustomerId
 	}
+	err = prepareEnquiryStatus(existingEnquiry.Status, dbEnquiry)
+	if err != nil {
+		return err
+	}
 
 	var dbCustomer *entities.Customer
 	customerRequest := requestModel.Customer{
@@ -195,7 +280,218 @@ func (svc enquiryService) UpdateEnquiryAndCustomer(ctx *context.Context, request
 		return errr
 	}
 
-	return nil
+	return svc.recordEnquiryHistory(ctx, existingEnquiry, dbEnquiry, enquiryChangedFields(existingEnquiry, dbEnquiry, dbCustomer))
+}
+
+// prepareEnquiryStatus retains the status when it is not sent and validates the change of the status
+func prepareEnquiryStatus(oldStatus entities.EnquiryStatus, enquiry *entities.Enquiry) *errs.XError {
+	if enquiry.Status == "" {
+		enquiry.Status = oldStatus
+	}
+	if _, ok := entities.EnquiryStatusTransitions[enquiry.Status]; !ok {
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Invalid enquiry status %s", enquiry.Status), nil)
+	}
+
+	// Enquiries without a status (legacy data) can move to any status
+	if oldStatus == "" || oldStatus == enquiry.Status {
+		return nil
+	}
+	for _, allowed := range entities.EnquiryStatusTransitions[oldStatus] {
+		if allowed == enquiry.Status {
+			return nil
+		}
+	}
+	return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Enquiry status cannot be changed from %s to %s", oldStatus, enquiry.Status), nil)
+}
+
+// enquiryChangedFields returns the fields of the enquiry changed by the update,
+// the customer is compared only when it is updated along with the enquiry
+func enquiryChangedFields(oldEnquiry *entities.Enquiry, enquiry *entities.Enquiry, customer *entities.Customer) []string {
+	var changedFields []string
+	if oldEnquiry.Status != enquiry.Status {
+		changedFields = append(changedFields, entities.EnquiryChangeFieldStatus)
+	}
+	if oldEnquiry.Subject != enquiry.Subject {
+		changedFields = append(changedFields, entities.EnquiryChangeFieldSubject)
+	}
+	if oldEnquiry.Notes != enquiry.Notes {
+		changedFields = append(changedFields, entities.EnquiryChangeFieldNotes)
+	}
+	if oldEnquiry.Source != enquiry.Source {
+		changedFields = append(changedFields, entities.EnquiryChangeFieldSource)
+	}
+	if oldEnquiry.ReferredBy != enquiry.ReferredBy {
+		changedFields = append(changedFields, entities.EnquiryChangeFieldReferredBy)
+	}
+	if oldEnquiry.ReferrerPhoneNumber != enquiry.ReferrerPhoneNumber {
+		changedFields = append(changedFields, entities.EnquiryChangeFieldReferrerPhoneNumber)
+	}
+
+	customerId := enquiry.CustomerId
+	if customer != nil && customer.ID != 0 {
+		customerId = &customer.ID
+	}
+	if !uintEqual(oldEnquiry.CustomerId, customerId) {
+		changedFields = append(changedFields, entities.EnquiryChangeFieldCustomerId)
+	} else if customer != nil && oldEnquiry.Customer != nil && oldEnquiry.Customer.Model != nil {
+		old := oldEnquiry.Customer
+		if old.FirstName != customer.FirstName || old.LastName != customer.LastName || old.Email != customer.Email ||
+			old.PhoneNumber != customer.PhoneNumber || old.WhatsappNumber != customer.WhatsappNumber || old.Address != customer.Address {
+			changedFields = append(changedFields, entities.EnquiryChangeFieldCustomer)
+		}
+	}
+
+	return changedFields
+}
+
+// uintEqual compares two *uint values, handling nil cases
+func uintEqual(u1, u2 *uint) bool {
+	if u1 == nil && u2 == nil {
+		return true
+	}
+	if u1 == nil || u2 == nil {
+		return false
+	}
+	return *u1 == *u2
+}
+
+// recordEnquiryHistory records the update of the enquiry with the old status and the changed fields.
+// The call back and visiting dates of the latest history are carried forward, so that a pending call back is not lost.
+func (svc enquiryService) recordEnquiryHistory(ctx *context.Context, oldEnquiry *entities.Enquiry, enquiry *entities.Enquiry, changedFields []string) *errs.XError {
+	if len(changedFields) == 0 {
+		return nil
+	}
+
+	userId := utils.GetUserId(ctx)
+	performedAt := util.GetLocalTime()
+
+	oldStatus := oldEnquiry.Status
+	status := enquiry.Status
+	comment := "Enquiry updated"
+	if oldStatus != status {
+		comment = fmt.Sprintf("Status changed from %s to %s", oldStatus, status)
+	}
+
+	history := &entities.EnquiryHistory{
+		Model:           &entities.Model{IsActive: true},
+		Status:          &status,
+		OldStatus:       &oldStatus,
+		ChangedFields:   strings.Join(changedFields, ","),
+		EmployeeComment: comment,
+		EnquiryDate:     &performedAt,
+		EnquiryId:       oldEnquiry.ID,
+		EmployeeId:      userId,
+		PerformedAt:     performedAt,
+		PerformedById:   userId,
+	}
+
+	histories, err := svc.enquiryHistoryRepo.GetByEnquiryId(ctx, oldEnquiry.ID)
+	if err != nil {
+		return err
+	}
+	if len(histories) > 0 {
+		latest := histories[0]
+		history.VisitingDate = latest.VisitingDate
+		history.CallBackDate = latest.CallBackDate
+		if latest.EmployeeId != 0 {
+			history.EmployeeId = latest.EmployeeId
+		}
+	}
+
+	return svc.enquiryHistoryRepo.Create(ctx, history)
+}
+
+// ConvertToOrder converts an enquiry into an order for the enquiry's customer.
+// The customer is activated, the order is created with its items and the enquiry is accepted in one transaction.
+func (svc enquiryService) ConvertToOrder(ctx *context.Context, request requestModel.ConvertEnquiry, enquiryId uint) (*responseModel.ConvertedEnquiry, *errs.XError) {
+	enquiry, err := svc.enquiryRepo.Get(ctx, enquiryId)
+	if err != nil {
+		return nil, err
+	}
+	// the enquiry is not scoped to the channel, the order would be created for the customer of another channel
+	if channelId := utils.GetChannelId(ctx); enquiry.Model == nil || !enquiry.IsActive || (channelId != 0 && enquiry.ChannelId != channelId) {
+		return nil, errs.NewXError(errs.NOT_EXIST, "Enquiry not found", nil)
+	}
+	if enquiry.Status == entities.EnquiryStatusAccepted {
+		return nil, errs.NewXError(errs.VALIDATION, "Enquiry is already accepted", nil)
+	}
+	err = prepareEnquiryStatus(enquiry.Status, &entities.Enquiry{Status: entities.EnquiryStatusAccepted})
+	if err != nil {
+		return nil, err
+	}
+	if enquiry.CustomerId == nil {
+		return nil, errs.NewXError(errs.VALIDATION, "Enquiry does not have a customer to create the order for", nil)
+	}
+	if len(request.Order.OrderItems) == 0 {
+		return nil, errs.NewXError(errs.VALIDATION, "Order should have at least one item", nil)
+	}
+
+	dbOrder, mapErr := svc.mapper.Order(request.Order)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.INVALID_REQUEST, "Unable to map order", mapErr)
+	}
+
+	// A converted enquiry is always a new order
+	switch dbOrder.Status {
+	case "":
+		dbOrder.Status = entities.DRAFT
+	case entities.DRAFT, entities.CONFIRMED:
+	default:
+		return nil, errs.NewXError(errs.VALIDATION, fmt.Sprintf("Order cannot be created with status %s", dbOrder.Status), nil)
+	}
+
+	userId := utils.GetUserId(ctx)
+	performedAt := util.GetLocalTime()
+
+	dbOrder.ID = 0
+	dbOrder.IsActive = true
+	dbOrder.CustomerId = enquiry.CustomerId
+	dbOrder.DeliveredDate = nil
+	if dbOrder.OrderTakenById == nil {
+		dbOrder.OrderTakenById = &userId
+	}
+	for i := range dbOrder.OrderItems {
+		dbOrder.OrderItems[i].ID = 0
+		dbOrder.OrderItems[i].IsActive = true
+	}
+
+	orderHistory := &entities.OrderHistory{
+		Model:         &entities.Model{IsActive: true},
+		Action:        entities.OrderHistoryActionCreated,
+		PerformedAt:   performedAt,
+		PerformedById: userId,
+	}
+
+	comment := request.Comment
+	if util.IsNilOrEmptyString(&comment) {
+		comment = "Enquiry converted to order"
+	}
+	acceptedStatus := entities.EnquiryStatusAccepted
+	oldStatus := enquiry.Status
+	enquiryHistory := &entities.EnquiryHistory{
+		Model:           &entities.Model{IsActive: true},
+		Status:          &acceptedStatus,
+		OldStatus:       &oldStatus,
+		ChangedFields:   entities.EnquiryChangeFieldStatus,
+		EmployeeComment: comment,
+		EnquiryDate:     &performedAt,
+		ResponseStatus:  entities.ACCEPTED,
+		EnquiryId:       enquiry.ID,
+		EmployeeId:      userId,
+		PerformedAt:     performedAt,
+		PerformedById:   userId,
+	}
+
+	err = svc.enquiryRepo.ConvertToOrder(ctx, enquiry, dbOrder, orderHistory, enquiryHistory)
+	if err != nil {
+		return nil, err
+	}
+
+	return &responseModel.ConvertedEnquiry{
+		EnquiryId:  enquiry.ID,
+		CustomerId: *enquiry.CustomerId,
+		OrderId:    dbOrder.ID,
+	}, nil
 }
 
 func (svc enquiryService) Get(ctx *context.Context, id uint) (*responseModel.Enquiry, *errs.XError) {
@@ -233,3 +529,156 @@ func (svc enquiryService) Delete(ctx *context.Context, id uint) *errs.XError {
 	}
 	return nil
 }
+
+// RemindCallbacks raises a task for the employee of every open enquiry whose latest history has a call back today
+// and queues a digest mail of the day's call backs to each employee.
+func (svc enquiryService) RemindCallbacks(ctx *context.Context) *errs.XError {
+	now := util.GetLocalTime()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	callbacks, err := svc.enquiryHistoryRepo.GetDueCallbacks(ctx, today, today.AddDate(0, 0, 1))
+	if err != nil {
+		return err
+	}
+
+	// callbacks are ordered by channel and employee, remind an employee of a channel at a time
+	var lastErr *errs.XError
+	for start := 0; start < len(callbacks); {
+		channelEnd := start
+		for channelEnd < len(callbacks) && callbacks[channelEnd].ChannelId == callbacks[start].ChannelId {
+			channelEnd++
+		}
+
+		err = svc.remindChannelCallbacks(ctx, callbacks[start].ChannelId, callbacks[start:channelEnd])
+		if err != nil {
+			lastErr = err
+		}
+		start = channelEnd
+	}
+
+	return lastErr
+}
+
+func (svc enquiryService) remindChannelCallbacks(ctx *context.Context, channelId uint, callbacks []responseModel.EnquiryCallback) *errs.XError {
+	channel, err := svc.channelRepo.Get(ctx, channelId)
+	if err != nil {
+		return err
+	}
+	if channel.Model == nil || channel.OwnerUserID == 0 {
+		return nil
+	}
+
+	// the reminders are raised on behalf of the owner of the channel
+	channelCtx := utils.NewSystemContext(channelId, channel.OwnerUserID)
+
+	var lastErr *errs.XError
+	for start := 0; start < len(callbacks); {
+		end := start
+		for end < len(callbacks) && callbacks[end].EmployeeId == callbacks[start].EmployeeId {
+			end++
+		}
+
+		for _, callback := range callbacks[start:end] {
+			err = svc.raiseCallbackTask(&channelCtx, callback)
+			if err != nil {
+				lastErr = err
+			}
+		}
+
+		err = svc.queueCallbackDigest(&channelCtx, channel.Name, callbacks[start:end])
+		if err != nil {
+			lastErr = err
+		}
+		start = end
+	}
+
+	return lastErr
+}
+
+// raiseCallbackTask creates the call back task of the enquiry, or refreshes it when the previous one is still open
+func (svc enquiryService) raiseCallbackTask(ctx *context.Context, callback responseModel.EnquiryCallback) *errs.XError {
+	title := fmt.Sprintf("Call back enquiry #%d", callback.EnquiryId)
+
+	details := []string{fmt.Sprintf("Call back %s", callbackContact(callback))}
+	if callback.Subject != "" {
+		details = append(details, fmt.Sprintf("Enquiry: %s", callback.Subject))
+	}
+	if callback.EmployeeComment != "" {
+		details = append(details, fmt.Sprintf("Last comment: %s", callback.EmployeeComment))
+	}
+	if callback.CustomerComment != "" {
+		details = append(details, fmt.Sprintf("Customer said: %s", callback.CustomerComment))
+	}
+	description := strings.Join(details, "\n")
+	dueDate := callback.CallBackDate
+
+	task, err := svc.taskRepo.GetOpenByTitle(ctx, title, callback.EmployeeId)
+	if err != nil {
+		return err
+	}
+	if task.Model != nil {
+		task.Description = &description
+		task.DueDate = &dueDate
+		task.ReminderDate = &dueDate
+		return svc.taskRepo.Update(ctx, task)
+	}
+
+	return svc.taskRepo.Create(ctx, &entities.Task{
+		Model:        &entities.Model{IsActive: true},
+		Title:        title,
+		Description:  &description,
+		DueDate:      &dueDate,
+		ReminderDate: &dueDate,
+		AssignedToId: &callback.EmployeeId,
+	})
+}
+
+// queueCallbackDigest queues a mail listing the call backs of the day to the employee
+func (svc enquiryService) queueCallbackDigest(ctx *context.Context, companyName string, callbacks []responseModel.EnquiryCallback) *errs.XError {
+	employee := callbacks[0]
+	if util.IsNilOrEmptyString(&employee.EmployeeEmail) {
+		return nil
+	}
+
+	var rows strings.Builder
+	for _, callback := range callbacks {
+		comment := callback.EmployeeComment
+		if comment == "" {
+			comment = callback.CustomerComment
+		}
+		fmt.Fprintf(&rows, "<tr><td>#%d %s</td><td>%s</td><td>%s</td></tr>",
+			callback.EnquiryId, html.EscapeString(callback.Subject),
+			html.EscapeString(callbackContact(callback)),
+			html.EscapeString(comment))
+	}
+
+	fileName := constants.CALLBACK_DIGEST_HTML_TEMPLATE
+	return svc.notificationSvc.CreateEmailNotification(ctx, requestModel.EmaiNotification{
+		Notification: &requestModel.Notification{
+			SourceEntity: string(entities.Entity_User),
+			EntityId:     employee.EmployeeId,
+		},
+		EmailContent: &email.EmailContent{
+			To:                   []string{employee.EmployeeEmail},
+			Subject:              fmt.Sprintf("%d enquiry call backs for today", len(callbacks)),
+			HtmlTemplateFileName: &fileName,
+			TemplateValueMap: map[string]string{
+				"**COMPANY_NAME**":   companyName,
+				"**USER_NAME**":      employee.EmployeeName,
+				"**CALLBACK_COUNT**": fmt.Sprintf("%d", len(callbacks)),
+				"**CALLBACK_ROWS**":  rows.String(),
+			},
+		},
+	})
+}
+
+func callbackContact(callback responseModel.EnquiryCallback) string {
+	contact := callback.CustomerName
+	if callback.CustomerPhone != "" {
+		contact = strings.TrimSpace(fmt.Sprintf("%s (%s)", contact, callback.CustomerPhone))
+	}
+	if contact == "" {
+		contact = "the customer"
+	}
+	return contact
+}
@@ -20,13 +20,19 @@ type ExpenseTrackerService interface {
 
 type expenseTrackerService struct {
 	expenseTrackerRepo repository.ExpenseTrackerRepository
+	inventoryRepo      repository.InventoryRepository
+	vendorRepo         repository.VendorRepository
+	budgetRepo         repository.BudgetRepository
 	mapper             mapper.Mapper
 	respMapper         mapper.ResponseMapper
 }
 
-func ProvideExpenseTrackerService(repo repository.ExpenseTrackerRepository, mapper mapper.Mapper, respMapper mapper.ResponseMapper) ExpenseTrackerService {
+func ProvideExpenseTrackerService(repo repository.ExpenseTrackerRepository, inventoryRepo repository.InventoryRepository, vendorRepo repository.VendorRepository, budgetRepo repository.BudgetRepository, mapper mapper.Mapper, respMapper mapper.ResponseMapper) ExpenseTrackerService {
 	return expenseTrackerService{
 		expenseTrackerRepo: repo,
+		inventoryRepo:      inventoryRepo,
+		vendorRepo:         vendorRepo,
+		budgetRepo:         budgetRepo,
 		mapper:             mapper,
 		respMapper:         respMapper,
 	}
@@ -38,7 +44,17 @@ func (svc expenseTrackerService) SaveExpenseTracker(ctx *context.Context, expens
 		return errs.NewXError(errs.INVALID_REQUEST, "Unable to save expense tracker", err)
 	}
 
-	errr := svc.expenseTrackerRepo.Create(ctx, dbExpenseTracker)
+	errr := checkExpenseCategory(ctx, svc.budgetRepo, dbExpenseTracker.CategoryId)
+	if errr != nil {
+		return errr
+	}
+
+	errr = linkExpenseVendor(ctx, svc.vendorRepo, dbExpenseTracker)
+	if errr != nil {
+		return errr
+	}
+
+	errr = svc.expenseTrackerRepo.Create(ctx, dbExpenseTracker)
 	if errr != nil {
 		return errr
 	}
@@ -52,8 +68,27 @@ func (svc expenseTrackerService) UpdateExpenseTracker(ctx *context.Context, expe
 		return errs.NewXError(errs.INVALID_REQUEST, "Unable to update expense tracker", err)
 	}
 
+	oldExpenseTracker, errr := svc.expenseTrackerRepo.Get(ctx, id)
+	if errr != nil {
+		return errr
+	}
+	if oldExpenseTracker.Model == nil {
+		return errs.NewXError(errs.NOT_EXIST, "Expense not found", nil)
+	}
+
 	dbExpenseTracker.ID = id
-	errr := svc.expenseTrackerRepo.Update(ctx, dbExpenseTracker)
+	dbExpenseTracker.RecurringExpenseId = oldExpenseTracker.RecurringExpenseId
+	errr = checkExpenseCategory(ctx, svc.budgetRepo, dbExpenseTracker.CategoryId)
+	if errr != nil {
+		return errr
+	}
+
+	errr = linkExpenseVendor(ctx, svc.vendorRepo, dbExpenseTracker)
+	if errr != nil {
+		return errr
+	}
+
+	errr = svc.expenseTrackerRepo.Update(ctx, dbExpenseTracker)
 	if errr != nil {
 		return errr
 	}
@@ -94,5 +129,6 @@ func (svc expenseTrackerService) Delete(ctx *context.Context, id uint) *errs.XEr
 		return err
 	}
 
-	return nil
+	// The stock bought with the bill goes along with it
+	return svc.inventoryRepo.DeleteMovementsByExpenseId(ctx, id)
 }
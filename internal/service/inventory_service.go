@@ -0,0 +1,414 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/imkarthi24/sf-backend/internal/constants"
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/mapper"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	responseModel "github.com/imkarthi24/sf-backend/internal/model/response"
+	"github.com/imkarthi24/sf-backend/internal/repository"
+	"github.com/imkarthi24/sf-backend/internal/utils"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/loop-kar/pixie/util"
+)
+
+// lowStockDefaultKey is the threshold of the materials which are not listed in the master config
+const lowStockDefaultKey = "*"
+
+type InventoryService interface {
+	SaveMaterial(*context.Context, requestModel.Material) *errs.XError
+	UpdateMaterial(*context.Context, requestModel.Material, uint) *errs.XError
+	GetMaterial(*context.Context, uint) (*responseModel.Material, *errs.XError)
+	GetMaterials(*context.Context, string) ([]responseModel.Material, *errs.XError)
+	DeleteMaterial(*context.Context, uint) *errs.XError
+
+	SaveMovement(*context.Context, requestModel.StockMovement) *errs.XError
+	GetMovements(*context.Context, requestModel.StockMovementFilter) ([]responseModel.StockMovement, *errs.XError)
+	DeleteMovement(*context.Context, uint) *errs.XError
+
+	GetStockLevels(*context.Context, bool) ([]responseModel.StockLevel, *errs.XError)
+	CheckLowStock(*context.Context) *errs.XError
+}
+
+type inventoryService struct {
+	inventoryRepo   repository.InventoryRepository
+	expenseRepo     repository.ExpenseTrackerRepository
+	orderItemRepo   repository.OrderItemRepository
+	taskRepo        repository.TaskRepository
+	channelRepo     repository.ChannelRepository
+	masterConfigSvc MasterConfigService
+	mapper          mapper.Mapper
+	respMapper      mapper.ResponseMapper
+}
+
+func ProvideInventoryService(repo repository.InventoryRepository, expenseRepo repository.ExpenseTrackerRepository, orderItemRepo repository.OrderItemRepository, taskRepo repository.TaskRepository, channelRepo repository.ChannelRepository, masterConfigSvc MasterConfigService, mapper mapper.Mapper, respMapper mapper.ResponseMapper) InventoryService {
+	return inventoryService{
+		inventoryRepo:   repo,
+		expenseRepo:     expenseRepo,
+		orderItemRepo:   orderItemRepo,
+		taskRepo:        taskRepo,
+		channelRepo:     channelRepo,
+		masterConfigSvc: masterConfigSvc,
+		mapper:          mapper,
+		respMapper:      respMapper,
+	}
+}
+
+func (svc inventoryService) SaveMaterial(ctx *context.Context, material requestModel.Material) *errs.XError {
+	dbMaterial, err := svc.mapper.Material(material)
+	if err != nil {
+		return errs.NewXError(errs.INVALID_REQUEST, "Unable to save material", err)
+	}
+
+	errr := svc.prepareMaterial(ctx, dbMaterial, 0)
+	if errr != nil {
+		return errr
+	}
+
+	return svc.inventoryRepo.CreateMaterial(ctx, dbMaterial)
+}
+
+func (svc inventoryService) UpdateMaterial(ctx *context.Context, material requestModel.Material, id uint) *errs.XError {
+	oldMaterial, err := svc.inventoryRepo.GetMaterial(ctx, id)
+	if err != nil {
+		return err
+	}
+	if oldMaterial.Model == nil {
+		return errs.NewXError(errs.NOT_EXIST, "Material not found", nil)
+	}
+
+	dbMaterial, mapErr := svc.mapper.Material(material)
+	if mapErr != nil {
+		return errs.NewXError(errs.INVALID_REQUEST, "Unable to update material", mapErr)
+	}
+
+	dbMaterial.ID = id
+	err = svc.prepareMaterial(ctx, dbMaterial, id)
+	if err != nil {
+		return err
+	}
+
+	return svc.inventoryRepo.UpdateMaterial(ctx, dbMaterial)
+}
+
+func (svc inventoryService) GetMaterial(ctx *context.Context, id uint) (*responseModel.Material, *errs.XError) {
+	material, err := svc.inventoryRepo.GetMaterial(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if material.Model == nil {
+		return nil, errs.NewXError(errs.NOT_EXIST, "Material not found", nil)
+	}
+
+	mappedMaterial, mapErr := svc.respMapper.Material(material)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map Material data", mapErr)
+	}
+
+	return mappedMaterial, nil
+}
+
+func (svc inventoryService) GetMaterials(ctx *context.Context, search string) ([]responseModel.Material, *errs.XError) {
+	materials, err := svc.inventoryRepo.GetMaterials(ctx, search)
+	if err != nil {
+		return nil, err
+	}
+
+	mappedMaterials, mapErr := svc.respMapper.Materials(materials)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map Material data", mapErr)
+	}
+
+	return mappedMaterials, nil
+}
+
+// DeleteMaterial removes a material from the catalogue, only once it is out of stock
+func (svc inventoryService) DeleteMaterial(ctx *context.Context, id uint) *errs.XError {
+	onHand, err := svc.inventoryRepo.GetOnHand(ctx, id)
+	if err != nil {
+		return err
+	}
+	if onHand != 0 {
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Material with %.2f in stock cannot be deleted, adjust the stock to zero first", onHand), nil)
+	}
+
+	return svc.inventoryRepo.DeleteMaterial(ctx, id)
+}
+
+// SaveMovement records a change in the stock of a material.
+// A stock in can be against an expense bill, a consumption must be against an order item and cannot take the stock below zero.
+func (svc inventoryService) SaveMovement(ctx *context.Context, request requestModel.StockMovement) *errs.XError {
+	movement, mapErr := svc.mapper.StockMovement(request)
+	if mapErr != nil {
+		return errs.NewXError(errs.INVALID_REQUEST, "Unable to save stock movement", mapErr)
+	}
+
+	material, err := svc.inventoryRepo.GetMaterial(ctx, movement.MaterialId)
+	if err != nil {
+		return err
+	}
+	if material.Model == nil {
+		return errs.NewXError(errs.NOT_EXIST, "Material not found", nil)
+	}
+
+	if movement.UnitCost != nil && *movement.UnitCost < 0 {
+		return errs.NewXError(errs.VALIDATION, "Unit cost cannot be negative", nil)
+	}
+
+	switch movement.Type {
+	case entities.StockMovementTypeIn:
+		if movement.Quantity <= 0 {
+			return errs.NewXError(errs.VALIDATION, "Quantity should be more than zero", nil)
+		}
+		if movement.OrderItemId != nil {
+			return errs.NewXError(errs.VALIDATION, "Stock in cannot be against an order item", nil)
+		}
+		if movement.ExpenseId != nil {
+			expense, err := svc.expenseRepo.Get(ctx, *movement.ExpenseId)
+			if err != nil {
+				return err
+			}
+			if expense.Model == nil {
+				return errs.NewXError(errs.NOT_EXIST, "Expense not found", nil)
+			}
+		}
+
+	case entities.StockMovementTypeOut:
+		if movement.Quantity <= 0 {
+			return errs.NewXError(errs.VALIDATION, "Quantity should be more than zero", nil)
+		}
+		if movement.OrderItemId == nil {
+			return errs.NewXError(errs.VALIDATION, "Order item is required to consume stock", nil)
+		}
+		if movement.ExpenseId != nil {
+			return errs.NewXError(errs.VALIDATION, "Stock consumption cannot be against an expense", nil)
+		}
+		orderItem, err := svc.orderItemRepo.Get(ctx, *movement.OrderItemId)
+		if err != nil {
+			return err
+		}
+		// the order item is not scoped to the channel, the stock would be consumed against the order of another channel
+		if channelId := utils.GetChannelId(ctx); orderItem.Model == nil || (channelId != 0 && orderItem.ChannelId != channelId) {
+			return errs.NewXError(errs.NOT_EXIST, "Order item not found", nil)
+		}
+		movement.Quantity = -movement.Quantity
+
+	case entities.StockMovementTypeAdjustment:
+		if util.IsNilOrEmptyString(movement.Notes) {
+			return errs.NewXError(errs.VALIDATION, "Reason is required in the notes for a stock adjustment", nil)
+		}
+		if movement.ExpenseId != nil || movement.OrderItemId != nil {
+			return errs.NewXError(errs.VALIDATION, "Stock adjustment cannot be against an expense or an order item", nil)
+		}
+
+	default:
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Invalid stock movement type %s", request.Type), nil)
+	}
+
+	if movement.Quantity < 0 {
+		onHand, err := svc.inventoryRepo.GetOnHand(ctx, movement.MaterialId)
+		if err != nil {
+			return err
+		}
+		if onHand+movement.Quantity < 0 {
+			return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Only %.2f %s of %s is in stock", onHand, material.Unit, material.Name), nil)
+		}
+	}
+
+	return svc.inventoryRepo.CreateMovement(ctx, movement)
+}
+
+func (svc inventoryService) GetMovements(ctx *context.Context, filter requestModel.StockMovementFilter) ([]responseModel.StockMovement, *errs.XError) {
+	movements, err := svc.inventoryRepo.GetMovements(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	mappedMovements, mapErr := svc.respMapper.StockMovements(movements)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map StockMovement data", mapErr)
+	}
+
+	return mappedMovements, nil
+}
+
+// DeleteMovement removes a wrongly recorded movement, as long as the stock does not go below zero
+func (svc inventoryService) DeleteMovement(ctx *context.Context, id uint) *errs.XError {
+	movement, err := svc.inventoryRepo.GetMovement(ctx, id)
+	if err != nil {
+		return err
+	}
+	if movement.Model == nil {
+		return errs.NewXError(errs.NOT_EXIST, "Stock movement not found", nil)
+	}
+
+	if movement.Quantity > 0 {
+		onHand, err := svc.inventoryRepo.GetOnHand(ctx, movement.MaterialId)
+		if err != nil {
+			return err
+		}
+		if onHand-movement.Quantity < 0 {
+			return errs.NewXError(errs.VALIDATION, "The stock of this movement is already consumed, it cannot be deleted", nil)
+		}
+	}
+
+	return svc.inventoryRepo.DeleteMovement(ctx, id)
+}
+
+// GetStockLevels returns the on hand quantity of the materials along with their low stock threshold
+func (svc inventoryService) GetStockLevels(ctx *context.Context, lowOnly bool) ([]responseModel.StockLevel, *errs.XError) {
+	levels, err := svc.inventoryRepo.GetStockLevels(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	applyLowStockThresholds(levels, svc.getLowStockThresholds(ctx))
+	if !lowOnly {
+		return levels, nil
+	}
+
+	lowLevels := make([]responseModel.StockLevel, 0)
+	for _, level := range levels {
+		if level.IsLowStock {
+			lowLevels = append(lowLevels, level)
+		}
+	}
+	return lowLevels, nil
+}
+
+// CheckLowStock raises a task to the owner of each channel for every material running low on stock.
+// An open reminder of a material is refreshed with the current stock instead of raising another one.
+func (svc inventoryService) CheckLowStock(ctx *context.Context) *errs.XError {
+	levels, err := svc.inventoryRepo.GetStockLevels(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	// levels are ordered by channel, check them a channel at a time
+	var lastErr *errs.XError
+	for start := 0; start < len(levels); {
+		end := start
+		for end < len(levels) && levels[end].ChannelId == levels[start].ChannelId {
+			end++
+		}
+
+		err = svc.checkChannelLowStock(ctx, levels[start].ChannelId, levels[start:end])
+		if err != nil {
+			lastErr = err
+		}
+		start = end
+	}
+
+	return lastErr
+}
+
+func (svc inventoryService) checkChannelLowStock(ctx *context.Context, channelId uint, levels []responseModel.StockLevel) *errs.XError {
+	channel, err := svc.channelRepo.Get(ctx, channelId)
+	if err != nil {
+		return err
+	}
+	if channel.Model == nil || channel.OwnerUserID == 0 {
+		return nil
+	}
+
+	channelCtx := utils.NewSystemContext(channelId, channel.OwnerUserID)
+	applyLowStockThresholds(levels, svc.getLowStockThresholds(&channelCtx))
+
+	for _, level := range levels {
+		if !level.IsLowStock {
+			continue
+		}
+
+		err = svc.raiseLowStockReminder(&channelCtx, level, channel.OwnerUserID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (svc inventoryService) raiseLowStockReminder(ctx *context.Context, level responseModel.StockLevel, ownerId uint) *errs.XError {
+	title := fmt.Sprintf("Low stock: %s", level.Material)
+	description := fmt.Sprintf("%s is down to %.2f %s, the low stock threshold is %.2f %s", level.Material, level.OnHand, level.Unit, *level.LowStockThreshold, level.Unit)
+
+	task, err := svc.taskRepo.GetOpenByTitle(ctx, title, ownerId)
+	if err != nil {
+		return err
+	}
+	if task.Model != nil {
+		task.Description = &description
+		return svc.taskRepo.Update(ctx, task)
+	}
+
+	now := util.GetLocalTime()
+	return svc.taskRepo.Create(ctx, &entities.Task{
+		Model:        &entities.Model{IsActive: true},
+		Title:        title,
+		Description:  &description,
+		DueDate:      &now,
+		ReminderDate: &now,
+		AssignedToId: &ownerId,
+	})
+}
+
+func (svc inventoryService) prepareMaterial(ctx *context.Context, material *entities.Material, id uint) *errs.XError {
+	material.Name = strings.TrimSpace(material.Name)
+	material.Unit = strings.ToUpper(strings.TrimSpace(material.Unit))
+	if material.Name == "" || material.Unit == "" {
+		return errs.NewXError(errs.VALIDATION, "Name and unit of the material are required", nil)
+	}
+
+	existing, err := svc.inventoryRepo.GetMaterialByName(ctx, material.Name)
+	if err != nil {
+		return err
+	}
+	if existing.Model != nil && existing.ID != id {
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Material %s already exists", material.Name), nil)
+	}
+	return nil
+}
+
+// getLowStockThresholds returns the thresholds of the channel from master config keyed by the lower cased
+// material code or name, eg: {"FAB-001": 10, "lining": 25, "*": 5}. Nil when not configured or invalid.
+func (svc inventoryService) getLowStockThresholds(ctx *context.Context) map[string]float64 {
+	value, err := svc.masterConfigSvc.GetByName(ctx, constants.CONFIG_INVENTORY_LOW_STOCK_THRESHOLDS)
+	if err != nil || util.IsNilOrEmptyString(&value) {
+		return nil
+	}
+
+	configured := make(map[string]float64)
+	jsonErr := json.Unmarshal([]byte(value), &configured)
+	if jsonErr != nil {
+		return nil
+	}
+
+	thresholds := make(map[string]float64, len(configured))
+	for key, threshold := range configured {
+		thresholds[strings.ToLower(strings.TrimSpace(key))] = threshold
+	}
+	return thresholds
+}
+
+// applyLowStockThresholds sets the threshold of each material, the code takes precedence over the name.
+// A material is low on stock once it is at or below its threshold.
+func applyLowStockThresholds(levels []responseModel.StockLevel, thresholds map[string]float64) {
+	for i := range levels {
+		keys := []string{strings.ToLower(levels[i].Material), lowStockDefaultKey}
+		if levels[i].Code != nil {
+			keys = append([]string{strings.ToLower(*levels[i].Code)}, keys...)
+		}
+
+		for _, key := range keys {
+			if threshold, ok := thresholds[key]; ok {
+				levels[i].LowStockThreshold = &threshold
+				levels[i].IsLowStock = levels[i].OnHand <= threshold
+				break
+			}
+		}
+	}
+}
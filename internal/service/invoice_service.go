@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/imkarthi24/sf-backend/internal/constants"
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/repository"
+	"github.com/imkarthi24/sf-backend/internal/utils"
+	"github.com/imkarthi24/sf-backend/internal/utils/pdf"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/loop-kar/pixie/util"
+)
+
+type InvoiceService interface {
+	GenerateInvoice(*context.Context, uint) ([]byte, string, *errs.XError)
+}
+
+type invoiceService struct {
+	invoiceRepo     repository.InvoiceRepository
+	orderRepo       repository.OrderRepository
+	masterConfigSvc MasterConfigService
+}
+
+func ProvideInvoiceService(repo repository.InvoiceRepository, orderRepo repository.OrderRepository, masterConfigSvc MasterConfigService) InvoiceService {
+	return invoiceService{
+		invoiceRepo:     repo,
+		orderRepo:       orderRepo,
+		masterConfigSvc: masterConfigSvc,
+	}
+}
+
+// GenerateInvoice renders the invoice PDF of an order and returns the content along with the file name.
+// Invoice number is allocated the first time an invoice is generated for the order and reused afterwards.
+func (svc invoiceService) GenerateInvoice(ctx *context.Context, orderId uint) ([]byte, string, *errs.XError) {
+	order, err := svc.orderRepo.Get(ctx, orderId)
+	if err != nil {
+		return nil, "", err
+	}
+	// the order is not scoped to the channel, the invoice would take the number and the branding of the caller's channel
+	if channelId := utils.GetChannelId(ctx); order.Model == nil || (channelId != 0 && order.ChannelId != channelId) {
+		return nil, "", errs.NewXError(errs.NOT_EXIST, "Order not found", nil)
+	}
+	if order.Status == entities.CANCELLED {
+		return nil, "", errs.NewXError(errs.INVALID, "Invoice cannot be generated for a cancelled order", nil)
+	}
+
+	invoice, err := svc.getOrCreateInvoice(ctx, order.ID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	template, err := svc.loadTemplate(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	content, pdfErr := pdf.GenerateInvoice(svc.buildInvoice(ctx, order, invoice), *template)
+	if pdfErr != nil {
+		return nil, "", errs.NewXError(errs.INTERNAL, "Unable to generate invoice", pdfErr)
+	}
+
+	return content, fmt.Sprintf("%s.pdf", invoice.InvoiceNumber), nil
+}
+
+func (svc invoiceService) getOrCreateInvoice(ctx *context.Context, orderId uint) (*entities.Invoice, *errs.XError) {
+	invoice, err := svc.invoiceRepo.GetByOrderId(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+	if invoice != nil {
+		return invoice, nil
+	}
+
+	sequence, err := svc.invoiceRepo.GetNextSequence(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := svc.getConfigValue(ctx, constants.CONFIG_INVOICE_PREFIX, constants.DEFAULT_INVOICE_PREFIX)
+
+	invoice = &entities.Invoice{
+		Model:         &entities.Model{IsActive: true},
+		Sequence:      sequence,
+		InvoiceNumber: fmt.Sprintf("%s-%05d", prefix, sequence),
+		InvoiceDate:   util.GetLocalTime(),
+		OrderId:       orderId,
+	}
+
+	err = svc.invoiceRepo.Create(ctx, invoice)
+	if err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+// loadTemplate loads the channel's invoice template, falling back to the default template
+func (svc invoiceService) loadTemplate(ctx *context.Context) (*pdf.InvoiceTemplate, *errs.XError) {
+	templateName := svc.getConfigValue(ctx, constants.CONFIG_INVOICE_TEMPLATE, constants.DEFAULT_INVOICE_TEMPLATE)
+
+	template, err := pdf.LoadInvoiceTemplate(constants.INVOICE_TEMPLATE_DIR, templateName)
+	if err != nil && templateName != constants.DEFAULT_INVOICE_TEMPLATE {
+		template, err = pdf.LoadInvoiceTemplate(constants.INVOICE_TEMPLATE_DIR, constants.DEFAULT_INVOICE_TEMPLATE)
+	}
+	if err != nil {
+		return nil, errs.NewXError(errs.IO, "Unable to load invoice template", err)
+	}
+
+	return template, nil
+}
+
+func (svc invoiceService) buildInvoice(ctx *context.Context, order *entities.Order, invoice *entities.Invoice) pdf.Invoice {
+	channelName := ""
+	if session := utils.GetSession(ctx); session != nil {
+		channelName = session.ChannelName
+	}
+
+	result := pdf.Invoice{
+		InvoiceNumber: invoice.InvoiceNumber,
+		InvoiceDate:   invoice.InvoiceDate,
+		OrderId:       order.ID,
+		Channel: pdf.InvoiceParty{
+			Name:        svc.getConfigValue(ctx, constants.CONFIG_CHANNEL_NAME, channelName),
+			Address:     svc.getConfigValue(ctx, constants.CONFIG_CHANNEL_ADDRESS, ""),
+			PhoneNumber: svc.getConfigValue(ctx, constants.CONFIG_CHANNEL_PHONE, ""),
+			Email:       svc.getConfigValue(ctx, constants.CONFIG_CHANNEL_EMAIL, ""),
+			TaxNumber:   svc.getConfigValue(ctx, constants.CONFIG_CHANNEL_GSTIN, ""),
+		},
+		AdditionalCharges: order.AdditionalCharges,
+		PaidAmount:        order.PaidAmount,
+	}
+
+	if order.Customer != nil {
+		result.Customer = pdf.InvoiceParty{
+			Name:        order.Customer.FirstName + " " + order.Customer.LastName,
+			Address:     order.Customer.Address,
+			PhoneNumber: order.Customer.PhoneNumber,
+			Email:       order.Customer.Email,
+		}
+	}
+
+	for _, item := range order.OrderItems {
+		description := item.Description
+		if item.Measurement != nil && item.Measurement.DressType != nil {
+			description = fmt.Sprintf("%s - %s", item.Measurement.DressType.Name, item.Description)
+		}
+
+		result.Items = append(result.Items, pdf.InvoiceItem{
+			Description:       description,
+			Quantity:          item.Quantity,
+			Price:             item.Price,
+			AdditionalCharges: item.AdditionalCharges,
+			Total:             item.Total,
+		})
+		result.SubTotal += item.Total
+	}
+
+	result.GrandTotal = result.SubTotal + result.AdditionalCharges
+	result.BalanceAmount = result.GrandTotal - result.PaidAmount
+
+	return result
+}
+
+func (svc invoiceService) getConfigValue(ctx *context.Context, name string, defaultValue string) string {
+	value, err := svc.masterConfigSvc.GetByName(ctx, name)
+	if err != nil || util.IsNilOrEmptyString(&value) {
+		return defaultValue
+	}
+	return value
+}
@@ -2,30 +2,36 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 
+	"github.com/imkarthi24/sf-backend/internal/entities"
 	"github.com/imkarthi24/sf-backend/internal/mapper"
 	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
 	responseModel "github.com/imkarthi24/sf-backend/internal/model/response"
 	"github.com/imkarthi24/sf-backend/internal/repository"
+	measurementUtil "github.com/imkarthi24/sf-backend/internal/utils/measurement"
 	"github.com/loop-kar/pixie/errs"
 )
 
 type MeasurementHistoryService interface {
 	SaveMeasurementHistory(*context.Context, requestModel.MeasurementHistory) *errs.XError
-	Get(*context.Context, uint) (*responseModel.MeasurementHistory, *errs.XError)
-	GetAll(*context.Context, string) ([]responseModel.MeasurementHistory, *errs.XError)
-	GetByMeasurementId(*context.Context, uint) ([]responseModel.MeasurementHistory, *errs.XError)
+	Get(*context.Context, uint, string) (*responseModel.MeasurementHistory, *errs.XError)
+	GetAll(*context.Context, string, string) ([]responseModel.MeasurementHistory, *errs.XError)
+	GetByMeasurementId(*context.Context, uint, string) ([]responseModel.MeasurementHistory, *errs.XError)
+	Diff(*context.Context, uint, *uint, string) (*responseModel.MeasurementHistoryDiff, *errs.XError)
 }
 
 type measurementHistoryService struct {
 	measurementHistoryRepo repository.MeasurementHistoryRepository
+	masterConfigSvc        MasterConfigService
 	mapper                 mapper.Mapper
 	respMapper             mapper.ResponseMapper
 }
 
-func ProvideMeasurementHistoryService(repo repository.MeasurementHistoryRepository, mapper mapper.Mapper, respMapper mapper.ResponseMapper) MeasurementHistoryService {
+func ProvideMeasurementHistoryService(repo repository.MeasurementHistoryRepository, masterConfigSvc MasterConfigService, mapper mapper.Mapper, respMapper mapper.ResponseMapper) MeasurementHistoryService {
 	return measurementHistoryService{
 		measurementHistoryRepo: repo,
+		masterConfigSvc:        masterConfigSvc,
 		mapper:                 mapper,
 		respMapper:             respMapper,
 	}
@@ -45,12 +51,25 @@ func (svc measurementHistoryService) SaveMeasurementHistory(ctx *context.Context
 	return nil
 }
 
-func (svc measurementHistoryService) Get(ctx *context.Context, id uint) (*responseModel.MeasurementHistory, *errs.XError) {
+func (svc measurementHistoryService) Get(ctx *context.Context, id uint, unit string) (*responseModel.MeasurementHistory, *errs.XError) {
+	channelUnit := getChannelMeasurementUnit(ctx, svc.masterConfigSvc)
+	targetUnit, err := parseMeasurementUnit(unit, channelUnit)
+	if err != nil {
+		return nil, err
+	}
+
 	measurementHistory, err := svc.measurementHistoryRepo.Get(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
+	if measurementHistory.Model != nil {
+		err = convertMeasurementHistoryUnit(measurementHistory, channelUnit, targetUnit)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	mappedMeasurementHistory, mapErr := svc.respMapper.MeasurementHistory(measurementHistory)
 	if mapErr != nil {
 		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map MeasurementHistory data", mapErr)
@@ -59,12 +78,25 @@ func (svc measurementHistoryService) Get(ctx *context.Context, id uint) (*respon
 	return mappedMeasurementHistory, nil
 }
 
-func (svc measurementHistoryService) GetAll(ctx *context.Context, search string) ([]responseModel.MeasurementHistory, *errs.XError) {
+func (svc measurementHistoryService) GetAll(ctx *context.Context, search string, unit string) ([]responseModel.MeasurementHistory, *errs.XError) {
+	channelUnit := getChannelMeasurementUnit(ctx, svc.masterConfigSvc)
+	targetUnit, err := parseMeasurementUnit(unit, channelUnit)
+	if err != nil {
+		return nil, err
+	}
+
 	measurementHistories, err := svc.measurementHistoryRepo.GetAll(ctx, search)
 	if err != nil {
 		return nil, err
 	}
 
+	for i := range measurementHistories {
+		err = convertMeasurementHistoryUnit(&measurementHistories[i], channelUnit, targetUnit)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	mappedMeasurementHistories, mapErr := svc.respMapper.MeasurementHistories(measurementHistories)
 	if mapErr != nil {
 		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map MeasurementHistory data", mapErr)
@@ -73,12 +105,25 @@ func (svc measurementHistoryService) GetAll(ctx *context.Context, search string)
 	return mappedMeasurementHistories, nil
 }
 
-func (svc measurementHistoryService) GetByMeasurementId(ctx *context.Context, measurementId uint) ([]responseModel.MeasurementHistory, *errs.XError) {
+func (svc measurementHistoryService) GetByMeasurementId(ctx *context.Context, measurementId uint, unit string) ([]responseModel.MeasurementHistory, *errs.XError) {
+	channelUnit := getChannelMeasurementUnit(ctx, svc.masterConfigSvc)
+	targetUnit, err := parseMeasurementUnit(unit, channelUnit)
+	if err != nil {
+		return nil, err
+	}
+
 	measurementHistories, err := svc.measurementHistoryRepo.GetByMeasurementId(ctx, measurementId)
 	if err != nil {
 		return nil, err
 	}
 
+	for i := range measurementHistories {
+		err = convertMeasurementHistoryUnit(&measurementHistories[i], channelUnit, targetUnit)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	mappedMeasurementHistories, mapErr := svc.respMapper.MeasurementHistories(measurementHistories)
 	if mapErr != nil {
 		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map MeasurementHistory data", mapErr)
@@ -86,3 +131,112 @@ func (svc measurementHistoryService) GetByMeasurementId(ctx *context.Context, me
 
 	return mappedMeasurementHistories, nil
 }
+
+// Diff compares the values of the measurement before the history against the values before another history of the
+// same measurement, or against the current values when compareToId is not given
+func (svc measurementHistoryService) Diff(ctx *context.Context, id uint, compareToId *uint, unit string) (*responseModel.MeasurementHistoryDiff, *errs.XError) {
+	channelUnit := getChannelMeasurementUnit(ctx, svc.masterConfigSvc)
+	targetUnit, err := parseMeasurementUnit(unit, channelUnit)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := svc.getHistoryInUnit(ctx, id, channelUnit, targetUnit)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := responseModel.MeasurementHistoryDiff{
+		MeasurementId: history.MeasurementId,
+		Unit:          string(targetUnit),
+		From:          measurementVersion(history),
+	}
+
+	if compareToId != nil {
+		compareTo, err := svc.getHistoryInUnit(ctx, *compareToId, channelUnit, targetUnit)
+		if err != nil {
+			return nil, err
+		}
+		if compareTo.MeasurementId != history.MeasurementId {
+			return nil, errs.NewXError(errs.INVALID_REQUEST, "Measurement histories should be of the same measurement", nil)
+		}
+		diff.To = measurementVersion(compareTo)
+	} else {
+		if history.Measurement == nil || history.Measurement.Model == nil {
+			return nil, errs.NewXError(errs.NOT_EXIST, "Measurement not found", nil)
+		}
+		diff.To = responseModel.MeasurementVersion{Values: json.RawMessage(history.Measurement.Value)}
+	}
+
+	changes, diffErr := measurementUtil.Diff(diff.From.Values, diff.To.Values)
+	if diffErr != nil {
+		return nil, errs.NewXError(errs.INVALID_REQUEST, "Unable to compare measurement values", diffErr)
+	}
+
+	diff.Changes = make([]responseModel.MeasurementFieldChange, 0, len(changes))
+	for _, change := range changes {
+		diff.Changes = append(diff.Changes, responseModel.MeasurementFieldChange{
+			Field:    change.Field,
+			Change:   string(change.Change),
+			OldValue: change.OldValue,
+			NewValue: change.NewValue,
+		})
+	}
+
+	return &diff, nil
+}
+
+func (svc measurementHistoryService) getHistoryInUnit(ctx *context.Context, id uint, channelUnit entities.MeasurementUnit, targetUnit entities.MeasurementUnit) (*entities.MeasurementHistory, *errs.XError) {
+	history, err := svc.measurementHistoryRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if history.Model == nil {
+		return nil, errs.NewXError(errs.NOT_EXIST, "Measurement history not found", nil)
+	}
+
+	err = convertMeasurementHistoryUnit(history, channelUnit, targetUnit)
+	if err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// measurementVersion is the values of the measurement before the action of the history
+func measurementVersion(history *entities.MeasurementHistory) responseModel.MeasurementVersion {
+	historyId := history.ID
+	performedAt := history.PerformedAt
+	return responseModel.MeasurementVersion{
+		HistoryId:   &historyId,
+		Action:      string(history.Action),
+		PerformedAt: &performedAt,
+		Values:      json.RawMessage(history.OldValues),
+	}
+}
+
+// convertMeasurementHistoryUnit converts the old values and the values of the measurement to the target unit,
+// histories recorded before units were tracked are in the channel unit
+func convertMeasurementHistoryUnit(history *entities.MeasurementHistory, channelUnit entities.MeasurementUnit, targetUnit entities.MeasurementUnit) *errs.XError {
+	var err *errs.XError
+	if len(history.OldValues) > 0 {
+		oldUnit := history.Unit
+		if oldUnit == "" {
+			oldUnit = channelUnit
+		}
+		history.OldValues, err = convertMeasurementValues(history.OldValues, oldUnit, targetUnit)
+		if err != nil {
+			return err
+		}
+		history.Unit = targetUnit
+	}
+
+	if history.Measurement != nil && history.Measurement.Model != nil {
+		history.Measurement.Value, err = convertMeasurementValues(history.Measurement.Value, storedMeasurementUnit(history.Measurement, channelUnit), targetUnit)
+		if err != nil {
+			return err
+		}
+		history.Measurement.Unit = targetUnit
+	}
+
+	return nil
+}
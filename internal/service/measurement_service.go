@@ -2,7 +2,10 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 
+	"github.com/imkarthi24/sf-backend/internal/constants"
 	"github.com/imkarthi24/sf-backend/internal/entities"
 	entitiy_types "github.com/imkarthi24/sf-backend/internal/entities/types"
 	"github.com/imkarthi24/sf-backend/internal/mapper"
@@ -10,6 +13,8 @@ import (
 	responseModel "github.com/imkarthi24/sf-backend/internal/model/response"
 	"github.com/imkarthi24/sf-backend/internal/repository"
 	"github.com/imkarthi24/sf-backend/internal/utils"
+	measurementUtil "github.com/imkarthi24/sf-backend/internal/utils/measurement"
+	"github.com/imkarthi24/sf-backend/internal/utils/validator"
 	"github.com/loop-kar/pixie/errs"
 	"github.com/loop-kar/pixie/util"
 )
@@ -19,22 +24,27 @@ type MeasurementService interface {
 	SaveBulkMeasurements(*context.Context, []requestModel.BulkMeasurementRequest) *errs.XError
 	UpdateMeasurement(*context.Context, requestModel.Measurement, uint) *errs.XError
 	BulkUpdateMeasurements(*context.Context, []requestModel.Measurement) *errs.XError
-	Get(*context.Context, uint) (*responseModel.Measurement, *errs.XError)
+	Get(*context.Context, uint, string) (*responseModel.Measurement, *errs.XError)
 	GetAll(*context.Context, string) ([]responseModel.MeasurementBrowse, *errs.XError)
 	Delete(*context.Context, uint) *errs.XError
+	Restore(*context.Context, uint, uint) *errs.XError
 }
 
 type measurementService struct {
 	measurementRepo        repository.MeasurementRepository
 	measurementHistoryRepo repository.MeasurementHistoryRepository
+	dressTypeRepo          repository.DressTypeRepository
+	masterConfigSvc        MasterConfigService
 	mapper                 mapper.Mapper
 	respMapper             mapper.ResponseMapper
 }
 
-func ProvideMeasurementService(repo repository.MeasurementRepository, measurementHistoryRepo repository.MeasurementHistoryRepository, mapper mapper.Mapper, respMapper mapper.ResponseMapper) MeasurementService {
+func ProvideMeasurementService(repo repository.MeasurementRepository, measurementHistoryRepo repository.MeasurementHistoryRepository, dressTypeRepo repository.DressTypeRepository, masterConfigSvc MasterConfigService, mapper mapper.Mapper, respMapper mapper.ResponseMapper) MeasurementService {
 	return measurementService{
 		measurementRepo:        repo,
 		measurementHistoryRepo: measurementHistoryRepo,
+		dressTypeRepo:          dressTypeRepo,
+		masterConfigSvc:        masterConfigSvc,
 		mapper:                 mapper,
 		respMapper:             respMapper,
 	}
@@ -46,19 +56,41 @@ func (svc measurementService) SaveMeasurement(ctx *context.Context, measurement
 		return errs.NewXError(errs.INVALID_REQUEST, "Unable to save measurement", err)
 	}
 
+	channelUnit := getChannelMeasurementUnit(ctx, svc.masterConfigSvc)
+	unit, errr := parseMeasurementUnit(measurement.Unit, channelUnit)
+	if errr != nil {
+		return errr
+	}
+
+	fieldErrs, errr := svc.validateValues(ctx, dbMeasurement.DressTypeId, json.RawMessage(dbMeasurement.Value), unit, map[uint]*entities.DressType{})
+	if errr != nil {
+		return errr
+	}
+	errr = fieldErrs.ToXError("Invalid measurement values")
+	if errr != nil {
+		return errr
+	}
+
+	// values are stored in the unit of the channel
+	dbMeasurement.Value, errr = convertMeasurementValues(dbMeasurement.Value, unit, channelUnit)
+	if errr != nil {
+		return errr
+	}
+	dbMeasurement.Unit = channelUnit
+
 	// Set TakenById to the current user if it's not provided in the request
 	if measurement.TakenById == nil {
 		userID := utils.GetUserId(ctx)
 		dbMeasurement.TakenById = &userID
 	}
 
-	errr := svc.measurementRepo.Create(ctx, dbMeasurement)
+	errr = svc.measurementRepo.Create(ctx, dbMeasurement)
 	if errr != nil {
 		return errr
 	}
 
 	// Record measurement history for CREATED action
-	errr = svc.recordMeasurementHistory(ctx, dbMeasurement.ID, entities.MeasurementHistoryActionCreated, nil)
+	errr = svc.recordMeasurementHistory(ctx, dbMeasurement.ID, entities.MeasurementHistoryActionCreated, nil, "")
 	if errr != nil {
 		return errr
 	}
@@ -70,11 +102,45 @@ func (svc measurementService) SaveBulkMeasurements(ctx *context.Context, bulkReq
 	var measurementsToCreate []*entities.Measurement
 	userID := utils.GetUserId(ctx)
 
-	for _, bulkRequest := range bulkRequests {
-		for _, measurementItem := range bulkRequest.Measurements {
+	channelUnit := getChannelMeasurementUnit(ctx, svc.masterConfigSvc)
+
+	fieldErrs := validator.FieldErrors{}
+	dressTypes := make(map[uint]*entities.DressType)
+	units := make([][]entities.MeasurementUnit, len(bulkRequests))
+	for i, bulkRequest := range bulkRequests {
+		requestUnit, errr := parseMeasurementUnit(bulkRequest.Unit, channelUnit)
+		if errr != nil {
+			return errr
+		}
+
+		units[i] = make([]entities.MeasurementUnit, len(bulkRequest.Measurements))
+		for j, measurementItem := range bulkRequest.Measurements {
+			unit, errr := parseMeasurementUnit(measurementItem.Unit, requestUnit)
+			if errr != nil {
+				return errr
+			}
+			units[i][j] = unit
+
+			itemErrs, errr := svc.validateValues(ctx, measurementItem.DressTypeId, measurementItem.Values, unit, dressTypes)
+			if errr != nil {
+				return errr
+			}
+			fieldErrs = append(fieldErrs, itemErrs.WithPrefix(fmt.Sprintf("[%d].measurements[%d].", i, j))...)
+		}
+	}
+	errr := fieldErrs.ToXError("Invalid measurement values")
+	if errr != nil {
+		return errr
+	}
+
+	for i, bulkRequest := range bulkRequests {
+		for j, measurementItem := range bulkRequest.Measurements {
 			var valuesJSON entitiy_types.JSON
 			if len(measurementItem.Values) > 0 {
-				valuesJSON = entitiy_types.JSON(measurementItem.Values)
+				valuesJSON, errr = convertMeasurementValues(entitiy_types.JSON(measurementItem.Values), units[i][j], channelUnit)
+				if errr != nil {
+					return errr
+				}
 			}
 
 			measurement := &entities.Measurement{
@@ -82,6 +148,7 @@ func (svc measurementService) SaveBulkMeasurements(ctx *context.Context, bulkReq
 					IsActive: true,
 				},
 				Value:       valuesJSON,
+				Unit:        channelUnit,
 				PersonId:    bulkRequest.PersonId,
 				DressTypeId: measurementItem.DressTypeId,
 				TakenById:   &userID,
@@ -92,14 +159,14 @@ func (svc measurementService) SaveBulkMeasurements(ctx *context.Context, bulkReq
 	}
 
 	// Batch create all measurements
-	errr := svc.measurementRepo.BatchCreate(ctx, measurementsToCreate)
+	errr = svc.measurementRepo.BatchCreate(ctx, measurementsToCreate)
 	if errr != nil {
 		return errr
 	}
 
 	// Record measurement history for each created measurement
 	for _, measurement := range measurementsToCreate {
-		errr = svc.recordMeasurementHistory(ctx, measurement.ID, entities.MeasurementHistoryActionCreated, nil)
+		errr = svc.recordMeasurementHistory(ctx, measurement.ID, entities.MeasurementHistoryActionCreated, nil, "")
 		if errr != nil {
 			return errr
 		}
@@ -122,6 +189,30 @@ func (svc measurementService) UpdateMeasurement(ctx *context.Context, measuremen
 		return errs.NewXError(errs.INVALID_REQUEST, "Unable to update measurement", mapErr)
 	}
 
+	channelUnit := getChannelMeasurementUnit(ctx, svc.masterConfigSvc)
+	unit, err := parseMeasurementUnit(measurement.Unit, channelUnit)
+	if err != nil {
+		return err
+	}
+
+	dressTypeId := dbMeasurement.DressTypeId
+	if dressTypeId == 0 {
+		dressTypeId = oldMeasurement.DressTypeId
+	}
+	fieldErrs, err := svc.validateValues(ctx, dressTypeId, json.RawMessage(dbMeasurement.Value), unit, map[uint]*entities.DressType{})
+	if err != nil {
+		return err
+	}
+	err = fieldErrs.ToXError("Invalid measurement values")
+	if err != nil {
+		return err
+	}
+
+	err = svc.normalizeValues(dbMeasurement, unit, channelUnit)
+	if err != nil {
+		return err
+	}
+
 	dbMeasurement.ID = id
 	// Set TakenById to the current user if it's not provided in the request
 	if measurement.TakenById == nil {
@@ -134,7 +225,7 @@ func (svc measurementService) UpdateMeasurement(ctx *context.Context, measuremen
 		return errr
 	}
 
-	errr = svc.recordMeasurementHistory(ctx, id, entities.MeasurementHistoryActionUpdated, &oldMeasurement.Value)
+	errr = svc.recordMeasurementHistory(ctx, id, entities.MeasurementHistoryActionUpdated, &oldMeasurement.Value, storedMeasurementUnit(oldMeasurement, channelUnit))
 	if errr != nil {
 		return errr
 	}
@@ -168,22 +259,51 @@ func (svc measurementService) BulkUpdateMeasurements(ctx *context.Context, measu
 		}
 	}
 
-	for _, measurement := range measurements {
+	channelUnit := getChannelMeasurementUnit(ctx, svc.masterConfigSvc)
+
+	fieldErrs := validator.FieldErrors{}
+	dressTypes := make(map[uint]*entities.DressType)
+	for i, measurement := range measurements {
 		if measurement.ID == 0 {
 			continue
 		}
 
+		unit, err := parseMeasurementUnit(measurement.Unit, channelUnit)
+		if err != nil {
+			return err
+		}
+
 		dbMeasurement, mapErr := svc.mapper.Measurement(measurement)
 		if mapErr != nil {
 			return errs.NewXError(errs.INVALID_REQUEST, "Unable to update measurement", mapErr)
 		}
 
+		dressTypeId := dbMeasurement.DressTypeId
+		if oldMeasurement, exists := oldMeasurementsMap[measurement.ID]; exists && dressTypeId == 0 {
+			dressTypeId = oldMeasurement.DressTypeId
+		}
+		itemErrs, err := svc.validateValues(ctx, dressTypeId, json.RawMessage(dbMeasurement.Value), unit, dressTypes)
+		if err != nil {
+			return err
+		}
+		fieldErrs = append(fieldErrs, itemErrs.WithPrefix(fmt.Sprintf("[%d].", i))...)
+
+		err = svc.normalizeValues(dbMeasurement, unit, channelUnit)
+		if err != nil {
+			return err
+		}
+
 		dbMeasurement.ID = measurement.ID
 		measurementsToUpdate = append(measurementsToUpdate, dbMeasurement)
 	}
 
+	errr := fieldErrs.ToXError("Invalid measurement values")
+	if errr != nil {
+		return errr
+	}
+
 	if len(measurementsToUpdate) > 0 {
-		errr := svc.measurementRepo.BatchUpdate(ctx, measurementsToUpdate)
+		errr = svc.measurementRepo.BatchUpdate(ctx, measurementsToUpdate)
 		if errr != nil {
 			return errr
 		}
@@ -192,7 +312,7 @@ func (svc measurementService) BulkUpdateMeasurements(ctx *context.Context, measu
 		for _, measurement := range measurementsToUpdate {
 			oldMeasurement, exists := oldMeasurementsMap[measurement.ID]
 			if exists {
-				errr = svc.recordMeasurementHistory(ctx, measurement.ID, entities.MeasurementHistoryActionUpdated, &oldMeasurement.Value)
+				errr = svc.recordMeasurementHistory(ctx, measurement.ID, entities.MeasurementHistoryActionUpdated, &oldMeasurement.Value, storedMeasurementUnit(oldMeasurement, channelUnit))
 				if errr != nil {
 					return errr
 				}
@@ -203,12 +323,27 @@ func (svc measurementService) BulkUpdateMeasurements(ctx *context.Context, measu
 	return nil
 }
 
-func (svc measurementService) Get(ctx *context.Context, id uint) (*responseModel.Measurement, *errs.XError) {
+// Get returns the measurement with the values in the given unit, the channel unit when it is empty
+func (svc measurementService) Get(ctx *context.Context, id uint, unit string) (*responseModel.Measurement, *errs.XError) {
+	channelUnit := getChannelMeasurementUnit(ctx, svc.masterConfigSvc)
+	targetUnit, err := parseMeasurementUnit(unit, channelUnit)
+	if err != nil {
+		return nil, err
+	}
+
 	measurement, err := svc.measurementRepo.Get(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
+	if measurement.Model != nil {
+		measurement.Value, err = convertMeasurementValues(measurement.Value, storedMeasurementUnit(measurement, channelUnit), targetUnit)
+		if err != nil {
+			return nil, err
+		}
+		measurement.Unit = targetUnit
+	}
+
 	mappedMeasurement, mapErr := svc.respMapper.Measurement(measurement)
 	if mapErr != nil {
 		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map Measurement data", mapErr)
@@ -239,16 +374,108 @@ func (svc measurementService) Delete(ctx *context.Context, id uint) *errs.XError
 	}
 
 	// Record measurement history for DELETED action with old values
-	err = svc.recordMeasurementHistory(ctx, id, entities.MeasurementHistoryActionDeleted, &oldMeasurement.Value)
+	channelUnit := getChannelMeasurementUnit(ctx, svc.masterConfigSvc)
+	err = svc.recordMeasurementHistory(ctx, id, entities.MeasurementHistoryActionDeleted, &oldMeasurement.Value, storedMeasurementUnit(oldMeasurement, channelUnit))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Restore sets the values of the measurement back to the values before the history and records an UPDATED history
+func (svc measurementService) Restore(ctx *context.Context, id uint, historyId uint) *errs.XError {
+	history, err := svc.measurementHistoryRepo.Get(ctx, historyId)
 	if err != nil {
 		return err
 	}
+	if history.Model == nil {
+		return errs.NewXError(errs.NOT_EXIST, "Measurement history not found", nil)
+	}
+	if history.MeasurementId != id {
+		return errs.NewXError(errs.INVALID_REQUEST, "Measurement history does not belong to the measurement", nil)
+	}
+	if len(history.OldValues) == 0 {
+		return errs.NewXError(errs.INVALID_REQUEST, "Measurement history has no values to restore", nil)
+	}
+
+	oldMeasurement, err := svc.measurementRepo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if oldMeasurement.Model == nil {
+		return errs.NewXError(errs.NOT_EXIST, "Measurement not found", nil)
+	}
+
+	channelUnit := getChannelMeasurementUnit(ctx, svc.masterConfigSvc)
+	historyUnit := history.Unit
+	if historyUnit == "" {
+		historyUnit = channelUnit
+	}
+
+	values, err := convertMeasurementValues(history.OldValues, historyUnit, channelUnit)
+	if err != nil {
+		return err
+	}
+
+	fieldErrs, err := svc.validateValues(ctx, oldMeasurement.DressTypeId, json.RawMessage(values), channelUnit, map[uint]*entities.DressType{})
+	if err != nil {
+		return err
+	}
+	err = fieldErrs.ToXError("Measurement values of the history are not valid for the dress type")
+	if err != nil {
+		return err
+	}
+
+	// update saves every field, so the restored measurement starts from the saved one without its associations
+	restored := *oldMeasurement
+	restored.Model = &entities.Model{ID: id, IsActive: true}
+	restored.Value = values
+	restored.Unit = channelUnit
+	restored.Person = nil
+	restored.DressType = nil
+	restored.TakenBy = nil
+	err = svc.measurementRepo.Update(ctx, &restored)
+	if err != nil {
+		return err
+	}
+
+	return svc.recordMeasurementHistory(ctx, id, entities.MeasurementHistoryActionUpdated, &oldMeasurement.Value, storedMeasurementUnit(oldMeasurement, channelUnit))
+}
+
+// validateValues checks the values against the measurement definitions of the dress type.
+// dressTypes holds the dress types already loaded while validating a bulk request.
+func (svc measurementService) validateValues(ctx *context.Context, dressTypeId uint, values json.RawMessage, unit entities.MeasurementUnit, dressTypes map[uint]*entities.DressType) (validator.FieldErrors, *errs.XError) {
+	dressType, ok := dressTypes[dressTypeId]
+	if !ok {
+		var err *errs.XError
+		dressType, err = svc.dressTypeRepo.Get(ctx, dressTypeId)
+		if err != nil {
+			return nil, err
+		}
+		dressTypes[dressTypeId] = dressType
+	}
+
+	if dressType.Model == nil {
+		return validator.FieldErrors{{Field: "dressTypeId", Message: "dress type does not exist"}}, nil
+	}
+
+	return validator.ValidateMeasurementValues(dressType.MeasurementFields, values, unit), nil
+}
 
+// normalizeValues converts the values of the measurement to the channel unit
+func (svc measurementService) normalizeValues(measurement *entities.Measurement, unit entities.MeasurementUnit, channelUnit entities.MeasurementUnit) *errs.XError {
+	var err *errs.XError
+	measurement.Value, err = convertMeasurementValues(measurement.Value, unit, channelUnit)
+	if err != nil {
+		return err
+	}
+	measurement.Unit = channelUnit
 	return nil
 }
 
 // recordMeasurementHistory creates a measurement history record
-func (svc measurementService) recordMeasurementHistory(ctx *context.Context, measurementId uint, action entities.MeasurementHistoryAction, oldValues *entitiy_types.JSON) *errs.XError {
+func (svc measurementService) recordMeasurementHistory(ctx *context.Context, measurementId uint, action entities.MeasurementHistoryAction, oldValues *entitiy_types.JSON, oldUnit entities.MeasurementUnit) *errs.XError {
 	userID := utils.GetUserId(ctx)
 	performedAt := util.GetLocalTime()
 
@@ -261,6 +488,7 @@ func (svc measurementService) recordMeasurementHistory(ctx *context.Context, mea
 		Model:         &entities.Model{IsActive: true},
 		Action:        action,
 		OldValues:     oldValuesJSON,
+		Unit:          oldUnit,
 		MeasurementId: measurementId,
 		PerformedAt:   performedAt,
 		PerformedById: userID,
@@ -268,3 +496,62 @@ func (svc measurementService) recordMeasurementHistory(ctx *context.Context, mea
 
 	return svc.measurementHistoryRepo.Create(ctx, history)
 }
+
+// getChannelMeasurementUnit returns the default measurement unit of the channel, cm when it is not configured
+func getChannelMeasurementUnit(ctx *context.Context, masterConfigSvc MasterConfigService) entities.MeasurementUnit {
+	value, err := masterConfigSvc.GetByName(ctx, constants.CONFIG_MEASUREMENT_DEFAULT_UNIT)
+	if err != nil {
+		return entities.MeasurementUnitCm
+	}
+
+	unit, ok := measurementUtil.ParseUnit(value)
+	if !ok {
+		return entities.MeasurementUnitCm
+	}
+	return unit
+}
+
+// parseMeasurementUnit reads the unit of a request, the fallback is used when it is not given
+func parseMeasurementUnit(unit string, fallback entities.MeasurementUnit) (entities.MeasurementUnit, *errs.XError) {
+	if unit == "" {
+		return fallback, nil
+	}
+
+	parsed, ok := measurementUtil.ParseUnit(unit)
+	if !ok {
+		return "", errs.NewXError(errs.VALIDATION, fmt.Sprintf("Invalid measurement unit %s, should be %s or %s", unit, entities.MeasurementUnitCm, entities.MeasurementUnitInch), nil)
+	}
+	return parsed, nil
+}
+
+// storedMeasurementUnit returns the unit the values of the measurement are stored in,
+// measurements saved before units were tracked are in the channel unit
+func storedMeasurementUnit(measurement *entities.Measurement, channelUnit entities.MeasurementUnit) entities.MeasurementUnit {
+	if measurement == nil || measurement.Unit == "" {
+		return channelUnit
+	}
+	return measurement.Unit
+}
+
+// convertMeasurementsUnit converts the values of the measurements to the target unit
+func convertMeasurementsUnit(measurements []entities.Measurement, channelUnit entities.MeasurementUnit, targetUnit entities.MeasurementUnit) *errs.XError {
+	for i := range measurements {
+		measurement := &measurements[i]
+
+		var err *errs.XError
+		measurement.Value, err = convertMeasurementValues(measurement.Value, storedMeasurementUnit(measurement, channelUnit), targetUnit)
+		if err != nil {
+			return err
+		}
+		measurement.Unit = targetUnit
+	}
+	return nil
+}
+
+func convertMeasurementValues(values entitiy_types.JSON, from entities.MeasurementUnit, to entities.MeasurementUnit) (entitiy_types.JSON, *errs.XError) {
+	converted, err := measurementUtil.ConvertValues(json.RawMessage(values), from, to)
+	if err != nil {
+		return nil, errs.NewXError(errs.INVALID_REQUEST, "Measurement values should be an object of measurement name and value", err)
+	}
+	return entitiy_types.JSON(converted), nil
+}
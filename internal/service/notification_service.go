@@ -2,13 +2,22 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/imkarthi24/sf-backend/internal/config"
+	"github.com/imkarthi24/sf-backend/internal/constants"
 	"github.com/imkarthi24/sf-backend/internal/entities"
 	"github.com/imkarthi24/sf-backend/internal/mapper"
 	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
 	"github.com/imkarthi24/sf-backend/internal/repository"
+	"github.com/imkarthi24/sf-backend/internal/utils"
+	"github.com/imkarthi24/sf-backend/internal/utils/whatsapp"
 	"github.com/loop-kar/pixie/errs"
 	"github.com/loop-kar/pixie/service/email"
 	"github.com/loop-kar/pixie/util"
@@ -17,27 +26,48 @@ import (
 type NotificationService interface {
 	CreateEmailNotification(ctx *context.Context, notif requestModel.EmaiNotification) *errs.XError
 	CreateEmailNotifications(ctx *context.Context, notifs []requestModel.EmaiNotification) *errs.XError
+	CreateWhatsappNotification(ctx *context.Context, notif requestModel.WhatsappNotification) *errs.XError
+	CreateWhatsappNotifications(ctx *context.Context, notifs []requestModel.WhatsappNotification) *errs.XError
 	GetPendingNotifications(ctx *context.Context) ([]entities.Notification, *errs.XError)
 
+	NotifyOrderEvent(ctx *context.Context, event entities.OrderEvent, order entities.Order) *errs.XError
+	NotifyAppointmentReminder(ctx *context.Context, appointment entities.Appointment) *errs.XError
+
 	SendNotification(ctx *context.Context, notif entities.Notification) *errs.XError
 }
 
 type notificationService struct {
-	notifRepo  repository.NotificationRepository
-	mapper     mapper.Mapper
-	smtpConfig config.SMTPConfig
-	emailSvc   email.EmailService
+	notifRepo       repository.NotificationRepository
+	masterConfigSvc MasterConfigService
+	mapper          mapper.Mapper
+	smtpConfig      config.SMTPConfig
+	jobConfig       config.JobConfig
+	whatsappConfig  config.WhatsappConfig
+	emailSvc        email.EmailService
+	whatsappSender  whatsapp.Sender
 }
 
-func ProvideNotificationService(repo repository.NotificationRepository, mapper mapper.Mapper, smtpConfig config.SMTPConfig, emailSvc email.EmailService) NotificationService {
+func ProvideNotificationService(repo repository.NotificationRepository, masterConfigSvc MasterConfigService, mapper mapper.Mapper, smtpConfig config.SMTPConfig, jobConfig config.JobConfig, whatsappConfig config.WhatsappConfig, emailSvc email.EmailService, whatsappSender whatsapp.Sender) NotificationService {
 	return &notificationService{
-		notifRepo:  repo,
-		mapper:     mapper,
-		smtpConfig: smtpConfig,
-		emailSvc:   emailSvc,
+		notifRepo:       repo,
+		masterConfigSvc: masterConfigSvc,
+		mapper:          mapper,
+		smtpConfig:      smtpConfig,
+		jobConfig:       jobConfig,
+		whatsappConfig:  whatsappConfig,
+		emailSvc:        emailSvc,
+		whatsappSender:  whatsappSender,
 	}
 }
 
+// notificationTemplate is the message sent to the customer for an event.
+// Values enclosed in ** (eg: **CUSTOMER_NAME**) are replaced while queueing the notification.
+type notificationTemplate struct {
+	EmailSubject string `json:"emailSubject"`
+	EmailBody    string `json:"emailBody"`
+	WhatsappBody string `json:"whatsappBody"`
+}
+
 func (svc *notificationService) CreateEmailNotification(ctx *context.Context, email requestModel.EmaiNotification) *errs.XError {
 
 	emailNotif, err := createEmailNotification(email)
@@ -76,21 +106,285 @@ func (svc *notificationService) CreateEmailNotifications(ctx *context.Context, n
 	return err
 }
 
+func (svc *notificationService) CreateWhatsappNotification(ctx *context.Context, whatsappNotif requestModel.WhatsappNotification) *errs.XError {
+
+	if util.IsNilOrEmptyString(&whatsappNotif.ReceipientNumber) {
+		return errs.NewXError(errs.INVALID_REQUEST, "Receipient number is required for Whatsapp Notification", nil)
+	}
+
+	notification := createNotification(whatsappNotif.Notification)
+
+	notification.AddWhatsappNotification(*createWhatsappNotification(whatsappNotif))
+
+	return svc.notifRepo.CreateNotification(ctx, *notification)
+
+}
+
+func (svc *notificationService) CreateWhatsappNotifications(ctx *context.Context, notifs []requestModel.WhatsappNotification) *errs.XError {
+
+	whatsappNotifs := make([]entities.WhatsappNotification, 0)
+
+	for _, notif := range notifs {
+		if util.IsNilOrEmptyString(&notif.ReceipientNumber) {
+			continue
+		}
+		whatsappNotifs = append(whatsappNotifs, *createWhatsappNotification(notif))
+	}
+
+	if len(whatsappNotifs) == 0 {
+		return nil
+	}
+
+	notification := createNotification(notifs[0].Notification)
+	notification.AddWhatsappNotification(whatsappNotifs...)
+
+	return svc.notifRepo.CreateNotification(ctx, *notification)
+}
+
 func (svc *notificationService) GetPendingNotifications(ctx *context.Context) ([]entities.Notification, *errs.XError) {
 	return svc.notifRepo.GetPendingNotifications(ctx)
 }
 
+// NotifyOrderEvent queues the customer notifications for an order event.
+// Channels opt in per event with the Notification.OrderEvents master config, eg: {"DELIVERED":["EMAIL","WHATSAPP"]}
+// and can override the default templates with Notification.OrderTemplates, which takes the shape of
+// templates/notification_templates/order_events.json
+func (svc *notificationService) NotifyOrderEvent(ctx *context.Context, event entities.OrderEvent, order entities.Order) *errs.XError {
+	channels := svc.getNotificationChannels(ctx, constants.CONFIG_NOTIFICATION_ORDER_EVENTS, string(event))
+	if len(channels) == 0 || order.Customer == nil {
+		return nil
+	}
+
+	template := svc.getNotificationTemplate(ctx, constants.ORDER_NOTIFICATION_TEMPLATE, constants.CONFIG_NOTIFICATION_ORDER_TEMPLATES, string(event))
+	replacer := strings.NewReplacer(svc.orderTemplateValues(ctx, order)...)
+
+	notification := createNotification(&requestModel.Notification{
+		SourceEntity: string(entities.Entity_Order),
+		EntityId:     order.ID,
+	})
+	addCustomerNotifications(notification, *order.Customer, channels, template, replacer)
+
+	if len(notification.EmailNotifications) == 0 && len(notification.WhatsappNotifications) == 0 {
+		return nil
+	}
+
+	return svc.notifRepo.CreateNotification(ctx, *notification)
+}
+
+// NotifyAppointmentReminder queues the reminder of an appointment to the customer.
+// Channels opt in per appointment type with the Notification.AppointmentReminders master config, eg: {"FITTING":["WHATSAPP"]}
+// and can override the default templates with Notification.AppointmentTemplates, which takes the shape of
+// templates/notification_templates/appointment_reminders.json
+func (svc *notificationService) NotifyAppointmentReminder(ctx *context.Context, appointment entities.Appointment) *errs.XError {
+	channels := svc.getNotificationChannels(ctx, constants.CONFIG_NOTIFICATION_APPOINTMENT_REMINDERS, string(appointment.Type))
+	if len(channels) == 0 || appointment.Customer == nil {
+		return nil
+	}
+
+	template := svc.getNotificationTemplate(ctx, constants.APPOINTMENT_NOTIFICATION_TEMPLATE, constants.CONFIG_NOTIFICATION_APPOINTMENT_TEMPLATES, string(appointment.Type))
+	replacer := strings.NewReplacer(svc.appointmentTemplateValues(ctx, appointment)...)
+
+	notification := createNotification(&requestModel.Notification{
+		SourceEntity: string(entities.Entity_Appointment),
+		EntityId:     appointment.ID,
+	})
+	addCustomerNotifications(notification, *appointment.Customer, channels, template, replacer)
+
+	if len(notification.EmailNotifications) == 0 && len(notification.WhatsappNotifications) == 0 {
+		return nil
+	}
+
+	return svc.notifRepo.CreateNotification(ctx, *notification)
+}
+
+// addCustomerNotifications adds a message to the customer for each channel, skipping the channels
+// the customer has no contact for or the template has no body for
+func addCustomerNotifications(notification *entities.Notification, customer entities.Customer, channels []entities.NotificationChannel, template notificationTemplate, replacer *strings.Replacer) {
+	for _, channel := range channels {
+		switch channel {
+		case entities.NOTIF_CHANNEL_EMAIL:
+			if util.IsNilOrEmptyString(&customer.Email) || template.EmailBody == "" {
+				continue
+			}
+			notification.AddEmailNotification(entities.EmailNotification{
+				Status:        string(entities.NOTIF_PENDING),
+				ToMailAddress: customer.Email,
+				Subject:       replacer.Replace(template.EmailSubject),
+				Body:          replacer.Replace(template.EmailBody),
+			})
+		case entities.NOTIF_CHANNEL_WHATSAPP:
+			number := customer.WhatsappNumber
+			if number == "" {
+				number = customer.PhoneNumber
+			}
+			if number == "" || template.WhatsappBody == "" {
+				continue
+			}
+			notification.AddWhatsappNotification(entities.WhatsappNotification{
+				Status:           string(entities.NOTIF_PENDING),
+				ReceipientNumber: number,
+				Body:             replacer.Replace(template.WhatsappBody),
+			})
+		}
+	}
+}
+
+// SendNotification delivers the children of the notification that are not yet delivered and
+// rolls the result up into the notification status.
+// A failed attempt is retried with exponential backoff until the configured max retries,
+// after which the notification is marked FAULTED.
 func (svc *notificationService) SendNotification(ctx *context.Context, notif entities.Notification) *errs.XError {
 
-	notifStatus := entities.NOTIF_COMPLETED
-	err := svc.sendEmailNotification(ctx, notif.EmailNotifications)
+	attemptCount := notif.AttemptCount + 1
+
+	emailDelivered, emailFailed := svc.sendEmailNotification(ctx, notif.EmailNotifications)
+	whatsappDelivered, whatsappFailed := svc.sendWhatsappNotification(ctx, notif.WhatsappNotifications)
+
+	delivered := emailDelivered + whatsappDelivered
+	failed := emailFailed + whatsappFailed
+
+	var notifStatus entities.NotificationStatus
+	var nextAttemptAt *time.Time
+	switch {
+	case failed == 0:
+		notifStatus = entities.NOTIF_COMPLETED
+	case attemptCount >= svc.jobConfig.NotificationMaxRetries:
+		notifStatus = entities.NOTIF_FAULTED
+	default:
+		notifStatus = entities.NOTIF_PENDING
+		if delivered > 0 {
+			notifStatus = entities.NOTIF_PARTIAL
+		}
+		next := time.Now().Add(svc.getBackoff(attemptCount))
+		nextAttemptAt = &next
+	}
+
+	err := svc.notifRepo.UpdateNotificationAttempt(ctx, notif.ID, notifStatus, attemptCount, nextAttemptAt)
 	if err != nil {
-		notifStatus = entities.NOTIF_PARTIAL
+		return err
+	}
+
+	if emailFailed > 0 {
+		return errs.NewXError(errs.SMTPERROR, errs.SMTP_ERROR, errors.New("Error sending email notification"))
+	}
+	if whatsappFailed > 0 {
+		return errs.NewXError(errs.IO, "Error sending whatsapp notification", nil)
+	}
+
+	return nil
+}
+
+// getBackoff returns the wait before the next attempt, doubling the base backoff on every attempt
+func (svc *notificationService) getBackoff(attemptCount int) time.Duration {
+	backoff := time.Duration(svc.jobConfig.NotificationBackoffSeconds) * time.Second
+	for i := 1; i < attemptCount && backoff < 24*time.Hour; i++ {
+		backoff *= 2
+	}
+	return min(backoff, 24*time.Hour)
+}
+
+// getNotificationChannels returns the channels the key (eg: an order event) is opted in for in the master config,
+// no channel by default
+func (svc *notificationService) getNotificationChannels(ctx *context.Context, configName string, key string) []entities.NotificationChannel {
+	value, err := svc.masterConfigSvc.GetByName(ctx, configName)
+	if err != nil || util.IsNilOrEmptyString(&value) {
+		return nil
+	}
+
+	optIns := make(map[string][]entities.NotificationChannel)
+	if jsonErr := json.Unmarshal([]byte(value), &optIns); jsonErr != nil {
+		return nil
+	}
+
+	return optIns[key]
+}
+
+// getNotificationTemplate returns the channel's template for the key from the master config,
+// every field not overridden in master config falls back to the default template file
+func (svc *notificationService) getNotificationTemplate(ctx *context.Context, templateFile string, configName string, key string) notificationTemplate {
+	template := notificationTemplate{}
+
+	defaults := make(map[string]notificationTemplate)
+	content, fileErr := os.ReadFile(filepath.Join(constants.NOTIFICATION_TEMPLATE_DIR, templateFile))
+	if fileErr == nil && json.Unmarshal(content, &defaults) == nil {
+		template = defaults[key]
+	}
+
+	value, err := svc.masterConfigSvc.GetByName(ctx, configName)
+	if err != nil || util.IsNilOrEmptyString(&value) {
+		return template
+	}
+
+	overrides := make(map[string]notificationTemplate)
+	if json.Unmarshal([]byte(value), &overrides) != nil {
+		return template
 	}
 
-	// have to include whatsaap notifications as well
-	return svc.notifRepo.UpdateNotificationStatus(ctx, notif.ID, notifStatus)
+	override := overrides[key]
+	if override.EmailSubject != "" {
+		template.EmailSubject = override.EmailSubject
+	}
+	if override.EmailBody != "" {
+		template.EmailBody = override.EmailBody
+	}
+	if override.WhatsappBody != "" {
+		template.WhatsappBody = override.WhatsappBody
+	}
+
+	return template
+}
+
+func (svc *notificationService) orderTemplateValues(ctx *context.Context, order entities.Order) []string {
+	formatDate := func(date *time.Time) string {
+		if date == nil {
+			return ""
+		}
+		return date.Format("02 Jan 2006")
+	}
 
+	return append([]string{
+		"**CUSTOMER_NAME**", strings.TrimSpace(order.Customer.FirstName + " " + order.Customer.LastName),
+		"**ORDER_ID**", fmt.Sprintf("%d", order.ID),
+		"**ORDER_STATUS**", string(order.Status),
+		"**EXPECTED_DELIVERY_DATE**", formatDate(order.ExpectedDeliveryDate),
+		"**DELIVERED_DATE**", formatDate(order.DeliveredDate),
+	}, svc.companyTemplateValues(ctx)...)
+}
+
+func (svc *notificationService) appointmentTemplateValues(ctx *context.Context, appointment entities.Appointment) []string {
+	startTime := appointment.StartTime.In(util.GetLocalTime().Location())
+
+	var staffName, orderId string
+	if appointment.Staff != nil {
+		staffName = strings.TrimSpace(appointment.Staff.FirstName + " " + appointment.Staff.LastName)
+	}
+	if appointment.OrderId != nil {
+		orderId = fmt.Sprintf("%d", *appointment.OrderId)
+	}
+
+	return append([]string{
+		"**CUSTOMER_NAME**", strings.TrimSpace(appointment.Customer.FirstName + " " + appointment.Customer.LastName),
+		"**APPOINTMENT_TYPE**", strings.ToLower(string(appointment.Type)),
+		"**APPOINTMENT_DATE**", startTime.Format("02 Jan 2006"),
+		"**APPOINTMENT_TIME**", startTime.Format("03:04 PM"),
+		"**STAFF_NAME**", staffName,
+		"**ORDER_ID**", orderId,
+	}, svc.companyTemplateValues(ctx)...)
+}
+
+func (svc *notificationService) companyTemplateValues(ctx *context.Context) []string {
+	companyName, _ := svc.masterConfigSvc.GetByName(ctx, constants.CONFIG_CHANNEL_NAME)
+	if companyName == "" {
+		if session := utils.GetSession(ctx); session != nil {
+			companyName = session.ChannelName
+		}
+	}
+	companyPhone, _ := svc.masterConfigSvc.GetByName(ctx, constants.CONFIG_CHANNEL_PHONE)
+
+	return []string{
+		"**COMPANY_NAME**", companyName,
+		"**COMPANY_PHONE**", companyPhone,
+	}
 }
 
 func createNotification(notif *requestModel.Notification) *entities.Notification {
@@ -101,6 +395,16 @@ func createNotification(notif *requestModel.Notification) *entities.Notification
 	}
 }
 
+func createWhatsappNotification(notif requestModel.WhatsappNotification) *entities.WhatsappNotification {
+	return &entities.WhatsappNotification{
+		Status:              string(entities.NOTIF_PENDING),
+		ReceipientNumber:    notif.ReceipientNumber,
+		ReceipientExtension: notif.ReceipientExtension,
+		Subject:             notif.Subject,
+		Body:                notif.Body,
+	}
+}
+
 func createEmailNotification(notif requestModel.EmaiNotification) (*entities.EmailNotification, error) {
 
 	if notif.EmailContent != nil {
@@ -126,10 +430,17 @@ func createEmailNotification(notif requestModel.EmaiNotification) (*entities.Ema
 	}, nil
 }
 
-func (svc *notificationService) sendEmailNotification(ctx *context.Context, emailNotifs []entities.EmailNotification) *errs.XError {
+// sendEmailNotification sends the emails that are not yet delivered and
+// returns the number of delivered (including earlier attempts) and failed emails
+func (svc *notificationService) sendEmailNotification(ctx *context.Context, emailNotifs []entities.EmailNotification) (int, int) {
 
-	var faulted bool
+	var delivered, failed int
 	for _, notif := range emailNotifs {
+		if notif.Status == string(entities.NOTIF_COMPLETED) {
+			delivered++
+			continue
+		}
+
 		recipients := []string{notif.ToMailAddress}
 		mail := email.EmailContent{
 			To:      recipients,
@@ -141,7 +452,9 @@ func (svc *notificationService) sendEmailNotification(ctx *context.Context, emai
 		notifStatus := entities.NOTIF_COMPLETED
 		if err != nil {
 			notifStatus = entities.NOTIF_FAULTED
-			faulted = true
+			failed++
+		} else {
+			delivered++
 		}
 		notif.Status = string(notifStatus)
 
@@ -149,9 +462,45 @@ func (svc *notificationService) sendEmailNotification(ctx *context.Context, emai
 
 	}
 
-	if faulted {
-		return errs.NewXError(errs.SMTPERROR, errs.SMTP_ERROR, errors.New("Error sending email notification"))
+	return delivered, failed
+}
+
+// sendWhatsappNotification sends the whatsapp messages that are not yet delivered and
+// returns the number of delivered (including earlier attempts) and failed messages
+func (svc *notificationService) sendWhatsappNotification(ctx *context.Context, whatsappNotifs []entities.WhatsappNotification) (int, int) {
+
+	var delivered, failed int
+	for _, notif := range whatsappNotifs {
+		if notif.Status == string(entities.NOTIF_COMPLETED) {
+			delivered++
+			continue
+		}
+
+		countryCode := notif.ReceipientExtension
+		if countryCode == "" {
+			countryCode = svc.whatsappConfig.DefaultCountryCode
+		}
+
+		body := notif.Body
+		if notif.Subject != "" {
+			body = fmt.Sprintf("*%s*\n%s", notif.Subject, notif.Body)
+		}
+
+		err := svc.whatsappSender.SendMessage(whatsapp.Message{
+			To:   whatsapp.FormatNumber(countryCode, notif.ReceipientNumber),
+			Body: body,
+		})
+		notifStatus := entities.NOTIF_COMPLETED
+		if err != nil {
+			notifStatus = entities.NOTIF_FAULTED
+			failed++
+		} else {
+			delivered++
+		}
+
+		svc.notifRepo.UpdateWhatsappNotificationStatus(ctx, notif.ID, notifStatus)
+
 	}
 
-	return nil
+	return delivered, failed
 }
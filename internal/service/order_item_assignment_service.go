@@ -0,0 +1,381 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/mapper"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	responseModel "github.com/imkarthi24/sf-backend/internal/model/response"
+	"github.com/imkarthi24/sf-backend/internal/repository"
+	"github.com/imkarthi24/sf-backend/internal/utils"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/loop-kar/pixie/util"
+)
+
+type OrderItemAssignmentService interface {
+	SaveAssignment(*context.Context, requestModel.OrderItemAssignment) *errs.XError
+	UpdateAssignment(*context.Context, requestModel.OrderItemAssignment, uint) *errs.XError
+	UpdateAssignmentStatus(*context.Context, requestModel.Status, uint) *errs.XError
+	Get(*context.Context, uint) (*responseModel.OrderItemAssignment, *errs.XError)
+	GetAll(*context.Context, requestModel.OrderItemAssignmentFilter) ([]responseModel.OrderItemAssignment, *errs.XError)
+	GetWorkQueue(*context.Context, string) ([]responseModel.OrderItemAssignment, *errs.XError)
+	Delete(*context.Context, uint) *errs.XError
+}
+
+type orderItemAssignmentService struct {
+	assignmentRepo repository.OrderItemAssignmentRepository
+	orderItemRepo  repository.OrderItemRepository
+	userRepo       repository.UserRepository
+	orderSvc       OrderService
+	payoutSvc      PayoutService
+	mapper         mapper.Mapper
+	respMapper     mapper.ResponseMapper
+}
+
+func ProvideOrderItemAssignmentService(repo repository.OrderItemAssignmentRepository, orderItemRepo repository.OrderItemRepository, userRepo repository.UserRepository, orderSvc OrderService, payoutSvc PayoutService, mapper mapper.Mapper, respMapper mapper.ResponseMapper) OrderItemAssignmentService {
+	return orderItemAssignmentService{
+		assignmentRepo: repo,
+		orderItemRepo:  orderItemRepo,
+		userRepo:       userRepo,
+		orderSvc:       orderSvc,
+		payoutSvc:      payoutSvc,
+		mapper:         mapper,
+		respMapper:     respMapper,
+	}
+}
+
+// Roles that production work can be assigned to
+var assignableRoles = map[entities.RoleType]bool{
+	entities.ADMIN:      true,
+	entities.STAFF:      true,
+	entities.OUTSOURCED: true,
+}
+
+func (svc orderItemAssignmentService) SaveAssignment(ctx *context.Context, assignment requestModel.OrderItemAssignment) *errs.XError {
+	dbAssignment, err := svc.mapper.OrderItemAssignment(assignment)
+	if err != nil {
+		return errs.NewXError(errs.INVALID_REQUEST, "Unable to save assignment", err)
+	}
+
+	if dbAssignment.Status == "" {
+		dbAssignment.Status = entities.AssignmentStatusPending
+	}
+	if _, ok := entities.AssignmentStatusTransitions[dbAssignment.Status]; !ok || dbAssignment.Status == entities.AssignmentStatusCancelled {
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Invalid assignment status %s", dbAssignment.Status), nil)
+	}
+
+	orderItem, errr := svc.validateAssignment(ctx, dbAssignment)
+	if errr != nil {
+		return errr
+	}
+
+	now := util.GetLocalTime()
+	if dbAssignment.Status != entities.AssignmentStatusPending {
+		dbAssignment.StartedAt = &now
+	}
+	if dbAssignment.Status == entities.AssignmentStatusDone {
+		dbAssignment.CompletedAt = &now
+	}
+
+	errr = svc.assignmentRepo.Create(ctx, dbAssignment)
+	if errr != nil {
+		return errr
+	}
+
+	if dbAssignment.Status == entities.AssignmentStatusDone {
+		dbAssignment.OrderItem = orderItem
+		errr = svc.payoutSvc.AccruePayout(ctx, dbAssignment)
+		if errr != nil {
+			return errr
+		}
+	}
+
+	if dbAssignment.Status == entities.AssignmentStatusPending {
+		return nil
+	}
+	return svc.orderSvc.SyncProductionStatus(ctx, orderItem.OrderId)
+}
+
+func (svc orderItemAssignmentService) UpdateAssignment(ctx *context.Context, assignment requestModel.OrderItemAssignment, id uint) *errs.XError {
+	if isOutsourced(ctx) {
+		return errs.NewXError(errs.VALIDATION, "Outsourced users can only update the status of their work", nil)
+	}
+
+	oldAssignment, err := svc.assignmentRepo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if oldAssignment.Model == nil {
+		return errs.NewXError(errs.NOT_EXIST, "Assignment not found", nil)
+	}
+
+	dbAssignment, mapErr := svc.mapper.OrderItemAssignment(assignment)
+	if mapErr != nil {
+		return errs.NewXError(errs.INVALID_REQUEST, "Unable to update assignment", mapErr)
+	}
+
+	// An assignment cannot be moved to another item, use the status endpoint to change the status
+	dbAssignment.ID = id
+	dbAssignment.OrderItemId = oldAssignment.OrderItemId
+	dbAssignment.Status = oldAssignment.Status
+	dbAssignment.StartedAt = oldAssignment.StartedAt
+	dbAssignment.CompletedAt = oldAssignment.CompletedAt
+
+	orderItem, err := svc.validateAssignment(ctx, dbAssignment)
+	if err != nil {
+		return err
+	}
+
+	err = svc.assignmentRepo.Update(ctx, dbAssignment)
+	if err != nil {
+		return err
+	}
+
+	// Repricing a done work pays the tailor the new amount
+	if oldAssignment.Status != entities.AssignmentStatusDone ||
+		(oldAssignment.Quantity == dbAssignment.Quantity && oldAssignment.PieceRate == dbAssignment.PieceRate && oldAssignment.AssignedToId == dbAssignment.AssignedToId) {
+		return nil
+	}
+
+	err = svc.payoutSvc.ReversePayout(ctx, oldAssignment)
+	if err != nil {
+		return err
+	}
+	dbAssignment.OrderItem = orderItem
+	return svc.payoutSvc.AccruePayout(ctx, dbAssignment)
+}
+
+func (svc orderItemAssignmentService) UpdateAssignmentStatus(ctx *context.Context, status requestModel.Status, id uint) *errs.XError {
+	assignment, err := svc.assignmentRepo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if assignment.Model == nil || !canAccessAssignment(ctx, assignment) {
+		return errs.NewXError(errs.NOT_EXIST, "Assignment not found", nil)
+	}
+
+	newStatus := entities.AssignmentStatus(status.Status)
+	if assignment.Status == newStatus {
+		return nil
+	}
+
+	if _, ok := entities.AssignmentStatusTransitions[newStatus]; !ok {
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Invalid assignment status %s", newStatus), nil)
+	}
+	allowed := false
+	for _, target := range entities.AssignmentStatusTransitions[assignment.Status] {
+		if target == newStatus {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Assignment status cannot be changed from %s to %s", assignment.Status, newStatus), nil)
+	}
+
+	now := util.GetLocalTime()
+	startedAt := assignment.StartedAt
+	completedAt := assignment.CompletedAt
+	switch newStatus {
+	case entities.AssignmentStatusPending:
+		startedAt = nil
+		completedAt = nil
+	case entities.AssignmentStatusInProgress:
+		if startedAt == nil {
+			startedAt = &now
+		}
+		completedAt = nil
+	case entities.AssignmentStatusDone:
+		if startedAt == nil {
+			startedAt = &now
+		}
+		completedAt = &now
+	}
+
+	// The reason of the change (eg: why the work is redone) is kept as the notes
+	notes := assignment.Notes
+	if !util.IsNilOrEmptyString(&status.StatusReason) {
+		notes = status.StatusReason
+	}
+
+	err = svc.assignmentRepo.UpdateStatus(ctx, id, newStatus, startedAt, completedAt, notes)
+	if err != nil {
+		return err
+	}
+
+	// The outsourced tailors are paid for the work once it is done, reopened work is taken back till it is done again
+	if newStatus == entities.AssignmentStatusDone {
+		err = svc.payoutSvc.AccruePayout(ctx, assignment)
+	} else if assignment.Status == entities.AssignmentStatusDone {
+		err = svc.payoutSvc.ReversePayout(ctx, assignment)
+	}
+	if err != nil {
+		return err
+	}
+
+	if newStatus != entities.AssignmentStatusInProgress && newStatus != entities.AssignmentStatusDone {
+		return nil
+	}
+	return svc.orderSvc.SyncProductionStatus(ctx, assignment.OrderItem.OrderId)
+}
+
+func (svc orderItemAssignmentService) Get(ctx *context.Context, id uint) (*responseModel.OrderItemAssignment, *errs.XError) {
+	assignment, err := svc.assignmentRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if assignment.Model == nil || !canAccessAssignment(ctx, assignment) {
+		return nil, errs.NewXError(errs.NOT_EXIST, "Assignment not found", nil)
+	}
+
+	mappedAssignment, mapErr := svc.respMapper.OrderItemAssignment(assignment)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map OrderItemAssignment data", mapErr)
+	}
+
+	return mappedAssignment, nil
+}
+
+func (svc orderItemAssignmentService) GetAll(ctx *context.Context, filter requestModel.OrderItemAssignmentFilter) ([]responseModel.OrderItemAssignment, *errs.XError) {
+	// Outsourced users only see the work assigned to them
+	if isOutsourced(ctx) {
+		userId := utils.GetUserId(ctx)
+		filter.AssignedToId = &userId
+	}
+
+	assignments, err := svc.assignmentRepo.GetAll(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	mappedAssignments, mapErr := svc.respMapper.OrderItemAssignments(assignments)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map OrderItemAssignment data", mapErr)
+	}
+
+	return mappedAssignments, nil
+}
+
+// GetWorkQueue returns the work assigned to the logged in user, the pending and in progress work when status is not given
+func (svc orderItemAssignmentService) GetWorkQueue(ctx *context.Context, status string) ([]responseModel.OrderItemAssignment, *errs.XError) {
+	userId := utils.GetUserId(ctx)
+	return svc.GetAll(ctx, requestModel.OrderItemAssignmentFilter{
+		AssignedToId: &userId,
+		Status:       status,
+		OpenOnly:     true,
+	})
+}
+
+func (svc orderItemAssignmentService) Delete(ctx *context.Context, id uint) *errs.XError {
+	assignment, err := svc.assignmentRepo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if assignment.Model == nil {
+		return errs.NewXError(errs.NOT_EXIST, "Assignment not found", nil)
+	}
+	if assignment.Status == entities.AssignmentStatusDone {
+		return errs.NewXError(errs.VALIDATION, "Completed work cannot be deleted", nil)
+	}
+
+	return svc.assignmentRepo.Delete(ctx, id)
+}
+
+// validateAssignment checks the assignment against the order item and the user it is assigned to,
+// quantity defaults to the quantity of the item that is not yet assigned for the stage
+func (svc orderItemAssignmentService) validateAssignment(ctx *context.Context, assignment *entities.OrderItemAssignment) (*entities.OrderItem, *errs.XError) {
+	validStage := false
+	for _, stage := range entities.ProductionStages {
+		if assignment.Stage == stage {
+			validStage = true
+			break
+		}
+	}
+	if !validStage {
+		return nil, errs.NewXError(errs.VALIDATION, fmt.Sprintf("Invalid production stage %s", assignment.Stage), nil)
+	}
+	if assignment.PieceRate < 0 {
+		return nil, errs.NewXError(errs.VALIDATION, "Piece rate should not be negative", nil)
+	}
+	if assignment.Quantity < 0 {
+		return nil, errs.NewXError(errs.VALIDATION, "Quantity should not be negative", nil)
+	}
+
+	orderItem, err := svc.orderItemRepo.Get(ctx, assignment.OrderItemId)
+	if err != nil {
+		return nil, err
+	}
+	// the order item and the user are not scoped to the channel, the work of another channel would be assigned and paid out
+	channelId := utils.GetChannelId(ctx)
+	if orderItem.Model == nil || !orderItem.IsActive || (channelId != 0 && orderItem.ChannelId != channelId) {
+		return nil, errs.NewXError(errs.NOT_EXIST, "Order item not found", nil)
+	}
+	if orderItem.Order != nil && (orderItem.Order.Status == entities.CANCELLED || orderItem.Order.Status == entities.DELIVERED) {
+		return nil, errs.NewXError(errs.VALIDATION, fmt.Sprintf("Work cannot be assigned for a %s order", orderItem.Order.Status), nil)
+	}
+
+	user, err := svc.userRepo.Get(ctx, assignment.AssignedToId)
+	if err != nil {
+		return nil, err
+	}
+	if user.Model == nil || !user.IsActive {
+		return nil, errs.NewXError(errs.NOT_EXIST, "Assigned user not found", nil)
+	}
+	if channelId != 0 && user.ChannelId != channelId {
+		inChannel, err := svc.hasChannelAccess(ctx, user.ID, channelId)
+		if err != nil {
+			return nil, err
+		}
+		if !inChannel {
+			return nil, errs.NewXError(errs.NOT_EXIST, "Assigned user not found", nil)
+		}
+	}
+	if !assignableRoles[user.Role] {
+		return nil, errs.NewXError(errs.VALIDATION, fmt.Sprintf("Work cannot be assigned to a %s user", user.Role), nil)
+	}
+
+	// The quantity of an item can be split between users, but not assigned more than once for a stage
+	assignments, err := svc.assignmentRepo.GetByOrderItemId(ctx, assignment.OrderItemId)
+	if err != nil {
+		return nil, err
+	}
+	assignedQuantity := 0
+	for _, other := range assignments {
+		if other.ID != assignment.ID && other.Stage == assignment.Stage && other.Status != entities.AssignmentStatusCancelled {
+			assignedQuantity += other.Quantity
+		}
+	}
+	if assignment.Quantity == 0 {
+		assignment.Quantity = orderItem.Quantity - assignedQuantity
+	}
+	if assignment.Quantity <= 0 || assignedQuantity+assignment.Quantity > orderItem.Quantity {
+		return nil, errs.NewXError(errs.VALIDATION, fmt.Sprintf("Only %d of the %d pieces are left to be assigned for %s", max(orderItem.Quantity-assignedQuantity, 0), orderItem.Quantity, assignment.Stage), nil)
+	}
+
+	return orderItem, nil
+}
+
+// isOutsourced is true when the logged in user is an outsourced tailor, who can only work on their own assignments
+func isOutsourced(ctx *context.Context) bool {
+	return utils.GetRole(ctx) == entities.OUTSOURCED
+}
+
+func canAccessAssignment(ctx *context.Context, assignment *entities.OrderItemAssignment) bool {
+	return !isOutsourced(ctx) || assignment.AssignedToId == utils.GetUserId(ctx)
+}
+
+// hasChannelAccess reports whether the user can work in a channel other than their own channel
+func (svc orderItemAssignmentService) hasChannelAccess(ctx *context.Context, userId uint, channelId uint) (bool, *errs.XError) {
+	channelDetails, err := svc.userRepo.GetUserAccessibleChannels(ctx, userId)
+	if err != nil {
+		return false, err
+	}
+	for _, detail := range channelDetails {
+		if detail.UserChannelID == channelId {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -2,12 +2,19 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"strings"
+	"time"
 
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	entitiy_types "github.com/imkarthi24/sf-backend/internal/entities/types"
 	"github.com/imkarthi24/sf-backend/internal/mapper"
 	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
 	responseModel "github.com/imkarthi24/sf-backend/internal/model/response"
 	"github.com/imkarthi24/sf-backend/internal/repository"
+	"github.com/imkarthi24/sf-backend/internal/utils"
 	"github.com/loop-kar/pixie/errs"
+	"github.com/loop-kar/pixie/util"
 )
 
 type OrderItemService interface {
@@ -19,16 +26,18 @@ type OrderItemService interface {
 }
 
 type orderItemService struct {
-	orderItemRepo repository.OrderItemRepository
-	mapper        mapper.Mapper
-	respMapper    mapper.ResponseMapper
+	orderItemRepo    repository.OrderItemRepository
+	orderHistoryRepo repository.OrderHistoryRepository
+	mapper           mapper.Mapper
+	respMapper       mapper.ResponseMapper
 }
 
-func ProvideOrderItemService(repo repository.OrderItemRepository, mapper mapper.Mapper, respMapper mapper.ResponseMapper) OrderItemService {
+func ProvideOrderItemService(repo repository.OrderItemRepository, orderHistoryRepo repository.OrderHistoryRepository, mapper mapper.Mapper, respMapper mapper.ResponseMapper) OrderItemService {
 	return orderItemService{
-		orderItemRepo: repo,
-		mapper:        mapper,
-		respMapper:    respMapper,
+		orderItemRepo:    repo,
+		orderHistoryRepo: orderHistoryRepo,
+		mapper:           mapper,
+		respMapper:       respMapper,
 	}
 }
 
@@ -43,21 +52,36 @@ func (svc orderItemService) SaveOrderItem(ctx *context.Context, orderItem reques
 		return errr
 	}
 
-	return nil
+	return recordOrderItemHistory(ctx, svc.orderHistoryRepo, dbOrderItem.OrderId, nil, dbOrderItem)
 }
 
 func (svc orderItemService) UpdateOrderItem(ctx *context.Context, orderItem requestModel.OrderItem, id uint) *errs.XError {
+	oldOrderItem, errr := svc.orderItemRepo.Get(ctx, id)
+	if errr != nil {
+		return errr
+	}
+	if oldOrderItem.Model == nil {
+		return errs.NewXError(errs.NOT_EXIST, "Order item not found", nil)
+	}
+
 	dbOrderItem, err := svc.mapper.OrderItem(orderItem)
 	if err != nil {
 		return errs.NewXError(errs.INVALID_REQUEST, "Unable to update order item", err)
 	}
 
 	dbOrderItem.ID = id
-	errr := svc.orderItemRepo.Update(ctx, dbOrderItem)
+	errr = svc.orderItemRepo.Update(ctx, dbOrderItem)
 	if errr != nil {
 		return errr
 	}
-	return nil
+
+	// the update saves every column of the item, including the ones not sent, so the saved item is read back to find what changed
+	updatedOrderItem, errr := svc.orderItemRepo.Get(ctx, id)
+	if errr != nil {
+		return errr
+	}
+
+	return recordOrderItemHistory(ctx, svc.orderHistoryRepo, oldOrderItem.OrderId, oldOrderItem, updatedOrderItem)
 }
 
 func (svc orderItemService) Get(ctx *context.Context, id uint) (*responseModel.OrderItem, *errs.XError) {
@@ -89,9 +113,177 @@ func (svc orderItemService) GetAll(ctx *context.Context, search string) ([]respo
 }
 
 func (svc orderItemService) Delete(ctx *context.Context, id uint) *errs.XError {
-	err := svc.orderItemRepo.Delete(ctx, id)
+	oldOrderItem, err := svc.orderItemRepo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if oldOrderItem.Model == nil {
+		return errs.NewXError(errs.NOT_EXIST, "Order item not found", nil)
+	}
+
+	err = svc.orderItemRepo.Delete(ctx, id)
 	if err != nil {
 		return err
 	}
+
+	return recordOrderItemHistory(ctx, svc.orderHistoryRepo, oldOrderItem.OrderId, oldOrderItem, nil)
+}
+
+// recordOrderItemChanges records the items added, modified and removed between two versions of the items of an order
+func recordOrderItemChanges(ctx *context.Context, orderHistoryRepo repository.OrderHistoryRepository, orderId uint, oldItems []entities.OrderItem, newItems []entities.OrderItem) *errs.XError {
+	oldActiveItems := make(map[uint]*entities.OrderItem)
+	for i := range oldItems {
+		if isActiveOrderItem(&oldItems[i]) {
+			oldActiveItems[oldItems[i].ID] = &oldItems[i]
+		}
+	}
+
+	newActiveItems := make(map[uint]bool)
+	for i := range newItems {
+		newItem := &newItems[i]
+		if !isActiveOrderItem(newItem) {
+			continue
+		}
+		newActiveItems[newItem.ID] = true
+
+		err := recordOrderItemHistory(ctx, orderHistoryRepo, orderId, oldActiveItems[newItem.ID], newItem)
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := range oldItems {
+		oldItem := &oldItems[i]
+		if !isActiveOrderItem(oldItem) || newActiveItems[oldItem.ID] {
+			continue
+		}
+
+		err := recordOrderItemHistory(ctx, orderHistoryRepo, orderId, oldItem, nil)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
+
+// recordOrderItemHistory creates an item level order history with the changed fields of the item.
+// A nil old item is recorded as added, a nil new item as removed, nothing is recorded when no field changed.
+func recordOrderItemHistory(ctx *context.Context, orderHistoryRepo repository.OrderHistoryRepository, orderId uint, oldItem *entities.OrderItem, newItem *entities.OrderItem) *errs.XError {
+	changes := diffOrderItem(oldItem, newItem)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	action := entities.OrderHistoryActionItemModified
+	item := newItem
+	if oldItem == nil {
+		action = entities.OrderHistoryActionItemAdded
+	} else if newItem == nil {
+		action = entities.OrderHistoryActionItemRemoved
+		item = oldItem
+	}
+
+	data, err := json.Marshal(entities.OrderItemHistoryData{Description: item.Description, Changes: changes})
+	if err != nil {
+		return errs.NewXError(errs.INVALID_REQUEST, "Unable to record order item history", err)
+	}
+	orderItemData := entitiy_types.JSON(data)
+
+	changedFields := make([]string, 0, len(changes))
+	for _, change := range changes {
+		changedFields = append(changedFields, change.Field)
+	}
+
+	orderItemId := item.ID
+	history := &entities.OrderHistory{
+		Model:         &entities.Model{IsActive: true},
+		Action:        action,
+		ChangedFields: strings.Join(changedFields, ","),
+		OrderItemId:   &orderItemId,
+		OrderItemData: &orderItemData,
+		OrderId:       orderId,
+		PerformedAt:   util.GetLocalTime(),
+		PerformedById: utils.GetUserId(ctx),
+	}
+
+	return orderHistoryRepo.Create(ctx, history)
+}
+
+var orderItemChangeFields = []string{
+	entities.OrderItemChangeFieldDescription,
+	entities.OrderItemChangeFieldQuantity,
+	entities.OrderItemChangeFieldPrice,
+	entities.OrderItemChangeFieldAdditionalCharges,
+	entities.OrderItemChangeFieldTotal,
+	entities.OrderItemChangeFieldExpectedDeliveryDate,
+	entities.OrderItemChangeFieldDeliveredDate,
+	entities.OrderItemChangeFieldPersonId,
+	entities.OrderItemChangeFieldMeasurementId,
+}
+
+// diffOrderItem returns the tracked fields that differ between two versions of an item, a nil version is taken as empty
+func diffOrderItem(oldItem *entities.OrderItem, newItem *entities.OrderItem) []entities.OrderItemFieldChange {
+	oldValues := orderItemFieldValues(oldItem)
+	newValues := orderItemFieldValues(newItem)
+
+	changes := make([]entities.OrderItemFieldChange, 0)
+	for _, field := range orderItemChangeFields {
+		oldValue, newValue := oldValues[field], newValues[field]
+		if isSameFieldValue(oldValue, newValue) {
+			continue
+		}
+		changes = append(changes, entities.OrderItemFieldChange{Field: field, OldValue: oldValue, NewValue: newValue})
+	}
+	return changes
+}
+
+// orderItemFieldValues returns the tracked fields of the item, empty values are left out
+func orderItemFieldValues(item *entities.OrderItem) map[string]interface{} {
+	values := make(map[string]interface{})
+	if item == nil {
+		return values
+	}
+
+	if item.Description != "" {
+		values[entities.OrderItemChangeFieldDescription] = item.Description
+	}
+	if item.Quantity != 0 {
+		values[entities.OrderItemChangeFieldQuantity] = item.Quantity
+	}
+	if item.Price != 0 {
+		values[entities.OrderItemChangeFieldPrice] = item.Price
+	}
+	if item.AdditionalCharges != 0 {
+		values[entities.OrderItemChangeFieldAdditionalCharges] = item.AdditionalCharges
+	}
+	if item.Total != 0 {
+		values[entities.OrderItemChangeFieldTotal] = item.Total
+	}
+	if item.ExpectedDeliveryDate != nil {
+		values[entities.OrderItemChangeFieldExpectedDeliveryDate] = *item.ExpectedDeliveryDate
+	}
+	if item.DeliveredDate != nil {
+		values[entities.OrderItemChangeFieldDeliveredDate] = *item.DeliveredDate
+	}
+	if item.PersonId != nil {
+		values[entities.OrderItemChangeFieldPersonId] = *item.PersonId
+	}
+	if item.MeasurementId != nil {
+		values[entities.OrderItemChangeFieldMeasurementId] = *item.MeasurementId
+	}
+	return values
+}
+
+func isSameFieldValue(oldValue interface{}, newValue interface{}) bool {
+	oldTime, oldIsTime := oldValue.(time.Time)
+	newTime, newIsTime := newValue.(time.Time)
+	if oldIsTime && newIsTime {
+		return oldTime.Equal(newTime)
+	}
+	return oldValue == newValue
+}
+
+func isActiveOrderItem(item *entities.OrderItem) bool {
+	return item.Model != nil && item.IsActive
+}
@@ -2,9 +2,12 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"strings"
 	"time"
 
+	"github.com/imkarthi24/sf-backend/internal/constants"
 	"github.com/imkarthi24/sf-backend/internal/entities"
 	"github.com/imkarthi24/sf-backend/internal/mapper"
 	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
@@ -18,6 +21,8 @@ import (
 type OrderService interface {
 	SaveOrder(*context.Context, requestModel.Order) *errs.XError
 	UpdateOrder(*context.Context, requestModel.Order, uint) *errs.XError
+	UpdateOrderStatus(*context.Context, requestModel.Status, uint) *errs.XError
+	SyncProductionStatus(*context.Context, uint) *errs.XError
 	Get(*context.Context, uint) (*responseModel.Order, *errs.XError)
 	GetAll(*context.Context, string) ([]responseModel.Order, *errs.XError)
 	Delete(*context.Context, uint) *errs.XError
@@ -26,14 +31,20 @@ type OrderService interface {
 type orderService struct {
 	orderRepo        repository.OrderRepository
 	orderHistoryRepo repository.OrderHistoryRepository
+	assignmentRepo   repository.OrderItemAssignmentRepository
+	masterConfigSvc  MasterConfigService
+	notificationSvc  NotificationService
 	mapper           mapper.Mapper
 	respMapper       mapper.ResponseMapper
 }
 
-func ProvideOrderService(repo repository.OrderRepository, orderHistoryRepo repository.OrderHistoryRepository, mapper mapper.Mapper, respMapper mapper.ResponseMapper) OrderService {
+func ProvideOrderService(repo repository.OrderRepository, orderHistoryRepo repository.OrderHistoryRepository, assignmentRepo repository.OrderItemAssignmentRepository, masterConfigSvc MasterConfigService, notificationSvc NotificationService, mapper mapper.Mapper, respMapper mapper.ResponseMapper) OrderService {
 	return orderService{
 		orderRepo:        repo,
 		orderHistoryRepo: orderHistoryRepo,
+		assignmentRepo:   assignmentRepo,
+		masterConfigSvc:  masterConfigSvc,
+		notificationSvc:  notificationSvc,
 		mapper:           mapper,
 		respMapper:       respMapper,
 	}
@@ -45,6 +56,15 @@ func (svc orderService) SaveOrder(ctx *context.Context, order requestModel.Order
 		return errs.NewXError(errs.INVALID_REQUEST, "Unable to save order", err)
 	}
 
+	// A new order starts at the beginning of the status flow, the later statuses are reached through the transitions
+	switch dbOrder.Status {
+	case "":
+		dbOrder.Status = entities.DRAFT
+	case entities.DRAFT, entities.CONFIRMED:
+	default:
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Order cannot be created with status %s", dbOrder.Status), nil)
+	}
+
 	errr := svc.orderRepo.Create(ctx, dbOrder)
 	if errr != nil {
 		return errr
@@ -57,7 +77,7 @@ func (svc orderService) SaveOrder(ctx *context.Context, order requestModel.Order
 	}
 
 	// Record order history for CREATED action
-	errr = svc.recordOrderHistory(ctx, dbOrder.ID, entities.OrderHistoryActionCreated, nil, nil, nil, nil)
+	errr = svc.recordOrderHistory(ctx, dbOrder.ID, entities.OrderHistoryActionCreated, nil, nil, nil, nil, nil)
 	if errr != nil {
 		return errr
 	}
@@ -83,6 +103,29 @@ func (svc orderService) UpdateOrder(ctx *context.Context, order requestModel.Ord
 		dbOrder.OrderTakenById = &userID
 	}
 
+	// Status is retained when not sent, use the status endpoint to only change the status
+	if dbOrder.Status == "" {
+		dbOrder.Status = oldOrder.Status
+	}
+	if oldOrder.Status != dbOrder.Status {
+		errr := svc.validateStatusTransition(ctx, oldOrder.Status, dbOrder.Status)
+		if errr != nil {
+			return errr
+		}
+
+		errr = svc.validateProductionStages(ctx, id, dbOrder.Status)
+		if errr != nil {
+			return errr
+		}
+	}
+	if dbOrder.Status == entities.DELIVERED && dbOrder.DeliveredDate == nil {
+		dbOrder.DeliveredDate = oldOrder.DeliveredDate
+		if dbOrder.DeliveredDate == nil {
+			deliveredDate := util.GetLocalTime()
+			dbOrder.DeliveredDate = &deliveredDate
+		}
+	}
+
 	dbOrder.ID = id
 	errr := svc.orderRepo.Update(ctx, dbOrder)
 	if errr != nil {
@@ -104,12 +147,126 @@ func (svc orderService) UpdateOrder(ctx *context.Context, order requestModel.Ord
 	changedFieldsStr := strings.Join(changedFields, ",")
 
 	// Record order history for UPDATED action with old values
-	errr = svc.recordOrderHistory(ctx, id, entities.OrderHistoryActionUpdated, &oldOrder.Status, oldOrder.ExpectedDeliveryDate, oldOrder.DeliveredDate, &changedFieldsStr)
+	errr = svc.recordOrderHistory(ctx, id, entities.OrderHistoryActionUpdated, &oldOrder.Status, oldOrder.ExpectedDeliveryDate, oldOrder.DeliveredDate, &changedFieldsStr, nil)
 	if errr != nil {
 		return errr
 	}
 
-	return nil
+	// Items are saved along with the order, the saved order is read back to find the items that changed
+	if len(dbOrder.OrderItems) > 0 {
+		savedOrder, errr := svc.orderRepo.Get(ctx, id)
+		if errr != nil {
+			return errr
+		}
+
+		errr = recordOrderItemChanges(ctx, svc.orderHistoryRepo, id, oldOrder.OrderItems, savedOrder.OrderItems)
+		if errr != nil {
+			return errr
+		}
+	}
+
+	updatedOrder := *oldOrder
+	updatedOrder.Status = dbOrder.Status
+	updatedOrder.ExpectedDeliveryDate = dbOrder.ExpectedDeliveryDate
+	updatedOrder.DeliveredDate = dbOrder.DeliveredDate
+
+	return svc.notifyCustomer(ctx, oldOrder, updatedOrder)
+}
+
+func (svc orderService) UpdateOrderStatus(ctx *context.Context, status requestModel.Status, id uint) *errs.XError {
+	oldOrder, err := svc.orderRepo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if oldOrder.Model == nil {
+		return errs.NewXError(errs.NOT_EXIST, "Order not found", nil)
+	}
+
+	newStatus := entities.OrderStatus(status.Status)
+	if oldOrder.Status == newStatus {
+		return nil
+	}
+
+	err = svc.validateStatusTransition(ctx, oldOrder.Status, newStatus)
+	if err != nil {
+		return err
+	}
+
+	err = svc.validateProductionStages(ctx, id, newStatus)
+	if err != nil {
+		return err
+	}
+
+	changedFields := []string{entities.OrderChangeFieldStatus}
+
+	// DeliveredDate is set automatically when the order is delivered
+	deliveredDate := oldOrder.DeliveredDate
+	if newStatus == entities.DELIVERED && deliveredDate == nil {
+		now := util.GetLocalTime()
+		deliveredDate = &now
+		changedFields = append(changedFields, entities.OrderChangeFieldDeliveredDate)
+	}
+
+	err = svc.orderRepo.UpdateStatus(ctx, id, newStatus, deliveredDate)
+	if err != nil {
+		return err
+	}
+
+	var reason *string
+	if !util.IsNilOrEmptyString(&status.StatusReason) {
+		reason = &status.StatusReason
+	}
+
+	changedFieldsStr := strings.Join(changedFields, ",")
+	err = svc.recordOrderHistory(ctx, id, entities.OrderHistoryActionUpdated, &oldOrder.Status, oldOrder.ExpectedDeliveryDate, oldOrder.DeliveredDate, &changedFieldsStr, reason)
+	if err != nil {
+		return err
+	}
+
+	updatedOrder := *oldOrder
+	updatedOrder.Status = newStatus
+	updatedOrder.DeliveredDate = deliveredDate
+
+	return svc.notifyCustomer(ctx, oldOrder, updatedOrder)
+}
+
+// SyncProductionStatus moves the order forward to the production stage its items are in.
+// The order is never moved back, and is left as is when the channel's status flow does not allow the move.
+func (svc orderService) SyncProductionStatus(ctx *context.Context, id uint) *errs.XError {
+	order, err := svc.orderRepo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if order.Model == nil {
+		return errs.NewXError(errs.NOT_EXIST, "Order not found", nil)
+	}
+
+	assignments, err := svc.assignmentRepo.GetByOrderId(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	stage, ok := currentProductionStage(assignments)
+	if !ok {
+		return nil
+	}
+
+	newStatus := stage.OrderStatus()
+	if productionRank(newStatus) <= productionRank(order.Status) {
+		return nil
+	}
+	if svc.validateStatusTransition(ctx, order.Status, newStatus) != nil {
+		return nil
+	}
+
+	err = svc.orderRepo.UpdateStatus(ctx, id, newStatus, order.DeliveredDate)
+	if err != nil {
+		return err
+	}
+
+	changedFields := entities.OrderChangeFieldStatus
+	reason := "Moved along with the production stage of the items"
+	return svc.recordOrderHistory(ctx, id, entities.OrderHistoryActionUpdated, &order.Status, order.ExpectedDeliveryDate, order.DeliveredDate, &changedFields, &reason)
 }
 
 func (svc orderService) Get(ctx *context.Context, id uint) (*responseModel.Order, *errs.XError) {
@@ -153,7 +310,7 @@ func (svc orderService) Delete(ctx *context.Context, id uint) *errs.XError {
 	}
 
 	// Record order history for DELETED action with old values
-	err = svc.recordOrderHistory(ctx, id, entities.OrderHistoryActionDeleted, &oldOrder.Status, oldOrder.ExpectedDeliveryDate, oldOrder.DeliveredDate, nil)
+	err = svc.recordOrderHistory(ctx, id, entities.OrderHistoryActionDeleted, &oldOrder.Status, oldOrder.ExpectedDeliveryDate, oldOrder.DeliveredDate, nil, nil)
 	if err != nil {
 		return err
 	}
@@ -162,7 +319,7 @@ func (svc orderService) Delete(ctx *context.Context, id uint) *errs.XError {
 }
 
 // recordOrderHistory creates an order history record
-func (svc orderService) recordOrderHistory(ctx *context.Context, orderId uint, action entities.OrderHistoryAction, oldStatus *entities.OrderStatus, oldExpectedDeliveryDate *time.Time, oldDeliveredDate *time.Time, changedFields *string) *errs.XError {
+func (svc orderService) recordOrderHistory(ctx *context.Context, orderId uint, action entities.OrderHistoryAction, oldStatus *entities.OrderStatus, oldExpectedDeliveryDate *time.Time, oldDeliveredDate *time.Time, changedFields *string, reason *string) *errs.XError {
 	userID := utils.GetUserId(ctx)
 	performedAt := util.GetLocalTime()
 
@@ -172,6 +329,7 @@ func (svc orderService) recordOrderHistory(ctx *context.Context, orderId uint, a
 		OrderId:       orderId,
 		PerformedAt:   performedAt,
 		PerformedById: userID,
+		Reason:        reason,
 	}
 
 	// Set changed fields if provided
@@ -193,6 +351,174 @@ func (svc orderService) recordOrderHistory(ctx *context.Context, orderId uint, a
 	return svc.orderHistoryRepo.Create(ctx, history)
 }
 
+// notifyCustomer queues the customer notifications for the lifecycle events caused by an update
+func (svc orderService) notifyCustomer(ctx *context.Context, oldOrder *entities.Order, updatedOrder entities.Order) *errs.XError {
+	events := make([]entities.OrderEvent, 0)
+
+	if oldOrder.Status != updatedOrder.Status {
+		switch updatedOrder.Status {
+		case entities.READY_FOR_DELIVERY:
+			events = append(events, entities.OrderEventReadyForDelivery)
+		case entities.DELIVERED:
+			events = append(events, entities.OrderEventDelivered)
+		}
+	}
+
+	// Delivery date changes are irrelevant once the order is delivered or cancelled
+	if updatedOrder.ExpectedDeliveryDate != nil && !timeEqual(oldOrder.ExpectedDeliveryDate, updatedOrder.ExpectedDeliveryDate) &&
+		updatedOrder.Status != entities.DELIVERED && updatedOrder.Status != entities.CANCELLED {
+		events = append(events, entities.OrderEventDeliveryDateChanged)
+	}
+
+	for _, event := range events {
+		err := svc.notificationSvc.NotifyOrderEvent(ctx, event, updatedOrder)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateStatusTransition checks if the order can move from one status to another
+// based on the channel's configured transitions (or the default transitions)
+func (svc orderService) validateStatusTransition(ctx *context.Context, from entities.OrderStatus, to entities.OrderStatus) *errs.XError {
+	transitions := svc.getStatusTransitions(ctx)
+
+	if _, ok := transitions[to]; !ok {
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Invalid order status %s", to), nil)
+	}
+
+	// Orders without a status (legacy data) can move to any status
+	if from == "" {
+		return nil
+	}
+
+	for _, allowed := range transitions[from] {
+		if allowed == to {
+			return nil
+		}
+	}
+
+	return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Order status cannot be changed from %s to %s", from, to), nil)
+}
+
+// validateProductionStages checks that the production work of the stages before the status is done,
+// eg: an order cannot move to STITCHING while the cutting of any of its items is pending
+func (svc orderService) validateProductionStages(ctx *context.Context, orderId uint, to entities.OrderStatus) *errs.XError {
+	stages := stagesToComplete(to)
+	if len(stages) == 0 {
+		return nil
+	}
+
+	assignments, err := svc.assignmentRepo.GetByOrderId(ctx, orderId)
+	if err != nil {
+		return err
+	}
+
+	var pendingStages []string
+	for _, stage := range stages {
+		for _, assignment := range assignments {
+			if assignment.Stage == stage && assignment.IsOpen() {
+				pendingStages = append(pendingStages, string(stage))
+				break
+			}
+		}
+	}
+	if len(pendingStages) > 0 {
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Order cannot be moved to %s while the %s work of its items is pending", to, strings.Join(pendingStages, ", ")), nil)
+	}
+
+	return nil
+}
+
+// getStatusTransitions returns the channel specific transitions from master config,
+// falls back to the default transitions when not configured or invalid
+func (svc orderService) getStatusTransitions(ctx *context.Context) map[entities.OrderStatus][]entities.OrderStatus {
+	value, err := svc.masterConfigSvc.GetByName(ctx, constants.CONFIG_ORDER_STATUS_TRANSITIONS)
+	if err != nil || util.IsNilOrEmptyString(&value) {
+		return entities.DefaultOrderStatusTransitions
+	}
+
+	transitions := make(map[entities.OrderStatus][]entities.OrderStatus)
+	jsonErr := json.Unmarshal([]byte(value), &transitions)
+	if jsonErr != nil || len(transitions) == 0 {
+		return entities.DefaultOrderStatusTransitions
+	}
+
+	// Statuses that are only a target (eg: DELIVERED) are valid statuses as well
+	for _, targets := range transitions {
+		for _, target := range targets {
+			if _, ok := transitions[target]; !ok {
+				transitions[target] = []entities.OrderStatus{}
+			}
+		}
+	}
+
+	return transitions
+}
+
+// stagesToComplete returns the production stages that are to be done before an order moves to the status
+func stagesToComplete(status entities.OrderStatus) []entities.ProductionStage {
+	for i, stage := range entities.ProductionStages {
+		if stage.OrderStatus() == status {
+			return entities.ProductionStages[:i]
+		}
+	}
+	if status == entities.READY_FOR_DELIVERY || status == entities.DELIVERED {
+		return entities.ProductionStages
+	}
+	return nil
+}
+
+// currentProductionStage is the earliest stage with work yet to be done once the production has started,
+// or the last stage worked on when all the work is done
+func currentProductionStage(assignments []entities.OrderItemAssignment) (entities.ProductionStage, bool) {
+	started := false
+	for _, assignment := range assignments {
+		if assignment.Status == entities.AssignmentStatusInProgress || assignment.Status == entities.AssignmentStatusDone {
+			started = true
+			break
+		}
+	}
+	if !started {
+		return "", false
+	}
+
+	var current entities.ProductionStage
+	for _, stage := range entities.ProductionStages {
+		for _, assignment := range assignments {
+			if assignment.Stage != stage || assignment.Status == entities.AssignmentStatusCancelled {
+				continue
+			}
+			if assignment.IsOpen() {
+				return stage, true
+			}
+			current = stage
+		}
+	}
+	return current, current != ""
+}
+
+// productionRank is how far the order is in production, the statuses before the production are 0
+// and a cancelled order is ranked last so that it is never moved
+func productionRank(status entities.OrderStatus) int {
+	for i, stage := range entities.ProductionStages {
+		if stage.OrderStatus() == status {
+			return i + 1
+		}
+	}
+	switch status {
+	case entities.READY_FOR_DELIVERY:
+		return len(entities.ProductionStages) + 1
+	case entities.DELIVERED:
+		return len(entities.ProductionStages) + 2
+	case entities.CANCELLED:
+		return len(entities.ProductionStages) + 3
+	}
+	return 0
+}
+
 // timeEqual compares two *time.Time values, handling nil cases
 func timeEqual(t1, t2 *time.Time) bool {
 	if t1 == nil && t2 == nil {
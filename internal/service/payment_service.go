@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/mapper"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	responseModel "github.com/imkarthi24/sf-backend/internal/model/response"
+	"github.com/imkarthi24/sf-backend/internal/repository"
+	"github.com/imkarthi24/sf-backend/internal/utils"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/loop-kar/pixie/util"
+)
+
+type PaymentService interface {
+	SavePayment(*context.Context, requestModel.Payment) *errs.XError
+	UpdatePayment(*context.Context, requestModel.Payment, uint) *errs.XError
+	Get(*context.Context, uint) (*responseModel.Payment, *errs.XError)
+	GetAll(*context.Context, string) ([]responseModel.Payment, *errs.XError)
+	GetByOrderId(*context.Context, uint) ([]responseModel.Payment, *errs.XError)
+	Delete(*context.Context, uint) *errs.XError
+}
+
+type paymentService struct {
+	paymentRepo repository.PaymentRepository
+	orderRepo   repository.OrderRepository
+	mapper      mapper.Mapper
+	respMapper  mapper.ResponseMapper
+}
+
+func ProvidePaymentService(repo repository.PaymentRepository, orderRepo repository.OrderRepository, mapper mapper.Mapper, respMapper mapper.ResponseMapper) PaymentService {
+	return paymentService{
+		paymentRepo: repo,
+		orderRepo:   orderRepo,
+		mapper:      mapper,
+		respMapper:  respMapper,
+	}
+}
+
+func (svc paymentService) SavePayment(ctx *context.Context, payment requestModel.Payment) *errs.XError {
+	dbPayment, err := svc.mapper.Payment(payment)
+	if err != nil {
+		return errs.NewXError(errs.INVALID_REQUEST, "Unable to save payment", err)
+	}
+
+	errr := svc.validatePayment(ctx, dbPayment, 0)
+	if errr != nil {
+		return errr
+	}
+
+	if dbPayment.PaymentDate == nil {
+		paymentDate := util.GetLocalTime()
+		dbPayment.PaymentDate = &paymentDate
+	}
+
+	// Set ReceivedById to the current user if it's not provided in the request
+	if dbPayment.ReceivedById == nil {
+		userID := utils.GetUserId(ctx)
+		dbPayment.ReceivedById = &userID
+	}
+
+	errr = svc.paymentRepo.Create(ctx, dbPayment)
+	if errr != nil {
+		return errr
+	}
+
+	return nil
+}
+
+func (svc paymentService) UpdatePayment(ctx *context.Context, payment requestModel.Payment, id uint) *errs.XError {
+	oldPayment, err := svc.paymentRepo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if oldPayment.Model == nil {
+		return errs.NewXError(errs.NOT_EXIST, "Payment not found", nil)
+	}
+
+	dbPayment, mapErr := svc.mapper.Payment(payment)
+	if mapErr != nil {
+		return errs.NewXError(errs.INVALID_REQUEST, "Unable to update payment", mapErr)
+	}
+
+	// A payment cannot be moved to another order
+	dbPayment.ID = id
+	dbPayment.OrderId = oldPayment.OrderId
+
+	err = svc.validatePayment(ctx, dbPayment, oldPayment.Amount)
+	if err != nil {
+		return err
+	}
+
+	err = svc.paymentRepo.Update(ctx, dbPayment)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (svc paymentService) Get(ctx *context.Context, id uint) (*responseModel.Payment, *errs.XError) {
+	payment, err := svc.paymentRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	mappedPayment, mapErr := svc.respMapper.Payment(payment)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map Payment data", mapErr)
+	}
+
+	return mappedPayment, nil
+}
+
+func (svc paymentService) GetAll(ctx *context.Context, search string) ([]responseModel.Payment, *errs.XError) {
+	payments, err := svc.paymentRepo.GetAll(ctx, search)
+	if err != nil {
+		return nil, err
+	}
+
+	mappedPayments, mapErr := svc.respMapper.Payments(payments)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map Payment data", mapErr)
+	}
+
+	return mappedPayments, nil
+}
+
+func (svc paymentService) GetByOrderId(ctx *context.Context, orderId uint) ([]responseModel.Payment, *errs.XError) {
+	payments, err := svc.paymentRepo.GetByOrderId(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+
+	mappedPayments, mapErr := svc.respMapper.Payments(payments)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map Payment data", mapErr)
+	}
+
+	return mappedPayments, nil
+}
+
+func (svc paymentService) Delete(ctx *context.Context, id uint) *errs.XError {
+	err := svc.paymentRepo.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validatePayment checks the payment against the order it is recorded for.
+// existingAmount is the amount already recorded for this payment (0 for a new payment)
+// so that it is not counted twice while computing the balance.
+func (svc paymentService) validatePayment(ctx *context.Context, payment *entities.Payment, existingAmount float64) *errs.XError {
+	if payment.Amount <= 0 {
+		return errs.NewXError(errs.VALIDATION, "Payment amount should be greater than zero", nil)
+	}
+
+	switch payment.PaymentType {
+	case entities.PaymentTypeAdvance, entities.PaymentTypePart, entities.PaymentTypeFinal:
+	default:
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Invalid payment type %s", payment.PaymentType), nil)
+	}
+
+	switch payment.PaymentMethod {
+	case entities.PaymentMethodCash, entities.PaymentMethodUPI, entities.PaymentMethodCard,
+		entities.PaymentMethodBankTransfer, entities.PaymentMethodCheque:
+	default:
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Invalid payment method %s", payment.PaymentMethod), nil)
+	}
+
+	order, err := svc.orderRepo.Get(ctx, payment.OrderId)
+	if err != nil {
+		return err
+	}
+	// the order is not scoped to the channel, the payment would be recorded against the order of another channel
+	if channelId := utils.GetChannelId(ctx); order.Model == nil || (channelId != 0 && order.ChannelId != channelId) {
+		return errs.NewXError(errs.NOT_EXIST, "Order not found", nil)
+	}
+	if order.Status == entities.CANCELLED {
+		return errs.NewXError(errs.VALIDATION, "Payments cannot be recorded for a cancelled order", nil)
+	}
+
+	// amounts are compared in paise, the float sums of the earlier payments are not exact
+	balance := roundAmount(order.OrderValue + order.AdditionalCharges - (order.PaidAmount - existingAmount))
+	amount := roundAmount(payment.Amount)
+	if amount > balance {
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Payment amount exceeds the outstanding balance of %.2f", balance), nil)
+	}
+	if payment.PaymentType == entities.PaymentTypeFinal && amount != balance {
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Final payment should settle the outstanding balance of %.2f", balance), nil)
+	}
+
+	return nil
+}
@@ -0,0 +1,422 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/mapper"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	responseModel "github.com/imkarthi24/sf-backend/internal/model/response"
+	"github.com/imkarthi24/sf-backend/internal/repository"
+	"github.com/imkarthi24/sf-backend/internal/utils"
+	"github.com/loop-kar/pixie/errs"
+	"github.com/loop-kar/pixie/util"
+)
+
+const payoutStatementLineTypeSettlement = "SETTLEMENT"
+
+type PayoutService interface {
+	AccruePayout(*context.Context, *entities.OrderItemAssignment) *errs.XError
+	ReversePayout(*context.Context, *entities.OrderItemAssignment) *errs.XError
+	SaveSettlement(*context.Context, requestModel.PayoutSettlement) *errs.XError
+	GetSettlement(*context.Context, uint) (*responseModel.PayoutSettlement, *errs.XError)
+	GetSettlements(*context.Context, requestModel.PayoutFilter) ([]responseModel.PayoutSettlement, *errs.XError)
+	GetEntries(*context.Context, requestModel.PayoutFilter) ([]responseModel.PayoutEntry, *errs.XError)
+	GetOutstanding(*context.Context) ([]responseModel.PayoutOutstanding, *errs.XError)
+	GetStatement(*context.Context, uint, *time.Time, *time.Time) (*responseModel.PayoutStatement, *errs.XError)
+	ExportStatement(*context.Context, uint, *time.Time, *time.Time) ([]byte, string, *errs.XError)
+}
+
+type payoutService struct {
+	payoutRepo repository.PayoutRepository
+	userRepo   repository.UserRepository
+	respMapper mapper.ResponseMapper
+}
+
+func ProvidePayoutService(repo repository.PayoutRepository, userRepo repository.UserRepository, respMapper mapper.ResponseMapper) PayoutService {
+	return payoutService{
+		payoutRepo: repo,
+		userRepo:   userRepo,
+		respMapper: respMapper,
+	}
+}
+
+// AccruePayout adds the amount of a done assignment to the ledger of the tailor.
+// Only the outsourced tailors are paid per piece, an assignment is accrued only once till it is reversed.
+func (svc payoutService) AccruePayout(ctx *context.Context, assignment *entities.OrderItemAssignment) *errs.XError {
+	amount := roundAmount(float64(assignment.Quantity) * assignment.PieceRate)
+	if amount == 0 {
+		return nil
+	}
+
+	tailor, err := svc.userRepo.Get(ctx, assignment.AssignedToId)
+	if err != nil {
+		return err
+	}
+	if tailor.Model == nil || tailor.Role != entities.OUTSOURCED {
+		return nil
+	}
+
+	entries, err := svc.payoutRepo.GetEntriesByAssignmentId(ctx, assignment.ID)
+	if err != nil {
+		return err
+	}
+	if sumPayoutEntries(entries) != 0 {
+		return nil
+	}
+
+	return svc.payoutRepo.CreateEntry(ctx, &entities.PayoutEntry{
+		Model:        &entities.Model{IsActive: true},
+		Type:         entities.PayoutEntryTypeAccrual,
+		Amount:       amount,
+		Quantity:     assignment.Quantity,
+		PieceRate:    assignment.PieceRate,
+		Description:  payoutDescription(assignment),
+		AccruedAt:    util.GetLocalTime(),
+		TailorId:     assignment.AssignedToId,
+		AssignmentId: assignment.ID,
+	})
+}
+
+// ReversePayout takes back the payout of an assignment whose work is reopened or repriced.
+// The outstanding entries are removed, the settled amount is reversed to be adjusted in the next settlement.
+func (svc payoutService) ReversePayout(ctx *context.Context, assignment *entities.OrderItemAssignment) *errs.XError {
+	entries, err := svc.payoutRepo.GetEntriesByAssignmentId(ctx, assignment.ID)
+	if err != nil {
+		return err
+	}
+
+	var outstandingIds []uint
+	var settled []entities.PayoutEntry
+	for _, entry := range entries {
+		if entry.SettlementId == nil {
+			outstandingIds = append(outstandingIds, entry.ID)
+		} else {
+			settled = append(settled, entry)
+		}
+	}
+
+	if len(outstandingIds) > 0 {
+		err = svc.payoutRepo.DeleteEntries(ctx, outstandingIds)
+		if err != nil {
+			return err
+		}
+	}
+
+	settledAmount := sumPayoutEntries(settled)
+	if settledAmount == 0 {
+		return nil
+	}
+
+	return svc.payoutRepo.CreateEntry(ctx, &entities.PayoutEntry{
+		Model:        &entities.Model{IsActive: true},
+		Type:         entities.PayoutEntryTypeReversal,
+		Amount:       -settledAmount,
+		Quantity:     assignment.Quantity,
+		PieceRate:    assignment.PieceRate,
+		Description:  "Reversed: " + payoutDescription(assignment),
+		AccruedAt:    util.GetLocalTime(),
+		TailorId:     settled[0].TailorId,
+		AssignmentId: assignment.ID,
+	})
+}
+
+// SaveSettlement pays the tailor the entries outstanding till the given date
+func (svc payoutService) SaveSettlement(ctx *context.Context, request requestModel.PayoutSettlement) *errs.XError {
+	paymentMethod := entities.PaymentMethod(request.PaymentMethod)
+	switch paymentMethod {
+	case entities.PaymentMethodCash, entities.PaymentMethodUPI, entities.PaymentMethodCard,
+		entities.PaymentMethodBankTransfer, entities.PaymentMethodCheque:
+	default:
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Invalid payment method %s", request.PaymentMethod), nil)
+	}
+
+	now := util.GetLocalTime()
+	upTo := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if request.UpTo != nil {
+		date, err := util.GenerateDateTimeFromString(request.UpTo)
+		if err != nil {
+			return errs.NewXError(errs.INVALID_REQUEST, "Invalid settlement date", err)
+		}
+		upTo = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, now.Location())
+	}
+
+	tailor, err := svc.userRepo.Get(ctx, request.TailorId)
+	if err != nil {
+		return err
+	}
+	if tailor.Model == nil {
+		return errs.NewXError(errs.NOT_EXIST, "Tailor not found", nil)
+	}
+
+	entries, err := svc.payoutRepo.GetOutstandingEntries(ctx, request.TailorId, upTo.AddDate(0, 0, 1))
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return errs.NewXError(errs.VALIDATION, "Nothing is outstanding to be settled for the tailor", nil)
+	}
+
+	amount := sumPayoutEntries(entries)
+	if amount <= 0 {
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("The outstanding amount of %.2f is not payable, it is adjusted against the next work done", amount), nil)
+	}
+
+	entryIds := make([]uint, 0, len(entries))
+	for _, entry := range entries {
+		entryIds = append(entryIds, entry.ID)
+	}
+
+	userId := utils.GetUserId(ctx)
+	settlement := entities.PayoutSettlement{
+		Model:           &entities.Model{IsActive: true},
+		Amount:          amount,
+		EntryCount:      len(entries),
+		PeriodFrom:      &entries[0].AccruedAt,
+		PeriodTo:        upTo,
+		SettledAt:       now,
+		PaymentMethod:   paymentMethod,
+		ReferenceNumber: request.ReferenceNumber,
+		Notes:           request.Notes,
+		TailorId:        request.TailorId,
+		SettledById:     &userId,
+	}
+	err = svc.payoutRepo.CreateSettlement(ctx, &settlement)
+	if err != nil {
+		return err
+	}
+
+	return svc.payoutRepo.MarkSettled(ctx, entryIds, settlement.ID)
+}
+
+func (svc payoutService) GetSettlement(ctx *context.Context, id uint) (*responseModel.PayoutSettlement, *errs.XError) {
+	settlement, err := svc.payoutRepo.GetSettlement(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if settlement.Model == nil || (isOutsourced(ctx) && settlement.TailorId != utils.GetUserId(ctx)) {
+		return nil, errs.NewXError(errs.NOT_EXIST, "Settlement not found", nil)
+	}
+
+	mappedSettlement, mapErr := svc.respMapper.PayoutSettlement(settlement)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map PayoutSettlement data", mapErr)
+	}
+
+	return mappedSettlement, nil
+}
+
+func (svc payoutService) GetSettlements(ctx *context.Context, filter requestModel.PayoutFilter) ([]responseModel.PayoutSettlement, *errs.XError) {
+	// Outsourced users only see their own payouts
+	if isOutsourced(ctx) {
+		userId := utils.GetUserId(ctx)
+		filter.TailorId = &userId
+	}
+
+	settlements, err := svc.payoutRepo.GetSettlements(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	mappedSettlements, mapErr := svc.respMapper.PayoutSettlements(settlements)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map PayoutSettlement data", mapErr)
+	}
+
+	return mappedSettlements, nil
+}
+
+func (svc payoutService) GetEntries(ctx *context.Context, filter requestModel.PayoutFilter) ([]responseModel.PayoutEntry, *errs.XError) {
+	if isOutsourced(ctx) {
+		userId := utils.GetUserId(ctx)
+		filter.TailorId = &userId
+	}
+
+	entries, err := svc.payoutRepo.GetEntries(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	mappedEntries, mapErr := svc.respMapper.PayoutEntries(entries)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map PayoutEntry data", mapErr)
+	}
+
+	return mappedEntries, nil
+}
+
+func (svc payoutService) GetOutstanding(ctx *context.Context) ([]responseModel.PayoutOutstanding, *errs.XError) {
+	outstanding, err := svc.payoutRepo.GetOutstanding(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isOutsourced(ctx) {
+		return outstanding, nil
+	}
+
+	userId := utils.GetUserId(ctx)
+	result := make([]responseModel.PayoutOutstanding, 0)
+	for _, tailor := range outstanding {
+		if tailor.TailorId == userId {
+			result = append(result, tailor)
+		}
+	}
+	return result, nil
+}
+
+// GetStatement returns the ledger of the tailor between the from and to dates (both inclusive).
+// The range defaults to the start of the current month till today.
+func (svc payoutService) GetStatement(ctx *context.Context, tailorId uint, from *time.Time, to *time.Time) (*responseModel.PayoutStatement, *errs.XError) {
+	if isOutsourced(ctx) && tailorId != utils.GetUserId(ctx) {
+		return nil, errs.NewXError(errs.NOT_EXIST, "Tailor not found", nil)
+	}
+
+	tailor, err := svc.userRepo.Get(ctx, tailorId)
+	if err != nil {
+		return nil, err
+	}
+	if tailor.Model == nil {
+		return nil, errs.NewXError(errs.NOT_EXIST, "Tailor not found", nil)
+	}
+
+	rangeStart, rangeEnd, err := reportDateRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+	end := rangeEnd.AddDate(0, 0, 1)
+
+	openingBalance, err := svc.payoutRepo.GetBalance(ctx, tailorId, rangeStart)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := requestModel.PayoutFilter{TailorId: &tailorId, From: &rangeStart, To: &end}
+	entries, err := svc.payoutRepo.GetEntries(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	settlements, err := svc.payoutRepo.GetSettlements(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	statement := responseModel.PayoutStatement{
+		TailorId:       tailorId,
+		Tailor:         tailor.FirstName + " " + tailor.LastName,
+		From:           rangeStart,
+		To:             rangeEnd,
+		OpeningBalance: roundAmount(openingBalance),
+		Lines:          make([]responseModel.PayoutStatementLine, 0, len(entries)+len(settlements)),
+	}
+
+	for _, entry := range entries {
+		statement.Accrued += entry.Amount
+		statement.Lines = append(statement.Lines, responseModel.PayoutStatementLine{
+			Date:        entry.AccruedAt,
+			Type:        string(entry.Type),
+			Description: entry.Description,
+			Reference:   fmt.Sprintf("Assignment #%d", entry.AssignmentId),
+			Amount:      entry.Amount,
+		})
+	}
+	for _, settlement := range settlements {
+		statement.Settled += settlement.Amount
+		reference := fmt.Sprintf("Settlement #%d", settlement.ID)
+		if !util.IsNilOrEmptyString(settlement.ReferenceNumber) {
+			reference = *settlement.ReferenceNumber
+		}
+		statement.Lines = append(statement.Lines, responseModel.PayoutStatementLine{
+			Date:        settlement.SettledAt,
+			Type:        payoutStatementLineTypeSettlement,
+			Description: fmt.Sprintf("Paid by %s for %d entries", settlement.PaymentMethod, settlement.EntryCount),
+			Reference:   reference,
+			Amount:      -settlement.Amount,
+		})
+	}
+
+	sort.SliceStable(statement.Lines, func(i, j int) bool {
+		return statement.Lines[i].Date.Before(statement.Lines[j].Date)
+	})
+
+	balance := statement.OpeningBalance
+	for i := range statement.Lines {
+		balance = roundAmount(balance + statement.Lines[i].Amount)
+		statement.Lines[i].Balance = balance
+	}
+
+	statement.Accrued = roundAmount(statement.Accrued)
+	statement.Settled = roundAmount(statement.Settled)
+	statement.ClosingBalance = roundAmount(statement.OpeningBalance + statement.Accrued - statement.Settled)
+
+	return &statement, nil
+}
+
+// ExportStatement returns the statement of the tailor as a csv file
+func (svc payoutService) ExportStatement(ctx *context.Context, tailorId uint, from *time.Time, to *time.Time) ([]byte, string, *errs.XError) {
+	statement, err := svc.GetStatement(ctx, tailorId, from, to)
+	if err != nil {
+		return nil, "", err
+	}
+
+	formatAmount := func(amount float64) string {
+		return strconv.FormatFloat(amount, 'f', 2, 64)
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	rows := [][]string{
+		{"Tailor", statement.Tailor},
+		{"Period", statement.From.Format(time.DateOnly) + " to " + statement.To.Format(time.DateOnly)},
+		{},
+		{"Date", "Type", "Description", "Reference", "Amount", "Balance"},
+		{statement.From.Format(time.DateOnly), "", "Opening balance", "", "", formatAmount(statement.OpeningBalance)},
+	}
+	for _, line := range statement.Lines {
+		rows = append(rows, []string{
+			line.Date.Format(time.DateOnly),
+			line.Type,
+			line.Description,
+			line.Reference,
+			formatAmount(line.Amount),
+			formatAmount(line.Balance),
+		})
+	}
+	rows = append(rows, []string{statement.To.Format(time.DateOnly), "", "Closing balance", "", "", formatAmount(statement.ClosingBalance)})
+
+	writeErr := writer.WriteAll(rows)
+	if writeErr != nil {
+		return nil, "", errs.NewXError(errs.IO, "Unable to export payout statement", writeErr)
+	}
+
+	fileName := fmt.Sprintf("payout-statement-%d-%s-%s.csv", tailorId, statement.From.Format("20060102"), statement.To.Format("20060102"))
+	return buf.Bytes(), fileName, nil
+}
+
+// payoutDescription describes the work an entry is paid for, eg: "STITCHING of 2 x Blouse (order #12)"
+func payoutDescription(assignment *entities.OrderItemAssignment) string {
+	if assignment.OrderItem == nil {
+		return fmt.Sprintf("%s of %d pieces", assignment.Stage, assignment.Quantity)
+	}
+	return fmt.Sprintf("%s of %d x %s (order #%d)", assignment.Stage, assignment.Quantity, assignment.OrderItem.Description, assignment.OrderItem.OrderId)
+}
+
+func sumPayoutEntries(entries []entities.PayoutEntry) float64 {
+	var total float64
+	for _, entry := range entries {
+		total += entry.Amount
+	}
+	return roundAmount(total)
+}
+
+// roundAmount rounds the amount to paise so that the sums of the ledger compare exactly
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
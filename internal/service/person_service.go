@@ -13,23 +13,25 @@ import (
 type PersonService interface {
 	SavePerson(*context.Context, requestModel.Person) *errs.XError
 	UpdatePerson(*context.Context, requestModel.Person, uint) *errs.XError
-	Get(*context.Context, uint) (*responseModel.Person, *errs.XError)
+	Get(*context.Context, uint, string) (*responseModel.Person, *errs.XError)
 	GetAll(*context.Context, string) ([]responseModel.Person, *errs.XError)
 	Delete(*context.Context, uint) *errs.XError
-	GetByCustomerId(*context.Context, uint) ([]responseModel.Person, *errs.XError)
+	GetByCustomerId(*context.Context, uint, string) ([]responseModel.Person, *errs.XError)
 }
 
 type personService struct {
-	personRepo repository.PersonRepository
-	mapper     mapper.Mapper
-	respMapper mapper.ResponseMapper
+	personRepo      repository.PersonRepository
+	masterConfigSvc MasterConfigService
+	mapper          mapper.Mapper
+	respMapper      mapper.ResponseMapper
 }
 
-func ProvidePersonService(repo repository.PersonRepository, mapper mapper.Mapper, respMapper mapper.ResponseMapper) PersonService {
+func ProvidePersonService(repo repository.PersonRepository, masterConfigSvc MasterConfigService, mapper mapper.Mapper, respMapper mapper.ResponseMapper) PersonService {
 	return personService{
-		personRepo: repo,
-		mapper:     mapper,
-		respMapper: respMapper,
+		personRepo:      repo,
+		masterConfigSvc: masterConfigSvc,
+		mapper:          mapper,
+		respMapper:      respMapper,
 	}
 }
 
@@ -61,12 +63,24 @@ func (svc personService) UpdatePerson(ctx *context.Context, person requestModel.
 	return nil
 }
 
-func (svc personService) Get(ctx *context.Context, id uint) (*responseModel.Person, *errs.XError) {
+// Get returns the person with the measurement values in the given unit, the channel unit when it is empty
+func (svc personService) Get(ctx *context.Context, id uint, unit string) (*responseModel.Person, *errs.XError) {
+	channelUnit := getChannelMeasurementUnit(ctx, svc.masterConfigSvc)
+	targetUnit, err := parseMeasurementUnit(unit, channelUnit)
+	if err != nil {
+		return nil, err
+	}
+
 	person, err := svc.personRepo.Get(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
+	err = convertMeasurementsUnit(person.Measurements, channelUnit, targetUnit)
+	if err != nil {
+		return nil, err
+	}
+
 	mappedPerson, mapErr := svc.respMapper.Person(person)
 	if mapErr != nil {
 		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map Person data", mapErr)
@@ -97,12 +111,25 @@ func (svc personService) Delete(ctx *context.Context, id uint) *errs.XError {
 	return nil
 }
 
-func (svc personService) GetByCustomerId(ctx *context.Context, customerId uint) ([]responseModel.Person, *errs.XError) {
+func (svc personService) GetByCustomerId(ctx *context.Context, customerId uint, unit string) ([]responseModel.Person, *errs.XError) {
+	channelUnit := getChannelMeasurementUnit(ctx, svc.masterConfigSvc)
+	targetUnit, err := parseMeasurementUnit(unit, channelUnit)
+	if err != nil {
+		return nil, err
+	}
+
 	persons, err := svc.personRepo.GetByCustomerId(ctx, customerId)
 	if err != nil {
 		return nil, err
 	}
 
+	for i := range persons {
+		err = convertMeasurementsUnit(persons[i].Measurements, channelUnit, targetUnit)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	mappedPersons, mapErr := svc.respMapper.Persons(persons)
 	if mapErr != nil {
 		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map Person data", mapErr)
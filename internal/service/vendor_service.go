@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/mapper"
+	requestModel "github.com/imkarthi24/sf-backend/internal/model/request"
+	responseModel "github.com/imkarthi24/sf-backend/internal/model/response"
+	"github.com/imkarthi24/sf-backend/internal/repository"
+	"github.com/imkarthi24/sf-backend/internal/utils/validator"
+	"github.com/loop-kar/pixie/errs"
+)
+
+type VendorService interface {
+	SaveVendor(*context.Context, requestModel.Vendor) *errs.XError
+	UpdateVendor(*context.Context, requestModel.Vendor, uint) *errs.XError
+	Get(*context.Context, uint) (*responseModel.Vendor, *errs.XError)
+	GetAll(*context.Context, string) ([]responseModel.Vendor, *errs.XError)
+	Delete(*context.Context, uint) *errs.XError
+	AutocompleteVendor(*context.Context, string) ([]responseModel.VendorAutoComplete, *errs.XError)
+	GetSpend(*context.Context, *time.Time, *time.Time) ([]responseModel.VendorSpend, *errs.XError)
+}
+
+type vendorService struct {
+	vendorRepo repository.VendorRepository
+	mapper     mapper.Mapper
+	respMapper mapper.ResponseMapper
+}
+
+func ProvideVendorService(repo repository.VendorRepository, mapper mapper.Mapper, respMapper mapper.ResponseMapper) VendorService {
+	return vendorService{
+		vendorRepo: repo,
+		mapper:     mapper,
+		respMapper: respMapper,
+	}
+}
+
+func (svc vendorService) SaveVendor(ctx *context.Context, vendor requestModel.Vendor) *errs.XError {
+	dbVendor, err := svc.mapper.Vendor(vendor)
+	if err != nil {
+		return errs.NewXError(errs.INVALID_REQUEST, "Unable to save vendor", err)
+	}
+
+	errr := svc.prepareVendor(ctx, dbVendor, 0)
+	if errr != nil {
+		return errr
+	}
+
+	return svc.vendorRepo.Create(ctx, dbVendor)
+}
+
+func (svc vendorService) UpdateVendor(ctx *context.Context, vendor requestModel.Vendor, id uint) *errs.XError {
+	oldVendor, err := svc.vendorRepo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if oldVendor.Model == nil {
+		return errs.NewXError(errs.NOT_EXIST, "Vendor not found", nil)
+	}
+
+	dbVendor, mapErr := svc.mapper.Vendor(vendor)
+	if mapErr != nil {
+		return errs.NewXError(errs.INVALID_REQUEST, "Unable to update vendor", mapErr)
+	}
+
+	dbVendor.ID = id
+	err = svc.prepareVendor(ctx, dbVendor, id)
+	if err != nil {
+		return err
+	}
+
+	return svc.vendorRepo.Update(ctx, dbVendor)
+}
+
+func (svc vendorService) Get(ctx *context.Context, id uint) (*responseModel.Vendor, *errs.XError) {
+	vendor, err := svc.vendorRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if vendor.Model == nil {
+		return nil, errs.NewXError(errs.NOT_EXIST, "Vendor not found", nil)
+	}
+
+	mappedVendor, mapErr := svc.respMapper.Vendor(vendor)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map Vendor data", mapErr)
+	}
+
+	return mappedVendor, nil
+}
+
+func (svc vendorService) GetAll(ctx *context.Context, search string) ([]responseModel.Vendor, *errs.XError) {
+	vendors, err := svc.vendorRepo.GetAll(ctx, search)
+	if err != nil {
+		return nil, err
+	}
+
+	mappedVendors, mapErr := svc.respMapper.Vendors(vendors)
+	if mapErr != nil {
+		return nil, errs.NewXError(errs.MAPPING_ERROR, "Failed to map Vendor data", mapErr)
+	}
+
+	return mappedVendors, nil
+}
+
+// Delete removes the vendor, the expenses billed by the vendor keep the company name
+func (svc vendorService) Delete(ctx *context.Context, id uint) *errs.XError {
+	return svc.vendorRepo.Delete(ctx, id)
+}
+
+func (svc vendorService) AutocompleteVendor(ctx *context.Context, search string) ([]responseModel.VendorAutoComplete, *errs.XError) {
+	vendors, err := svc.vendorRepo.AutocompleteVendor(ctx, search)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]responseModel.VendorAutoComplete, 0)
+	for _, vendor := range vendors {
+		res = append(res, responseModel.VendorAutoComplete{
+			VendorID:    vendor.ID,
+			Name:        vendor.Name,
+			PhoneNumber: vendor.PhoneNumber,
+			GSTIN:       vendor.GSTIN,
+		})
+	}
+
+	return res, nil
+}
+
+// GetSpend returns the expenses billed by each vendor between the from and to dates (both inclusive)
+func (svc vendorService) GetSpend(ctx *context.Context, from *time.Time, to *time.Time) ([]responseModel.VendorSpend, *errs.XError) {
+	rangeStart, rangeEnd, err := reportDateRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	spend, err := svc.vendorRepo.GetSpend(ctx, rangeStart, rangeEnd.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	if spend == nil {
+		spend = make([]responseModel.VendorSpend, 0)
+	}
+
+	return spend, nil
+}
+
+func (svc vendorService) prepareVendor(ctx *context.Context, vendor *entities.Vendor, id uint) *errs.XError {
+	vendor.Name = strings.TrimSpace(vendor.Name)
+	if vendor.GSTIN != nil {
+		gstin := strings.ToUpper(strings.TrimSpace(*vendor.GSTIN))
+		vendor.GSTIN = &gstin
+	}
+
+	err := validator.ValidateVendor(*vendor).ToXError("Invalid vendor")
+	if err != nil {
+		return err
+	}
+
+	existing, err := svc.vendorRepo.GetByNameKey(ctx, vendorNameKey(vendor.Name))
+	if err != nil {
+		return err
+	}
+	if existing.Model != nil && existing.ID != id {
+		return errs.NewXError(errs.VALIDATION, fmt.Sprintf("Vendor %s already exists", existing.Name), nil)
+	}
+	return nil
+}
+
+// linkExpenseVendor sets the company name of the expense from its vendor. Without a vendor, the vendor is
+// matched by the company name and created when the company is billed for the first time.
+func linkExpenseVendor(ctx *context.Context, vendorRepo repository.VendorRepository, expense *entities.Expense) *errs.XError {
+	if expense.VendorId != nil {
+		vendor, err := vendorRepo.Get(ctx, *expense.VendorId)
+		if err != nil {
+			return err
+		}
+		if vendor.Model == nil {
+			return errs.NewXError(errs.NOT_EXIST, "Vendor not found", nil)
+		}
+
+		expense.CompanyName = vendor.Name
+		return nil
+	}
+
+	expense.CompanyName = strings.TrimSpace(expense.CompanyName)
+	key := vendorNameKey(expense.CompanyName)
+	if key == "" {
+		return nil
+	}
+
+	vendor, err := vendorRepo.GetByNameKey(ctx, key)
+	if err != nil {
+		return err
+	}
+	if vendor.Model == nil {
+		vendor = &entities.Vendor{
+			Model:   &entities.Model{IsActive: true},
+			Name:    expense.CompanyName,
+			Address: expense.Location,
+		}
+		err = vendorRepo.Create(ctx, vendor)
+		if err != nil {
+			return err
+		}
+	}
+
+	expense.VendorId = &vendor.ID
+	expense.CompanyName = vendor.Name
+	return nil
+}
+
+// vendorNameKey lower cases the name dropping the spaces and punctuation, so that "S.R. Textiles" and
+// "sr textiles" are the same vendor. It matches the key compared by the vendor repository.
+func vendorNameKey(name string) string {
+	var key strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			key.WriteRune(r)
+		}
+	}
+	return key.String()
+}
@@ -0,0 +1,170 @@
+package measurement
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+)
+
+const cmPerInch = 2.54
+
+// ParseUnit reads a unit case insensitively, cm / centimetre and inch / in are accepted
+func ParseUnit(unit string) (entities.MeasurementUnit, bool) {
+	switch strings.ToLower(strings.TrimSpace(unit)) {
+	case "cm", "cms", "centimetre", "centimeter":
+		return entities.MeasurementUnitCm, true
+	case "inch", "inches", "in":
+		return entities.MeasurementUnitInch, true
+	}
+	return "", false
+}
+
+// Convert converts a value between units, rounded to two decimals
+func Convert(value float64, from entities.MeasurementUnit, to entities.MeasurementUnit) float64 {
+	if from == to || from == "" || to == "" {
+		return value
+	}
+
+	if from == entities.MeasurementUnitInch && to == entities.MeasurementUnitCm {
+		value = value * cmPerInch
+	} else if from == entities.MeasurementUnitCm && to == entities.MeasurementUnitInch {
+		value = value / cmPerInch
+	}
+
+	return math.Round(value*100) / 100
+}
+
+// ConvertValues converts the numeric values of a measurement json object between units.
+// Numeric strings are converted to numbers, other values are left as they are.
+func ConvertValues(values json.RawMessage, from entities.MeasurementUnit, to entities.MeasurementUnit) (json.RawMessage, error) {
+	if len(values) == 0 || string(values) == "null" || from == to || from == "" || to == "" {
+		return values, nil
+	}
+
+	parsed := make(map[string]interface{})
+	err := json.Unmarshal(values, &parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, value := range parsed {
+		switch v := value.(type) {
+		case float64:
+			parsed[name] = Convert(v, from, to)
+		case string:
+			number, parseErr := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if parseErr == nil {
+				parsed[name] = Convert(number, from, to)
+			}
+		}
+	}
+
+	return json.Marshal(parsed)
+}
+
+type ChangeType string
+
+const (
+	ChangeTypeAdded    ChangeType = "ADDED"
+	ChangeTypeRemoved  ChangeType = "REMOVED"
+	ChangeTypeModified ChangeType = "MODIFIED"
+)
+
+// FieldChange is the change of a single measurement between two versions
+type FieldChange struct {
+	Field    string
+	Change   ChangeType
+	OldValue interface{}
+	NewValue interface{}
+}
+
+// Diff compares two versions of measurement values field by field, unchanged fields are left out.
+// Numbers and numeric strings with the same value are taken as equal, the changes are sorted by field.
+func Diff(oldValues json.RawMessage, newValues json.RawMessage) ([]FieldChange, error) {
+	oldParsed, err := parseValues(oldValues)
+	if err != nil {
+		return nil, err
+	}
+	newParsed, err := parseValues(newValues)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]FieldChange, 0)
+	for field, oldValue := range oldParsed {
+		newValue, ok := newParsed[field]
+		if !ok || isEmptyValue(newValue) {
+			if !isEmptyValue(oldValue) {
+				changes = append(changes, FieldChange{Field: field, Change: ChangeTypeRemoved, OldValue: oldValue})
+			}
+			continue
+		}
+		if isEmptyValue(oldValue) {
+			changes = append(changes, FieldChange{Field: field, Change: ChangeTypeAdded, NewValue: newValue})
+			continue
+		}
+		if !isSameValue(oldValue, newValue) {
+			changes = append(changes, FieldChange{Field: field, Change: ChangeTypeModified, OldValue: oldValue, NewValue: newValue})
+		}
+	}
+
+	for field, newValue := range newParsed {
+		if _, ok := oldParsed[field]; !ok && !isEmptyValue(newValue) {
+			changes = append(changes, FieldChange{Field: field, Change: ChangeTypeAdded, NewValue: newValue})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+
+	return changes, nil
+}
+
+func parseValues(values json.RawMessage) (map[string]interface{}, error) {
+	parsed := make(map[string]interface{})
+	if len(values) == 0 || string(values) == "null" {
+		return parsed, nil
+	}
+
+	err := json.Unmarshal(values, &parsed)
+	if err != nil {
+		return nil, err
+	}
+	return parsed, nil
+}
+
+func isEmptyValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	str, ok := value.(string)
+	return ok && strings.TrimSpace(str) == ""
+}
+
+func isSameValue(oldValue interface{}, newValue interface{}) bool {
+	oldNumber, oldIsNumber := toNumber(oldValue)
+	newNumber, newIsNumber := toNumber(newValue)
+	if oldIsNumber && newIsNumber {
+		return oldNumber == newNumber
+	}
+
+	oldJSON, _ := json.Marshal(oldValue)
+	newJSON, _ := json.Marshal(newValue)
+	return string(oldJSON) == string(newJSON)
+}
+
+func toNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case string:
+		number, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return number, err == nil
+	}
+	return 0, false
+}
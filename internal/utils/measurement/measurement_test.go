@@ -0,0 +1,39 @@
+package measurement
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Convert(t *testing.T) {
+	require.Equal(t, 81.28, Convert(32, entities.MeasurementUnitInch, entities.MeasurementUnitCm))
+	require.Equal(t, 27.56, Convert(70, entities.MeasurementUnitCm, entities.MeasurementUnitInch))
+	require.Equal(t, 32.0, Convert(32, entities.MeasurementUnitInch, entities.MeasurementUnitInch))
+}
+
+func Test_ConvertValues(t *testing.T) {
+	values, err := ConvertValues(json.RawMessage(`{"Waist": 32, "Chest": "36", "Sleeve": "", "Notes": "loose"}`), entities.MeasurementUnitInch, entities.MeasurementUnitCm)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"Waist": 81.28, "Chest": 91.44, "Sleeve": "", "Notes": "loose"}`, string(values))
+
+	_, err = ConvertValues(json.RawMessage(`[1, 2]`), entities.MeasurementUnitInch, entities.MeasurementUnitCm)
+	require.Error(t, err)
+}
+
+func Test_Diff(t *testing.T) {
+	changes, err := Diff(json.RawMessage(`{"Waist": 32, "Chest": "36", "Sleeve": 10, "Hip": ""}`), json.RawMessage(`{"Waist": 34, "Chest": 36, "Hip": 40, "Neck": 14}`))
+	require.NoError(t, err)
+	require.Equal(t, []FieldChange{
+		{Field: "Hip", Change: ChangeTypeAdded, NewValue: 40.0},
+		{Field: "Neck", Change: ChangeTypeAdded, NewValue: 14.0},
+		{Field: "Sleeve", Change: ChangeTypeRemoved, OldValue: 10.0},
+		{Field: "Waist", Change: ChangeTypeModified, OldValue: 32.0, NewValue: 34.0},
+	}, changes)
+
+	changes, err = Diff(nil, json.RawMessage(`{"Waist": 32}`))
+	require.NoError(t, err)
+	require.Equal(t, []FieldChange{{Field: "Waist", Change: ChangeTypeAdded, NewValue: 32.0}}, changes)
+}
@@ -17,14 +17,14 @@ func ExtractFile(fileHeader *multipart.FileHeader) (*models.FileUpload, error) {
 
 	file, err := fileHeader.Open()
 	if err != nil {
-		return nil, errs.NewXError(errs.INVALID_REQUEST, "Error opening offer letter file", err)
+		return nil, errs.NewXError(errs.INVALID_REQUEST, "Error opening file", err)
 	}
 	defer file.Close()
 
 	// Read file into buffer
 	fileBytes, err := io.ReadAll(file)
 	if err != nil {
-		return nil, errs.NewXError(errs.IO, "Failed to read offer letter file", err)
+		return nil, errs.NewXError(errs.IO, "Failed to read file", err)
 	}
 
 	outFile := models.FileUpload{}
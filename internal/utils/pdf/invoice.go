@@ -0,0 +1,246 @@
+package pdf
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// InvoiceTemplate holds the branding of an invoice.
+// Templates are json files kept under templates/invoice_templates so that
+// each channel can have its own look by pointing Invoice.Template to a file.
+type InvoiceTemplate struct {
+	Title       string   `json:"title"`
+	AccentColor string   `json:"accentColor"`
+	LogoPath    string   `json:"logoPath"`
+	Currency    string   `json:"currency"`
+	Footer      string   `json:"footer"`
+	Terms       []string `json:"terms"`
+}
+
+type InvoiceParty struct {
+	Name        string
+	Address     string
+	PhoneNumber string
+	Email       string
+	TaxNumber   string
+}
+
+type InvoiceItem struct {
+	Description       string
+	Quantity          int
+	Price             float64
+	AdditionalCharges float64
+	Total             float64
+}
+
+type Invoice struct {
+	InvoiceNumber string
+	InvoiceDate   time.Time
+	OrderId       uint
+
+	Channel  InvoiceParty
+	Customer InvoiceParty
+
+	Items             []InvoiceItem
+	SubTotal          float64
+	AdditionalCharges float64
+	GrandTotal        float64
+	PaidAmount        float64
+	BalanceAmount     float64
+}
+
+// LoadInvoiceTemplate reads the template file from the given directory
+func LoadInvoiceTemplate(dir string, fileName string) (*InvoiceTemplate, error) {
+	content, err := os.ReadFile(filepath.Join(dir, filepath.Base(fileName)))
+	if err != nil {
+		return nil, err
+	}
+
+	template := InvoiceTemplate{}
+	err = json.Unmarshal(content, &template)
+	if err != nil {
+		return nil, err
+	}
+
+	if template.Title == "" {
+		template.Title = "INVOICE"
+	}
+	if template.Currency == "" {
+		template.Currency = "Rs."
+	}
+
+	return &template, nil
+}
+
+// GenerateInvoice renders the invoice as a single A4 PDF document
+func GenerateInvoice(invoice Invoice, template InvoiceTemplate) ([]byte, error) {
+	doc := fpdf.New("P", "mm", "A4", "")
+	doc.SetMargins(15, 15, 15)
+	doc.SetAutoPageBreak(true, 20)
+	tr := doc.UnicodeTranslatorFromDescriptor("")
+
+	red, green, blue := hexToRGB(template.AccentColor)
+	replacer := strings.NewReplacer("**COMPANY_NAME**", invoice.Channel.Name)
+
+	doc.SetFooterFunc(func() {
+		doc.SetY(-15)
+		doc.SetFont("Helvetica", "I", 8)
+		doc.SetTextColor(128, 128, 128)
+		doc.CellFormat(0, 10, tr(replacer.Replace(template.Footer)), "", 0, "C", false, 0, "")
+	})
+
+	doc.AddPage()
+
+	// Header : logo, channel details and invoice title
+	if template.LogoPath != "" {
+		if _, err := os.Stat(template.LogoPath); err == nil {
+			doc.ImageOptions(template.LogoPath, 15, 15, 25, 0, false, fpdf.ImageOptions{ReadDpi: true}, 0, "")
+			doc.SetX(45)
+		}
+	}
+
+	headerX := doc.GetX()
+	doc.SetFont("Helvetica", "B", 16)
+	doc.SetTextColor(red, green, blue)
+	doc.CellFormat(100, 8, tr(invoice.Channel.Name), "", 2, "L", false, 0, "")
+	doc.SetFont("Helvetica", "", 9)
+	doc.SetTextColor(60, 60, 60)
+	doc.SetX(headerX)
+	doc.MultiCell(100, 4.5, tr(partyLines(invoice.Channel)), "", "L", false)
+
+	doc.SetXY(130, 15)
+	doc.SetFont("Helvetica", "B", 18)
+	doc.SetTextColor(red, green, blue)
+	doc.CellFormat(65, 10, tr(template.Title), "", 2, "R", false, 0, "")
+	doc.SetFont("Helvetica", "", 9)
+	doc.SetTextColor(60, 60, 60)
+	doc.CellFormat(65, 5, tr(fmt.Sprintf("Invoice No : %s", invoice.InvoiceNumber)), "", 2, "R", false, 0, "")
+	doc.CellFormat(65, 5, tr(fmt.Sprintf("Date : %s", invoice.InvoiceDate.Format("02 Jan 2006"))), "", 2, "R", false, 0, "")
+	doc.CellFormat(65, 5, tr(fmt.Sprintf("Order No : %d", invoice.OrderId)), "", 2, "R", false, 0, "")
+
+	// Bill to
+	doc.SetY(max(doc.GetY(), 45) + 5)
+	doc.SetFont("Helvetica", "B", 10)
+	doc.SetTextColor(red, green, blue)
+	doc.CellFormat(0, 6, "Bill To", "", 1, "L", false, 0, "")
+	doc.SetFont("Helvetica", "", 9)
+	doc.SetTextColor(0, 0, 0)
+	doc.MultiCell(100, 4.5, tr(invoice.Customer.Name+"\n"+partyLines(invoice.Customer)), "", "L", false)
+	doc.Ln(5)
+
+	// Line items
+	widths := []float64{10, 80, 18, 25, 22, 25}
+	headers := []string{"#", "Description", "Qty", "Price", "Addl.", "Total"}
+	alignments := []string{"C", "L", "C", "R", "R", "R"}
+
+	doc.SetFont("Helvetica", "B", 9)
+	doc.SetFillColor(red, green, blue)
+	doc.SetTextColor(255, 255, 255)
+	for i, header := range headers {
+		doc.CellFormat(widths[i], 8, header, "1", 0, alignments[i], true, 0, "")
+	}
+	doc.Ln(-1)
+
+	doc.SetFont("Helvetica", "", 9)
+	doc.SetTextColor(0, 0, 0)
+	for i, item := range invoice.Items {
+		row := []string{
+			strconv.Itoa(i + 1),
+			item.Description,
+			strconv.Itoa(item.Quantity),
+			formatAmount(item.Price),
+			formatAmount(item.AdditionalCharges),
+			formatAmount(item.Total),
+		}
+		for j, value := range row {
+			doc.CellFormat(widths[j], 7, tr(value), "1", 0, alignments[j], false, 0, "")
+		}
+		doc.Ln(-1)
+	}
+	doc.Ln(3)
+
+	// Totals
+	totals := []struct {
+		label  string
+		amount float64
+		bold   bool
+	}{
+		{"Sub Total", invoice.SubTotal, false},
+		{"Additional Charges", invoice.AdditionalCharges, false},
+		{"Grand Total", invoice.GrandTotal, true},
+		{"Paid", invoice.PaidAmount, false},
+		{"Balance Due", invoice.BalanceAmount, true},
+	}
+	for _, total := range totals {
+		style := ""
+		if total.bold {
+			style = "B"
+		}
+		doc.SetFont("Helvetica", style, 9)
+		doc.SetX(115)
+		doc.CellFormat(45, 6, total.label, "", 0, "R", false, 0, "")
+		doc.CellFormat(35, 6, tr(fmt.Sprintf("%s %s", template.Currency, formatAmount(total.amount))), "", 1, "R", false, 0, "")
+	}
+
+	// Terms
+	if len(template.Terms) > 0 {
+		doc.Ln(8)
+		doc.SetFont("Helvetica", "B", 9)
+		doc.SetTextColor(red, green, blue)
+		doc.CellFormat(0, 6, "Terms & Conditions", "", 1, "L", false, 0, "")
+		doc.SetFont("Helvetica", "", 8)
+		doc.SetTextColor(60, 60, 60)
+		for i, term := range template.Terms {
+			doc.MultiCell(0, 4.5, tr(fmt.Sprintf("%d. %s", i+1, replacer.Replace(term))), "", "L", false)
+		}
+	}
+
+	var buf bytes.Buffer
+	err := doc.Output(&buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func partyLines(party InvoiceParty) string {
+	lines := make([]string, 0)
+	if party.Address != "" {
+		lines = append(lines, party.Address)
+	}
+	if party.PhoneNumber != "" {
+		lines = append(lines, "Phone : "+party.PhoneNumber)
+	}
+	if party.Email != "" {
+		lines = append(lines, "Email : "+party.Email)
+	}
+	if party.TaxNumber != "" {
+		lines = append(lines, "GSTIN : "+party.TaxNumber)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
+
+// hexToRGB converts #RRGGBB to rgb values, falls back to black on invalid input
+func hexToRGB(hex string) (int, int, int) {
+	hex = strings.TrimPrefix(hex, "#")
+	if len(hex) != 6 {
+		return 0, 0, 0
+	}
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return 0, 0, 0
+	}
+	return int(value >> 16 & 0xFF), int(value >> 8 & 0xFF), int(value & 0xFF)
+}
@@ -0,0 +1,125 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidSignature = errors.New("invalid or expired signature")
+
+type LocalConfig struct {
+	// Directory the files are stored in
+	BasePath string
+	// DownloadURL is the endpoint serving the signed downloads, eg: http://localhost:9000/api/sf/v1/attachment/file
+	DownloadURL string
+	// SecretKey signs the download urls
+	SecretKey string
+}
+
+// LocalStorage stores the files on the local filesystem, meant for development and single node setups.
+// Presigned urls point to DownloadURL and are verified with VerifySignature before serving the file.
+type LocalStorage struct {
+	config LocalConfig
+}
+
+func NewLocalStorage(cfg LocalConfig) *LocalStorage {
+	return &LocalStorage{config: cfg}
+}
+
+func (s *LocalStorage) Upload(ctx context.Context, key string, content io.Reader, size int64, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(file, content)
+	return err
+}
+
+func (s *LocalStorage) Download(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(path)
+}
+
+func (s *LocalStorage) PresignedURL(ctx context.Context, key string, fileName string, expiry time.Duration) (string, error) {
+	expires := strconv.FormatInt(time.Now().Add(expiry).Unix(), 10)
+
+	query := url.Values{}
+	query.Set("key", key)
+	query.Set("name", fileName)
+	query.Set("expires", expires)
+	query.Set("signature", s.sign(key, expires))
+
+	return fmt.Sprintf("%s?%s", s.config.DownloadURL, query.Encode()), nil
+}
+
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// VerifySignature checks the signature and expiry of a url generated by PresignedURL
+func (s *LocalStorage) VerifySignature(key string, expires string, signature string) error {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > expiresAt {
+		return ErrInvalidSignature
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(s.sign(key, expires))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func (s *LocalStorage) sign(key string, expires string) string {
+	mac := hmac.New(sha256.New, []byte(s.config.SecretKey))
+	mac.Write([]byte(key + "|" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// path resolves the key inside the base path, keys escaping the base path are rejected
+func (s *LocalStorage) path(key string) (string, error) {
+	base, err := filepath.Abs(s.config.BasePath)
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(base, filepath.FromSlash(key))
+	if !strings.HasPrefix(path, base+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid key %s", key)
+	}
+	return path, nil
+}
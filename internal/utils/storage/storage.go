@@ -0,0 +1,23 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+const (
+	ProviderS3    = "s3"
+	ProviderLocal = "local"
+)
+
+// Storage stores the uploaded files, objects are addressed by key
+// eg: channel-1/Order/12/2f1c...-fabric.jpg
+type Storage interface {
+	Upload(ctx context.Context, key string, content io.Reader, size int64, contentType string) error
+	// Download returns the content of the object, the caller has to close it
+	Download(ctx context.Context, key string) (io.ReadCloser, error)
+	// PresignedURL returns a url which allows downloading the object without authentication until expiry
+	PresignedURL(ctx context.Context, key string, fileName string, expiry time.Duration) (string, error)
+	Delete(ctx context.Context, key string) error
+}
@@ -2,7 +2,12 @@ package utils
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"strings"
 
 	"github.com/imkarthi24/sf-backend/internal/config"
 	"github.com/imkarthi24/sf-backend/internal/constants"
@@ -58,6 +63,18 @@ func GetAccessibleLocationIds(ctx *context.Context) []uint {
 	return session.AccessibleLocationIds
 }
 
+// NewSystemContext returns a context with a system session of the channel acting as the user,
+// for the jobs which work on the data of a channel outside of a request
+func NewSystemContext(channelId uint, userId uint) context.Context {
+	session := &models.Session{
+		Role:            entities.SUPERADMIN,
+		UserId:          &userId,
+		ChannelId:       channelId,
+		IsSystemSession: true,
+	}
+	return context.WithValue(context.Background(), pkgConst.SESSION, session)
+}
+
 func GetSession(ctx *context.Context) *models.Session {
 	val := util.ReadValueFromContext(ctx, pkgConst.SESSION)
 	session, ok := val.(*models.Session)
@@ -68,3 +85,36 @@ func GetSession(ctx *context.Context) *models.Session {
 	return session
 
 }
+
+// GenerateApiKey returns a random key with the prefix, eg: sfk_3f9c...
+func GenerateApiKey(prefix string) (string, error) {
+	key := make([]byte, 24)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return prefix + hex.EncodeToString(key), nil
+}
+
+// HashApiKey returns the SHA-256 of the key, only the hash of a key is stored
+func HashApiKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+// DeriveApiSecret returns the signing secret of an api key from the server secret key, so that the secret is never stored
+func DeriveApiSecret(serverSecretKey string, keyId string, salt string) string {
+	mac := hmac.New(sha256.New, []byte(serverSecretKey))
+	mac.Write([]byte(keyId + ":" + salt))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignRequest returns the HMAC-SHA256 signature of a request made with an api key.
+// The signed payload is "<timestamp>\n<METHOD>\n<request uri>\n<hex sha256 of the body>"
+func SignRequest(secret string, timestamp string, method string, requestURI string, body []byte) string {
+	bodyHash := sha256.Sum256(body)
+	payload := strings.Join([]string{timestamp, strings.ToUpper(method), requestURI, hex.EncodeToString(bodyHash[:])}, "\n")
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
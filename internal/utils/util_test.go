@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_HashApiKey(t *testing.T) {
+	require.Equal(t, "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad", HashApiKey("abc"))
+	require.NotEqual(t, HashApiKey("abc"), HashApiKey("abd"))
+}
+
+func Test_DeriveApiSecret(t *testing.T) {
+	secret := DeriveApiSecret("server-secret", "sfk_1", "salt")
+	require.Len(t, secret, 64)
+	require.Equal(t, secret, DeriveApiSecret("server-secret", "sfk_1", "salt"))
+
+	tests := []struct {
+		name            string
+		serverSecretKey string
+		keyId           string
+		salt            string
+	}{
+		{"another server secret key", "server-secret-2", "sfk_1", "salt"},
+		{"another key", "server-secret", "sfk_2", "salt"},
+		{"rotated salt", "server-secret", "sfk_1", "salt-2"},
+	}
+
+	for _, test := range tests {
+		require.NotEqual(t, secret, DeriveApiSecret(test.serverSecretKey, test.keyId, test.salt), test.name)
+	}
+}
+
+func Test_SignRequest(t *testing.T) {
+	body := []byte(`{"phoneNumber":"9876543210"}`)
+	signature := SignRequest("secret", "1760000000", "POST", "/external/enquiry", body)
+	require.Len(t, signature, 64)
+
+	// the method is signed in upper case
+	require.Equal(t, signature, SignRequest("secret", "1760000000", "post", "/external/enquiry", body))
+
+	tests := []struct {
+		name       string
+		secret     string
+		timestamp  string
+		method     string
+		requestURI string
+		body       []byte
+	}{
+		{"another secret", "secret-2", "1760000000", "POST", "/external/enquiry", body},
+		{"another timestamp", "secret", "1760000001", "POST", "/external/enquiry", body},
+		{"another method", "secret", "1760000000", "PUT", "/external/enquiry", body},
+		{"another uri", "secret", "1760000000", "POST", "/external/enquiry?source=instagram", body},
+		{"another body", "secret", "1760000000", "POST", "/external/enquiry", []byte(`{"phoneNumber":"9999999999"}`)},
+		{"no body", "secret", "1760000000", "POST", "/external/enquiry", nil},
+	}
+
+	for _, test := range tests {
+		require.NotEqual(t, signature, SignRequest(test.secret, test.timestamp, test.method, test.requestURI, test.body), test.name)
+	}
+}
@@ -0,0 +1,158 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/imkarthi24/sf-backend/internal/utils/measurement"
+	"github.com/loop-kar/pixie/errs"
+)
+
+// FieldError is the validation error of a single field
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// FieldErrors is set as the error of the XError so that every invalid field is reported at once
+type FieldErrors []FieldError
+
+func (e FieldErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, fieldErr := range e {
+		messages = append(messages, fmt.Sprintf("%s: %s", fieldErr.Field, fieldErr.Message))
+	}
+	return strings.Join(messages, "; ")
+}
+
+// WithPrefix prefixes the field of every error, eg: values.Waist -> [1].values.Waist
+func (e FieldErrors) WithPrefix(prefix string) FieldErrors {
+	prefixed := make(FieldErrors, 0, len(e))
+	for _, fieldErr := range e {
+		prefixed = append(prefixed, FieldError{Field: prefix + fieldErr.Field, Message: fieldErr.Message})
+	}
+	return prefixed
+}
+
+// ToXError returns nil when there are no errors
+func (e FieldErrors) ToXError(msg string) *errs.XError {
+	if len(e) == 0 {
+		return nil
+	}
+	return errs.NewXError(errs.VALIDATION, msg, e)
+}
+
+// ValidateMeasurementFields checks the measurement definitions of a dress type
+func ValidateMeasurementFields(fields entities.MeasurementFields) FieldErrors {
+	fieldErrs := FieldErrors{}
+	names := make(map[string]bool)
+
+	for i, field := range fields {
+		path := fmt.Sprintf("measurementFields[%d]", i)
+
+		name := strings.TrimSpace(field.Name)
+		if name == "" {
+			fieldErrs = append(fieldErrs, FieldError{Field: path + ".name", Message: "is required"})
+		} else if names[strings.ToLower(name)] {
+			fieldErrs = append(fieldErrs, FieldError{Field: path + ".name", Message: fmt.Sprintf("%s is defined more than once", name)})
+		}
+		names[strings.ToLower(name)] = true
+
+		if field.Unit != entities.MeasurementUnitCm && field.Unit != entities.MeasurementUnitInch {
+			fieldErrs = append(fieldErrs, FieldError{Field: path + ".unit", Message: fmt.Sprintf("should be %s or %s", entities.MeasurementUnitCm, entities.MeasurementUnitInch)})
+		}
+
+		if field.Min != nil && field.Max != nil && *field.Min > *field.Max {
+			fieldErrs = append(fieldErrs, FieldError{Field: path + ".min", Message: "should not be greater than max"})
+		}
+	}
+
+	return fieldErrs
+}
+
+// ValidateMeasurementValues checks the values of a measurement against the definitions of its dress type.
+// Values are a json object of measurement name to a number (or a numeric string) in the given unit,
+// they are converted to the unit of each definition before checking the limits.
+// Dress types without definitions are not validated.
+func ValidateMeasurementValues(fields entities.MeasurementFields, values json.RawMessage, unit entities.MeasurementUnit) FieldErrors {
+	fieldErrs := FieldErrors{}
+	if len(fields) == 0 {
+		return fieldErrs
+	}
+
+	parsed := make(map[string]interface{})
+	if len(values) > 0 && string(values) != "null" {
+		err := json.Unmarshal(values, &parsed)
+		if err != nil {
+			return append(fieldErrs, FieldError{Field: "values", Message: "should be an object of measurement name and value"})
+		}
+	}
+
+	definitions := make(map[string]entities.MeasurementField)
+	for _, field := range fields {
+		definitions[strings.ToLower(strings.TrimSpace(field.Name))] = field
+	}
+
+	provided := make(map[string]bool)
+	names := make([]string, 0, len(parsed))
+	for name := range parsed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		definition, ok := definitions[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			fieldErrs = append(fieldErrs, FieldError{Field: "values." + name, Message: "is not a measurement of the dress type"})
+			continue
+		}
+
+		value, isEmpty, err := measurementValue(parsed[name])
+		if isEmpty {
+			continue
+		}
+		provided[strings.ToLower(strings.TrimSpace(definition.Name))] = true
+
+		if err != nil {
+			fieldErrs = append(fieldErrs, FieldError{Field: "values." + name, Message: "should be a number"})
+			continue
+		}
+		value = measurement.Convert(value, unit, definition.Unit)
+		if definition.Min != nil && value < *definition.Min {
+			fieldErrs = append(fieldErrs, FieldError{Field: "values." + name, Message: fmt.Sprintf("should be at least %v %s", *definition.Min, definition.Unit)})
+		}
+		if definition.Max != nil && value > *definition.Max {
+			fieldErrs = append(fieldErrs, FieldError{Field: "values." + name, Message: fmt.Sprintf("should be at most %v %s", *definition.Max, definition.Unit)})
+		}
+	}
+
+	for _, field := range fields {
+		if field.Required && !provided[strings.ToLower(strings.TrimSpace(field.Name))] {
+			fieldErrs = append(fieldErrs, FieldError{Field: "values." + field.Name, Message: "is required"})
+		}
+	}
+
+	return fieldErrs
+}
+
+// measurementValue reads a number from a json value, empty strings and nulls are treated as not provided
+func measurementValue(value interface{}) (float64, bool, error) {
+	switch v := value.(type) {
+	case nil:
+		return 0, true, nil
+	case float64:
+		return v, false, nil
+	case string:
+		if strings.TrimSpace(v) == "" {
+			return 0, true, nil
+		}
+		number, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return number, false, err
+	default:
+		return 0, false, fmt.Errorf("invalid measurement value %v", v)
+	}
+}
@@ -0,0 +1,56 @@
+package validator
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/stretchr/testify/require"
+)
+
+func float(value float64) *float64 {
+	return &value
+}
+
+var blouseFields = entities.MeasurementFields{
+	{Name: "Waist", Unit: entities.MeasurementUnitInch, Required: true, Min: float(20), Max: float(60)},
+	{Name: "Chest", Unit: entities.MeasurementUnitInch, Required: true},
+	{Name: "Sleeve", Unit: entities.MeasurementUnitInch},
+}
+
+func Test_ValidateMeasurementValues(t *testing.T) {
+	fieldErrs := ValidateMeasurementValues(blouseFields, json.RawMessage(`{"Waist": 32, "chest": "36.5", "Sleeve": ""}`), entities.MeasurementUnitInch)
+	require.Empty(t, fieldErrs)
+
+	// values are converted to the unit of the definition before checking the limits
+	require.Empty(t, ValidateMeasurementValues(blouseFields, json.RawMessage(`{"Waist": 70, "Chest": 90}`), entities.MeasurementUnitCm))
+
+	fieldErrs = ValidateMeasurementValues(blouseFields, json.RawMessage(`{"Wasit": 32, "Waist": 70, "Sleeve": "long"}`), entities.MeasurementUnitInch)
+	require.Equal(t, FieldErrors{
+		{Field: "values.Sleeve", Message: "should be a number"},
+		{Field: "values.Waist", Message: "should be at most 60 inch"},
+		{Field: "values.Wasit", Message: "is not a measurement of the dress type"},
+		{Field: "values.Chest", Message: "is required"},
+	}, fieldErrs)
+
+	// dress types without definitions are not validated
+	require.Empty(t, ValidateMeasurementValues(nil, json.RawMessage(`{"Wasit": 32}`), entities.MeasurementUnitInch))
+}
+
+func Test_ValidateMeasurementFields(t *testing.T) {
+	require.Empty(t, ValidateMeasurementFields(blouseFields))
+
+	fieldErrs := ValidateMeasurementFields(entities.MeasurementFields{
+		{Name: "Waist", Unit: "mm", Min: float(10), Max: float(5)},
+		{Name: "waist", Unit: entities.MeasurementUnitCm},
+	})
+	require.Equal(t, FieldErrors{
+		{Field: "measurementFields[0].unit", Message: "should be cm or inch"},
+		{Field: "measurementFields[0].min", Message: "should not be greater than max"},
+		{Field: "measurementFields[1].name", Message: "waist is defined more than once"},
+	}, fieldErrs)
+
+	require.Contains(t, fieldErrs.WithPrefix("[1].").Error(), "[1].measurementFields[0].unit: should be cm or inch")
+	require.NotNil(t, fieldErrs.ToXError("Invalid measurement fields"))
+	require.Nil(t, FieldErrors{}.ToXError("Invalid measurement fields"))
+}
@@ -0,0 +1,33 @@
+package validator
+
+import (
+	"net/mail"
+	"regexp"
+	"strings"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+)
+
+// gstinPattern is the 15 character GSTIN: state code, PAN, entity number, Z and a check character
+var gstinPattern = regexp.MustCompile(`^[0-9]{2}[A-Z]{5}[0-9]{4}[A-Z][1-9A-Z]Z[0-9A-Z]$`)
+
+// ValidateVendor checks the name, email and GSTIN of a vendor
+func ValidateVendor(vendor entities.Vendor) FieldErrors {
+	fieldErrs := make(FieldErrors, 0)
+
+	if strings.TrimSpace(vendor.Name) == "" {
+		fieldErrs = append(fieldErrs, FieldError{Field: "name", Message: "is required"})
+	}
+
+	if vendor.Email != nil && *vendor.Email != "" {
+		if _, err := mail.ParseAddress(*vendor.Email); err != nil {
+			fieldErrs = append(fieldErrs, FieldError{Field: "email", Message: "is not a valid email"})
+		}
+	}
+
+	if vendor.GSTIN != nil && *vendor.GSTIN != "" && !gstinPattern.MatchString(*vendor.GSTIN) {
+		fieldErrs = append(fieldErrs, FieldError{Field: "gstin", Message: "should be a 15 character GSTIN, eg: 33AAAAA0000A1Z5"})
+	}
+
+	return fieldErrs
+}
@@ -0,0 +1,25 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/imkarthi24/sf-backend/internal/entities"
+	"github.com/stretchr/testify/require"
+)
+
+func str(value string) *string {
+	return &value
+}
+
+func Test_ValidateVendor(t *testing.T) {
+	require.Empty(t, ValidateVendor(entities.Vendor{Name: "Sri Textiles", GSTIN: str("33AABCS1429B1Z6"), Email: str("sales@sritextiles.in")}))
+
+	// contact details are optional
+	require.Empty(t, ValidateVendor(entities.Vendor{Name: "Sri Textiles", GSTIN: str("")}))
+
+	require.Equal(t, FieldErrors{
+		{Field: "name", Message: "is required"},
+		{Field: "email", Message: "is not a valid email"},
+		{Field: "gstin", Message: "should be a 15 character GSTIN, eg: 33AAAAA0000A1Z5"},
+	}, ValidateVendor(entities.Vendor{Name: " ", GSTIN: str("33aabcs1429b1z6"), Email: str("sales")}))
+}
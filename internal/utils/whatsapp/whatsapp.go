@@ -0,0 +1,168 @@
+package whatsapp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	ProviderHTTP = "http"
+	ProviderFake = "fake"
+)
+
+// Message is a plain text WhatsApp message.
+// To is the recipient number along with the country code, eg: 919876543210
+type Message struct {
+	To   string
+	Body string
+}
+
+// Sender delivers WhatsApp messages.
+// Implementations are expected to be safe for concurrent use.
+type Sender interface {
+	SendMessage(Message) error
+}
+
+type Config struct {
+	BaseURL       string
+	PhoneNumberId string
+	AccessToken   string
+	Timeout       time.Duration
+}
+
+// NewSender returns the sender for the provider, the HTTP Business-API sender is used by default
+func NewSender(provider string, cfg Config) Sender {
+	if strings.EqualFold(provider, ProviderFake) {
+		return NewFakeSender()
+	}
+	return NewHTTPSender(cfg)
+}
+
+// FormatNumber joins the country code and number, dropping everything but the digits
+func FormatNumber(countryCode string, number string) string {
+	digits := func(value string) string {
+		return strings.Map(func(r rune) rune {
+			if r >= '0' && r <= '9' {
+				return r
+			}
+			return -1
+		}, value)
+	}
+
+	number = digits(number)
+	countryCode = digits(countryCode)
+	if countryCode == "" || (strings.HasPrefix(number, countryCode) && len(number) > 10) {
+		return number
+	}
+	return countryCode + number
+}
+
+// httpSender sends text messages through the WhatsApp Business Cloud API.
+// Free form text is delivered only within the customer service window,
+// outside it the API rejects the message and the error is returned to the caller.
+type httpSender struct {
+	config Config
+	client *http.Client
+}
+
+func NewHTTPSender(cfg Config) Sender {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 15 * time.Second
+	}
+	return &httpSender{
+		config: cfg,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+type textMessageRequest struct {
+	MessagingProduct string      `json:"messaging_product"`
+	RecipientType    string      `json:"recipient_type"`
+	To               string      `json:"to"`
+	Type             string      `json:"type"`
+	Text             textMessage `json:"text"`
+}
+
+type textMessage struct {
+	PreviewURL bool   `json:"preview_url"`
+	Body       string `json:"body"`
+}
+
+func (s *httpSender) SendMessage(msg Message) error {
+	if s.config.BaseURL == "" || s.config.PhoneNumberId == "" || s.config.AccessToken == "" {
+		return fmt.Errorf("whatsapp sender is not configured")
+	}
+	if msg.To == "" {
+		return fmt.Errorf("whatsapp recipient is empty")
+	}
+
+	payload, err := json.Marshal(textMessageRequest{
+		MessagingProduct: "whatsapp",
+		RecipientType:    "individual",
+		To:               msg.To,
+		Type:             "text",
+		Text:             textMessage{Body: msg.Body},
+	})
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/%s/messages", strings.TrimSuffix(s.config.BaseURL, "/"), s.config.PhoneNumberId)
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.config.AccessToken)
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("whatsapp api returned %d: %s", res.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
+
+// FakeSender records the messages instead of sending them.
+// Used for local development and tests, set Err to simulate a failed delivery.
+type FakeSender struct {
+	mu   sync.Mutex
+	sent []Message
+	Err  error
+}
+
+func NewFakeSender() *FakeSender {
+	return &FakeSender{}
+}
+
+func (s *FakeSender) SendMessage(msg Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.Err != nil {
+		return s.Err
+	}
+	s.sent = append(s.sent, msg)
+	return nil
+}
+
+// Sent returns the messages recorded so far
+func (s *FakeSender) Sent() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Message(nil), s.sent...)
+}
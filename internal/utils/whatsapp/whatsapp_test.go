@@ -0,0 +1,63 @@
+package whatsapp
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FormatNumber(t *testing.T) {
+	require.Equal(t, "919876543210", FormatNumber("+91", "98765 43210"))
+	require.Equal(t, "919876543210", FormatNumber("91", "+91 98765-43210"))
+	require.Equal(t, "9876543210", FormatNumber("", "9876543210"))
+}
+
+func Test_HTTPSender(t *testing.T) {
+	var received textMessageRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/12345/messages", r.URL.Path)
+		require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sender := NewHTTPSender(Config{BaseURL: server.URL, PhoneNumberId: "12345", AccessToken: "token"})
+
+	err := sender.SendMessage(Message{To: "919876543210", Body: "Your order is ready"})
+	require.NoError(t, err)
+	require.Equal(t, "whatsapp", received.MessagingProduct)
+	require.Equal(t, "919876543210", received.To)
+	require.Equal(t, "Your order is ready", received.Text.Body)
+}
+
+func Test_HTTPSender_Failure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"message":"invalid recipient"}}`))
+	}))
+	defer server.Close()
+
+	sender := NewHTTPSender(Config{BaseURL: server.URL, PhoneNumberId: "12345", AccessToken: "token"})
+
+	err := sender.SendMessage(Message{To: "91", Body: "Hi"})
+	require.ErrorContains(t, err, "invalid recipient")
+
+	err = NewHTTPSender(Config{}).SendMessage(Message{To: "919876543210", Body: "Hi"})
+	require.Error(t, err)
+}
+
+func Test_FakeSender(t *testing.T) {
+	sender := NewFakeSender()
+
+	require.NoError(t, sender.SendMessage(Message{To: "919876543210", Body: "Hi"}))
+	require.Len(t, sender.Sent(), 1)
+
+	sender.Err = errors.New("unavailable")
+	require.Error(t, sender.SendMessage(Message{To: "919876543210", Body: "Hi"}))
+	require.Len(t, sender.Sent(), 1)
+}
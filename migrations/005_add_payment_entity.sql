@@ -0,0 +1,40 @@
+-- Migration: 005_add_payment_entity
+-- Generated: 2026-10-18T10:12:41+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Create table: stich.Payments
+CREATE TABLE IF NOT EXISTS stich."Payments" (
+  id BIGSERIAL NOT NULL,
+  created_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ,
+  is_active BOOL DEFAULT true,
+  created_by_id INTEGER,
+  updated_by_id INTEGER,
+  channel_id INTEGER,
+  amount DOUBLE PRECISION NOT NULL,
+  payment_type TEXT NOT NULL,
+  payment_method TEXT NOT NULL,
+  payment_date TIMESTAMPTZ,
+  reference_number TEXT,
+  notes TEXT,
+  order_id INTEGER,
+  received_by_id INTEGER,
+  PRIMARY KEY (id)
+);
+
+
+-- Add foreign key to stich.Payments
+ALTER TABLE stich."Payments" ADD CONSTRAINT fk_Payment_order_id FOREIGN KEY (order_id) REFERENCES stich."Orders" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+-- Add foreign key to stich.Payments
+ALTER TABLE stich."Payments" ADD CONSTRAINT fk_Payment_received_by_id FOREIGN KEY (received_by_id) REFERENCES stich."Users" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- TODO: Add rollback statements manually
@@ -0,0 +1,60 @@
+-- Migration: 006_add_invoice_entity
+-- Generated: 2026-10-18T11:02:17+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Create table: stich.Invoices
+CREATE TABLE IF NOT EXISTS stich."Invoices" (
+  id BIGSERIAL NOT NULL,
+  created_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ,
+  is_active BOOL DEFAULT true,
+  created_by_id INTEGER,
+  updated_by_id INTEGER,
+  channel_id INTEGER,
+  sequence INTEGER NOT NULL,
+  invoice_number TEXT NOT NULL,
+  invoice_date TIMESTAMPTZ NOT NULL,
+  order_id INTEGER,
+  PRIMARY KEY (id)
+);
+
+
+-- Add foreign key to stich.Invoices
+ALTER TABLE stich."Invoices" ADD CONSTRAINT fk_Invoice_order_id FOREIGN KEY (order_id) REFERENCES stich."Orders" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+-- Invoice numbers are sequential per channel
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invoice_channel_sequence ON stich."Invoices" (channel_id, sequence);
+
+-- An order has a single invoice
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invoice_order_id ON stich."Invoices" (order_id);
+
+-- Create table: stich.InvoiceSequences
+CREATE TABLE IF NOT EXISTS stich."InvoiceSequences" (
+  id BIGSERIAL NOT NULL,
+  created_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ,
+  is_active BOOL DEFAULT true,
+  created_by_id INTEGER,
+  updated_by_id INTEGER,
+  channel_id INTEGER,
+  last_sequence INTEGER NOT NULL,
+  PRIMARY KEY (id)
+);
+
+-- The invoice sequences are allocated from a single counter row of the channel
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invoice_sequence_channel_id ON stich."InvoiceSequences" (channel_id);
+
+-- Continue from the invoices already issued
+INSERT INTO stich."InvoiceSequences" (channel_id, last_sequence, is_active, created_at, updated_at)
+SELECT channel_id, MAX(sequence), true, NOW(), NOW() FROM stich."Invoices" GROUP BY channel_id
+ON CONFLICT (channel_id) DO NOTHING;
+
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- TODO: Add rollback statements manually
@@ -0,0 +1,16 @@
+-- Migration: 007_order_history_reason
+-- Generated: 2026-10-18T11:48:09+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Add column to stich.OrderHistories
+ALTER TABLE stich."OrderHistories" ADD COLUMN reason TEXT;
+
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- TODO: Add rollback statements manually
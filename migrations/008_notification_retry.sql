@@ -0,0 +1,17 @@
+-- Migration: 008_notification_retry
+-- Generated: 2026-10-18T12:20:41+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Add column to stich.Notifications
+ALTER TABLE stich."Notifications" ADD COLUMN attempt_count BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE stich."Notifications" ADD COLUMN next_attempt_at TIMESTAMPTZ;
+
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- TODO: Add rollback statements manually
@@ -0,0 +1,39 @@
+-- Migration: 009_add_attachment_entity
+-- Generated: 2026-10-18T13:05:22+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Create table: stich.Attachments
+CREATE TABLE IF NOT EXISTS stich."Attachments" (
+  id BIGSERIAL NOT NULL,
+  created_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ,
+  is_active BOOL DEFAULT true,
+  created_by_id INTEGER,
+  updated_by_id INTEGER,
+  channel_id INTEGER,
+  entity_type TEXT NOT NULL,
+  entity_id BIGINT NOT NULL,
+  kind TEXT,
+  file_name TEXT NOT NULL,
+  content_type TEXT,
+  size BIGINT,
+  storage_key TEXT NOT NULL,
+  uploaded_by_id INTEGER,
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_attachments_entity ON stich."Attachments" (entity_type, entity_id);
+
+
+-- Add foreign key to stich.Attachments
+ALTER TABLE stich."Attachments" ADD CONSTRAINT fk_Attachment_uploaded_by_id FOREIGN KEY (uploaded_by_id) REFERENCES stich."Users" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- TODO: Add rollback statements manually
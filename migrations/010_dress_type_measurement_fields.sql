@@ -0,0 +1,16 @@
+-- Migration: 010_dress_type_measurement_fields
+-- Generated: 2026-10-18T14:02:17+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Add column to stich.DressTypes
+ALTER TABLE stich."DressTypes" ADD COLUMN measurement_fields JSONB;
+
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- TODO: Add rollback statements manually
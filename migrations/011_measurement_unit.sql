@@ -0,0 +1,19 @@
+-- Migration: 011_measurement_unit
+-- Generated: 2026-10-18T15:11:42+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Add column to stich.Measurements
+ALTER TABLE stich."Measurements" ADD COLUMN unit TEXT;
+
+-- Add column to stich.MeasurementHistories
+ALTER TABLE stich."MeasurementHistories" ADD COLUMN unit TEXT;
+
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- TODO: Add rollback statements manually
@@ -0,0 +1,38 @@
+-- Migration: 012_add_audit_log_entity
+-- Generated: 2026-10-18T16:03:51+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Create table: stich.AuditLogs
+CREATE TABLE IF NOT EXISTS stich."AuditLogs" (
+  id BIGSERIAL NOT NULL,
+  created_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ,
+  is_active BOOL DEFAULT true,
+  created_by_id INTEGER,
+  updated_by_id INTEGER,
+  channel_id INTEGER,
+  entity_name TEXT NOT NULL,
+  entity_id BIGINT,
+  action TEXT NOT NULL,
+  changes JSONB,
+  actor_id INTEGER,
+  performed_at TIMESTAMPTZ NOT NULL,
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_entity ON stich."AuditLogs" (entity_name, entity_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_channel_performed_at ON stich."AuditLogs" (channel_id, performed_at);
+
+
+-- Add foreign key to stich.AuditLogs
+ALTER TABLE stich."AuditLogs" ADD CONSTRAINT fk_AuditLog_actor_id FOREIGN KEY (actor_id) REFERENCES stich."Users" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- TODO: Add rollback statements manually
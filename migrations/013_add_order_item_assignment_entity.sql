@@ -0,0 +1,44 @@
+-- Migration: 013_add_order_item_assignment_entity
+-- Generated: 2026-10-18T17:12:40+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Create table: stich.OrderItemAssignments
+CREATE TABLE IF NOT EXISTS stich."OrderItemAssignments" (
+  id BIGSERIAL NOT NULL,
+  created_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ,
+  is_active BOOL DEFAULT true,
+  created_by_id INTEGER,
+  updated_by_id INTEGER,
+  channel_id INTEGER,
+  stage TEXT NOT NULL,
+  status TEXT NOT NULL,
+  quantity INTEGER,
+  piece_rate DOUBLE PRECISION,
+  due_date TIMESTAMPTZ,
+  started_at TIMESTAMPTZ,
+  completed_at TIMESTAMPTZ,
+  notes TEXT,
+  order_item_id INTEGER NOT NULL,
+  assigned_to_id INTEGER NOT NULL,
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_item_assignments_order_item_id ON stich."OrderItemAssignments" (order_item_id);
+CREATE INDEX IF NOT EXISTS idx_order_item_assignments_assigned_to_status ON stich."OrderItemAssignments" (assigned_to_id, status);
+
+
+-- Add foreign key to stich.OrderItemAssignments
+ALTER TABLE stich."OrderItemAssignments" ADD CONSTRAINT fk_OrderItemAssignment_order_item_id FOREIGN KEY (order_item_id) REFERENCES stich."OrderItems" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+ALTER TABLE stich."OrderItemAssignments" ADD CONSTRAINT fk_OrderItemAssignment_assigned_to_id FOREIGN KEY (assigned_to_id) REFERENCES stich."Users" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- TODO: Add rollback statements manually
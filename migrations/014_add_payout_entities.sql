@@ -0,0 +1,75 @@
+-- Migration: 014_add_payout_entities
+-- Generated: 2026-10-18T18:05:12+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Create table: stich.PayoutSettlements
+CREATE TABLE IF NOT EXISTS stich."PayoutSettlements" (
+  id BIGSERIAL NOT NULL,
+  created_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ,
+  is_active BOOL DEFAULT true,
+  created_by_id INTEGER,
+  updated_by_id INTEGER,
+  channel_id INTEGER,
+  amount DOUBLE PRECISION NOT NULL,
+  entry_count INTEGER,
+  period_from TIMESTAMPTZ,
+  period_to TIMESTAMPTZ NOT NULL,
+  settled_at TIMESTAMPTZ NOT NULL,
+  payment_method TEXT NOT NULL,
+  reference_number TEXT,
+  notes TEXT,
+  tailor_id INTEGER NOT NULL,
+  settled_by_id INTEGER,
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_payout_settlements_tailor_id_settled_at ON stich."PayoutSettlements" (tailor_id, settled_at);
+
+-- Create table: stich.PayoutEntries
+CREATE TABLE IF NOT EXISTS stich."PayoutEntries" (
+  id BIGSERIAL NOT NULL,
+  created_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ,
+  is_active BOOL DEFAULT true,
+  created_by_id INTEGER,
+  updated_by_id INTEGER,
+  channel_id INTEGER,
+  type TEXT NOT NULL,
+  amount DOUBLE PRECISION NOT NULL,
+  quantity INTEGER,
+  piece_rate DOUBLE PRECISION,
+  description TEXT,
+  accrued_at TIMESTAMPTZ NOT NULL,
+  tailor_id INTEGER NOT NULL,
+  assignment_id INTEGER NOT NULL,
+  settlement_id INTEGER,
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_payout_entries_tailor_id_accrued_at ON stich."PayoutEntries" (tailor_id, accrued_at);
+CREATE INDEX IF NOT EXISTS idx_payout_entries_assignment_id ON stich."PayoutEntries" (assignment_id);
+CREATE INDEX IF NOT EXISTS idx_payout_entries_settlement_id ON stich."PayoutEntries" (settlement_id);
+
+
+-- Add foreign key to stich.PayoutSettlements
+ALTER TABLE stich."PayoutSettlements" ADD CONSTRAINT fk_PayoutSettlement_tailor_id FOREIGN KEY (tailor_id) REFERENCES stich."Users" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+ALTER TABLE stich."PayoutSettlements" ADD CONSTRAINT fk_PayoutSettlement_settled_by_id FOREIGN KEY (settled_by_id) REFERENCES stich."Users" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+-- Add foreign key to stich.PayoutEntries
+ALTER TABLE stich."PayoutEntries" ADD CONSTRAINT fk_PayoutEntry_tailor_id FOREIGN KEY (tailor_id) REFERENCES stich."Users" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+ALTER TABLE stich."PayoutEntries" ADD CONSTRAINT fk_PayoutEntry_assignment_id FOREIGN KEY (assignment_id) REFERENCES stich."OrderItemAssignments" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+ALTER TABLE stich."PayoutEntries" ADD CONSTRAINT fk_PayoutEntry_settlement_id FOREIGN KEY (settlement_id) REFERENCES stich."PayoutSettlements" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- TODO: Add rollback statements manually
@@ -0,0 +1,64 @@
+-- Migration: 015_add_inventory_entities
+-- Generated: 2026-10-18T19:02:47+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Create table: stich.Materials
+CREATE TABLE IF NOT EXISTS stich."Materials" (
+  id BIGSERIAL NOT NULL,
+  created_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ,
+  is_active BOOL DEFAULT true,
+  created_by_id INTEGER,
+  updated_by_id INTEGER,
+  channel_id INTEGER,
+  name TEXT NOT NULL,
+  code TEXT,
+  category TEXT,
+  unit TEXT NOT NULL,
+  notes TEXT,
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_materials_channel_id_name ON stich."Materials" (channel_id, name);
+
+-- Create table: stich.StockMovements
+CREATE TABLE IF NOT EXISTS stich."StockMovements" (
+  id BIGSERIAL NOT NULL,
+  created_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ,
+  is_active BOOL DEFAULT true,
+  created_by_id INTEGER,
+  updated_by_id INTEGER,
+  channel_id INTEGER,
+  type TEXT NOT NULL,
+  quantity DOUBLE PRECISION NOT NULL,
+  unit_cost DOUBLE PRECISION,
+  moved_at TIMESTAMPTZ NOT NULL,
+  notes TEXT,
+  material_id INTEGER NOT NULL,
+  expense_id INTEGER,
+  order_item_id INTEGER,
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_movements_material_id ON stich."StockMovements" (material_id);
+CREATE INDEX IF NOT EXISTS idx_stock_movements_expense_id ON stich."StockMovements" (expense_id);
+CREATE INDEX IF NOT EXISTS idx_stock_movements_order_item_id ON stich."StockMovements" (order_item_id);
+
+
+-- Add foreign key to stich.StockMovements
+ALTER TABLE stich."StockMovements" ADD CONSTRAINT fk_StockMovement_material_id FOREIGN KEY (material_id) REFERENCES stich."Materials" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+ALTER TABLE stich."StockMovements" ADD CONSTRAINT fk_StockMovement_expense_id FOREIGN KEY (expense_id) REFERENCES stich."Expenses" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+ALTER TABLE stich."StockMovements" ADD CONSTRAINT fk_StockMovement_order_item_id FOREIGN KEY (order_item_id) REFERENCES stich."OrderItems" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- TODO: Add rollback statements manually
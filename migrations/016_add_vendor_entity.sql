@@ -0,0 +1,73 @@
+-- Migration: 016_add_vendor_entity
+-- Generated: 2026-10-18T21:14:05+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Create table: stich.Vendors
+CREATE TABLE IF NOT EXISTS stich."Vendors" (
+  id BIGSERIAL NOT NULL,
+  created_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ,
+  is_active BOOL DEFAULT true,
+  created_by_id INTEGER,
+  updated_by_id INTEGER,
+  channel_id INTEGER,
+  name TEXT NOT NULL,
+  contact_person TEXT,
+  phone_number TEXT,
+  email TEXT,
+  gstin TEXT,
+  address TEXT,
+  notes TEXT,
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_vendors_channel_id_name ON stich."Vendors" (channel_id, name);
+
+-- Add column: stich.Expenses.vendor_id
+ALTER TABLE stich."Expenses" ADD COLUMN IF NOT EXISTS vendor_id INTEGER;
+
+CREATE INDEX IF NOT EXISTS idx_expenses_vendor_id ON stich."Expenses" (vendor_id);
+
+
+-- Add foreign key to stich.Expenses
+ALTER TABLE stich."Expenses" ADD CONSTRAINT fk_Expense_vendor_id FOREIGN KEY (vendor_id) REFERENCES stich."Vendors" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+
+-- Create a vendor for each company billed in the existing expenses of a channel.
+-- Company names differing only in case, spaces or punctuation are the same vendor, named with the most used spelling.
+INSERT INTO stich."Vendors" (created_at, updated_at, is_active, channel_id, name, address)
+SELECT NOW(), NOW(), true, channel_id, company_name, location
+FROM (
+  SELECT channel_id, company_name, location,
+    ROW_NUMBER() OVER (
+      PARTITION BY channel_id, name_key
+      ORDER BY spelling_count DESC, created_at DESC
+    ) AS rank
+  FROM (
+    SELECT channel_id, company_name, location, created_at,
+      LOWER(REGEXP_REPLACE(company_name, '[^[:alnum:]]', '', 'g')) AS name_key,
+      COUNT(*) OVER (PARTITION BY channel_id, company_name) AS spelling_count
+    FROM stich."Expenses"
+    WHERE is_active = true
+      AND LOWER(REGEXP_REPLACE(COALESCE(company_name, ''), '[^[:alnum:]]', '', 'g')) <> ''
+  ) spellings
+) companies
+WHERE rank = 1;
+
+-- Link the existing expenses to their vendor and align the company name
+UPDATE stich."Expenses" E
+SET vendor_id = V.id, company_name = V.name
+FROM stich."Vendors" V
+WHERE V.channel_id IS NOT DISTINCT FROM E.channel_id
+  AND LOWER(REGEXP_REPLACE(V.name, '[^[:alnum:]]', '', 'g')) = LOWER(REGEXP_REPLACE(E.company_name, '[^[:alnum:]]', '', 'g'))
+  AND E.vendor_id IS NULL;
+
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- TODO: Add rollback statements manually
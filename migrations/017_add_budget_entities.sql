@@ -0,0 +1,92 @@
+-- Migration: 017_add_budget_entities
+-- Generated: 2026-10-18T22:41:37+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Create table: stich.ExpenseCategories
+CREATE TABLE IF NOT EXISTS stich."ExpenseCategories" (
+  id BIGSERIAL NOT NULL,
+  created_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ,
+  is_active BOOL DEFAULT true,
+  created_by_id INTEGER,
+  updated_by_id INTEGER,
+  channel_id INTEGER,
+  name TEXT NOT NULL,
+  description TEXT,
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_expense_categories_channel_id_name ON stich."ExpenseCategories" (channel_id, name);
+
+-- Create table: stich.RecurringExpenses
+CREATE TABLE IF NOT EXISTS stich."RecurringExpenses" (
+  id BIGSERIAL NOT NULL,
+  created_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ,
+  is_active BOOL DEFAULT true,
+  created_by_id INTEGER,
+  updated_by_id INTEGER,
+  channel_id INTEGER,
+  name TEXT NOT NULL,
+  frequency TEXT NOT NULL,
+  start_date TIMESTAMPTZ NOT NULL,
+  end_date TIMESTAMPTZ,
+  next_due_date TIMESTAMPTZ NOT NULL,
+  company_name TEXT,
+  material TEXT,
+  price DOUBLE PRECISION,
+  location TEXT,
+  notes TEXT,
+  category_id INTEGER,
+  vendor_id INTEGER,
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_recurring_expenses_next_due_date ON stich."RecurringExpenses" (next_due_date);
+
+-- Create table: stich.ExpenseBudgets
+CREATE TABLE IF NOT EXISTS stich."ExpenseBudgets" (
+  id BIGSERIAL NOT NULL,
+  created_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ,
+  is_active BOOL DEFAULT true,
+  created_by_id INTEGER,
+  updated_by_id INTEGER,
+  channel_id INTEGER,
+  month TIMESTAMPTZ NOT NULL,
+  amount DOUBLE PRECISION NOT NULL,
+  notes TEXT,
+  category_id INTEGER NOT NULL,
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_expense_budgets_channel_id_month ON stich."ExpenseBudgets" (channel_id, month);
+
+-- Add columns to stich.Expenses
+ALTER TABLE stich."Expenses" ADD COLUMN IF NOT EXISTS category_id INTEGER;
+ALTER TABLE stich."Expenses" ADD COLUMN IF NOT EXISTS recurring_expense_id INTEGER;
+
+CREATE INDEX IF NOT EXISTS idx_expenses_category_id ON stich."Expenses" (category_id);
+CREATE INDEX IF NOT EXISTS idx_expenses_recurring_expense_id ON stich."Expenses" (recurring_expense_id);
+
+
+-- Add foreign key to stich.RecurringExpenses
+ALTER TABLE stich."RecurringExpenses" ADD CONSTRAINT fk_RecurringExpense_category_id FOREIGN KEY (category_id) REFERENCES stich."ExpenseCategories" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+ALTER TABLE stich."RecurringExpenses" ADD CONSTRAINT fk_RecurringExpense_vendor_id FOREIGN KEY (vendor_id) REFERENCES stich."Vendors" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+-- Add foreign key to stich.ExpenseBudgets
+ALTER TABLE stich."ExpenseBudgets" ADD CONSTRAINT fk_ExpenseBudget_category_id FOREIGN KEY (category_id) REFERENCES stich."ExpenseCategories" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+-- Add foreign key to stich.Expenses
+ALTER TABLE stich."Expenses" ADD CONSTRAINT fk_Expense_category_id FOREIGN KEY (category_id) REFERENCES stich."ExpenseCategories" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- TODO: Add rollback statements manually
@@ -0,0 +1,46 @@
+-- Migration: 018_add_appointment_entity
+-- Generated: 2026-10-18T23:27:12+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Create table: stich.Appointments
+CREATE TABLE IF NOT EXISTS stich."Appointments" (
+  id BIGSERIAL NOT NULL,
+  created_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ,
+  is_active BOOL DEFAULT true,
+  created_by_id INTEGER,
+  updated_by_id INTEGER,
+  channel_id INTEGER,
+  type TEXT NOT NULL,
+  status TEXT NOT NULL,
+  start_time TIMESTAMPTZ NOT NULL,
+  end_time TIMESTAMPTZ NOT NULL,
+  notes TEXT,
+  reminder_queued_at TIMESTAMPTZ,
+  customer_id INTEGER NOT NULL,
+  order_id INTEGER,
+  staff_id INTEGER NOT NULL,
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_appointments_channel_id_start_time ON stich."Appointments" (channel_id, start_time);
+CREATE INDEX IF NOT EXISTS idx_appointments_staff_id_start_time ON stich."Appointments" (staff_id, start_time);
+CREATE INDEX IF NOT EXISTS idx_appointments_customer_id ON stich."Appointments" (customer_id);
+
+
+-- Add foreign key to stich.Appointments
+ALTER TABLE stich."Appointments" ADD CONSTRAINT fk_Appointment_customer_id FOREIGN KEY (customer_id) REFERENCES stich."Customers" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+ALTER TABLE stich."Appointments" ADD CONSTRAINT fk_Appointment_order_id FOREIGN KEY (order_id) REFERENCES stich."Orders" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+ALTER TABLE stich."Appointments" ADD CONSTRAINT fk_Appointment_staff_id FOREIGN KEY (staff_id) REFERENCES stich."Users" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- TODO: Add rollback statements manually
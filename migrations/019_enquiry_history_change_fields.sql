@@ -0,0 +1,19 @@
+-- Migration: 019_enquiry_history_change_fields
+-- Generated: 2026-10-18T23:58:41+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Add column to stich.EnquiryHistories
+ALTER TABLE stich."EnquiryHistories" ADD COLUMN old_status TEXT;
+
+-- Add column to stich.EnquiryHistories
+ALTER TABLE stich."EnquiryHistories" ADD COLUMN changed_fields TEXT;
+
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- TODO: Add rollback statements manually
@@ -0,0 +1,40 @@
+-- Migration: 020_add_api_key_entity
+-- Generated: 2026-10-19T00:48:05+05:30
+
+-- ====================================
+-- UP Migration
+-- ====================================
+
+-- Create table: stich.ApiKeys
+CREATE TABLE IF NOT EXISTS stich."ApiKeys" (
+  id BIGSERIAL NOT NULL,
+  created_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ,
+  is_active BOOL DEFAULT true,
+  created_by_id INTEGER,
+  updated_by_id INTEGER,
+  channel_id INTEGER,
+  name TEXT NOT NULL,
+  key_id TEXT NOT NULL,
+  salt TEXT NOT NULL,
+  secret_hash TEXT NOT NULL,
+  scopes TEXT NOT NULL,
+  expires_at TIMESTAMPTZ,
+  last_used_at TIMESTAMPTZ,
+  revoked_at TIMESTAMPTZ,
+  PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_ApiKeys_key_id" ON stich."ApiKeys" (key_id);
+CREATE INDEX IF NOT EXISTS idx_api_keys_channel_id ON stich."ApiKeys" (channel_id);
+
+
+-- Add foreign key to stich.ApiKeys
+ALTER TABLE stich."ApiKeys" ADD CONSTRAINT fk_ApiKey_channel_id FOREIGN KEY (channel_id) REFERENCES stich."Channels" (id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+
+-- ====================================
+-- DOWN Migration (Rollback)
+-- ====================================
+
+-- TODO: Add rollback statements manually